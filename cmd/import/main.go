@@ -0,0 +1,117 @@
+// main.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"song_library/configs"
+	"song_library/internal/app"
+	"song_library/internal/importer"
+	"song_library/internal/models"
+	"song_library/internal/utils"
+)
+
+const usage = `Использование: import [флаги] <файл>
+
+Загружает песни из CSV, JSON-массива или NDJSON. Вместо файла можно указать -,
+чтобы читать стандартный ввод; тогда формат задаётся флагом -format.
+
+Флаги:`
+
+func main() {
+	utils.LoadEnv()
+
+	var options models.ImportOptions
+	var format, onDuplicate, user string
+	flag.StringVar(&format, "format", "", "формат файла: csv, json или ndjson (по умолчанию - по расширению)")
+	flag.StringVar(&onDuplicate, "on-duplicate", string(models.DuplicateSkip), "что делать с песнями, которые уже есть: skip, overwrite или merge")
+	flag.BoolVar(&options.DryRun, "dry-run", false, "только проверить файл, ничего не сохраняя")
+	flag.BoolVar(&options.Enrich, "enrich", false, "поставить песни с пустыми датой, текстом или ссылкой в очередь обогащения; её разберёт сервер (ENRICH_PROVIDERS)")
+	flag.StringVar(&user, "user", "", "автор изменений для истории ревизий")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	options.Format = models.FileFormat(format)
+	options.OnDuplicate = models.DuplicatePolicy(onDuplicate)
+
+	loadConfig := configs.LoadDatabaseConfig
+	if options.Enrich {
+		loadConfig = configs.LoadConfig
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		utils.GetLogger().Fatalf("Не удалось загрузить конфигурацию: %v", err)
+	}
+	logger := utils.InitLogger(cfg.LogLevel)
+
+	if options.Format == "" {
+		detected, ok := importer.DetectFormat("", path)
+		if !ok {
+			logger.Fatalf("Не удалось определить формат файла %s, укажите -format", path)
+		}
+		options.Format = detected
+	}
+
+	var input io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			logger.Fatalf("Не удалось открыть файл: %v", err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	db, err := app.OpenDatabase(cfg)
+	if err != nil {
+		logger.Fatalf("Не удалось подключиться к базе данных: %v", err)
+	}
+	if db == nil {
+		logger.Fatalf("Драйвер %s не сохраняет данные между запусками, импорт не имеет смысла", cfg.DBDriver)
+	}
+
+	importService, err := app.NewImportService(cfg, db)
+	if err != nil {
+		logger.Fatalf("Схема базы данных не готова (выполните migrate up): %v", err)
+	}
+
+	report, err := importService.Import(input, options, user)
+	if err != nil {
+		logger.Fatalf("Ошибка импорта: %v", err)
+	}
+
+	printReport(filepath.Base(path), report)
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func printReport(name string, report *models.ImportReport) {
+	for _, row := range report.Rows {
+		line := fmt.Sprintf("%6d  %-8s  %s - %s", row.Row, row.Status, row.GroupName, row.SongTitle)
+		if row.Reason != "" {
+			line += ": " + row.Reason
+		}
+		fmt.Println(line)
+	}
+
+	mode := ""
+	if report.DryRun {
+		mode = " (проверка, ничего не сохранено)"
+	}
+	fmt.Printf("%s%s: всего %d, создано %d, обновлено %d, пропущено %d, ошибок %d\n",
+		name, mode, report.Total, report.Created, report.Updated, report.Skipped, report.Failed)
+}
@@ -0,0 +1,95 @@
+// main.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"song_library/configs"
+	"song_library/internal/app"
+	"song_library/internal/migrations"
+	"song_library/internal/utils"
+)
+
+const usage = `Использование: migrate <команда>
+
+Команды:
+  up        применить все неприменённые миграции
+  down      откатить последнюю применённую миграцию
+  status    показать состояние миграций
+  to N      привести схему к версии N (0 - откатить всё)`
+
+func main() {
+	utils.LoadEnv()
+
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	cfg, err := configs.LoadDatabaseConfig()
+	if err != nil {
+		utils.GetLogger().Fatalf("Не удалось загрузить конфигурацию: %v", err)
+	}
+	logger := utils.InitLogger(cfg.LogLevel)
+
+	db, err := app.OpenDatabase(cfg)
+	if err != nil {
+		logger.Fatalf("Не удалось подключиться к базе данных: %v", err)
+	}
+	if db == nil {
+		logger.Fatalf("Драйвер %s не использует базу данных, миграции не требуются", cfg.DBDriver)
+	}
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		logger.Fatalf("Не удалось загрузить миграции: %v", err)
+	}
+
+	switch command := os.Args[1]; command {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		err = migrator.Down()
+	case "to":
+		if len(os.Args) < 3 {
+			fmt.Println(usage)
+			os.Exit(2)
+		}
+		version, convErr := strconv.Atoi(os.Args[2])
+		if convErr != nil || version < 0 {
+			logger.Fatalf("Некорректная версия миграции: %s", os.Args[2])
+		}
+		err = migrator.To(version)
+	case "status":
+		err = printStatus(migrator)
+	default:
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		logger.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	if current, err := migrator.CurrentVersion(); err == nil {
+		logger.Infof("Текущая версия схемы: %d", current)
+	}
+}
+
+func printStatus(migrator *migrations.Migrator) error {
+	statuses, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		state := "ожидает"
+		if status.Applied {
+			state = "применена " + status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%04d  %-40s  %s\n", status.Version, status.Name, state)
+	}
+	return nil
+}
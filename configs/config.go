@@ -3,39 +3,241 @@ package configs
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DBDriverPostgres = "postgres"
+	DBDriverSQLite   = "sqlite"
+	DBDriverMemory   = "memory"
+)
+
+// Источники метаданных для обогащения песен.
+const (
+	// ProviderMusicAPI - внешний музыкальный API по адресу EXTERNAL_API
+	ProviderMusicAPI = "musicapi"
+	// ProviderLocal - JSON-файл LOCAL_METADATA_FILE
+	ProviderLocal = "local"
 )
 
 type Config struct {
 	ServerPort  string
+	DBDriver    string
 	DatabaseURL string
 	ExternalAPI string
 	LogLevel    string
+	// ExternalAPITimeout ограничивает запрос к внешнему API вместе с повторами
+	ExternalAPITimeout time.Duration
+	// ExternalAPIAttemptTimeout ограничивает одну попытку запроса к внешнему API
+	ExternalAPIAttemptTimeout time.Duration
+	// ExternalAPIMaxRetries - сколько раз повторить запрос после 5xx, 429 или сетевой ошибки
+	ExternalAPIMaxRetries int
+	// ExternalAPIRetryBackoff - пауза перед первым повтором; каждая следующая вдвое дольше
+	ExternalAPIRetryBackoff time.Duration
+	// ExternalAPIBreakerThreshold - после стольких неудачных запросов подряд внешний API
+	// считается недоступным на ExternalAPIBreakerCooldown; 0 - не отключать
+	ExternalAPIBreakerThreshold int
+	ExternalAPIBreakerCooldown  time.Duration
+	// TrashRetention - сколько песня хранится в корзине до окончательного удаления; 0 - без очистки
+	TrashRetention time.Duration
+	// TrashPurgeInterval - как часто фоновая задача очищает корзину
+	TrashPurgeInterval time.Duration
+	// EnrichWorkers - сколько песен обогащается данными внешнего API одновременно
+	EnrichWorkers int
+	// EnrichMaxAttempts - после стольких неудачных попыток обогащение помечается failed
+	EnrichMaxAttempts int
+	// EnrichRetryBackoff - пауза перед повтором обогащения; каждая следующая вдвое дольше
+	EnrichRetryBackoff time.Duration
+	// EnrichProviders - источники метаданных по убыванию приоритета; пусто - песни не обогащаются
+	EnrichProviders []string
+	// EnrichFieldProviders - свой порядок источников для отдельных полей песни
+	EnrichFieldProviders map[string][]string
+	// LocalMetadataFile - JSON-файл источника local
+	LocalMetadataFile string
+	// EnrichBackfillRate - сколько песен в секунду дозаполнение отправляет источникам
+	EnrichBackfillRate float64
+	// EnrichRefreshInterval - как часто запускается плановое дозаполнение; 0 - не запускать
+	EnrichRefreshInterval time.Duration
+	// EnrichStaleAfter - плановое дозаполнение обновляет данные источников старше этого срока
+	EnrichStaleAfter time.Duration
+	// PaginationMaxLimit - наибольший размер страницы списков; больший limit уменьшается до него
+	PaginationMaxLimit int
+	// CursorSecret - ключ подписи курсоров списков; если не задан, ключ генерируется
+	// при запуске, и курсоры перестают действовать после перезапуска
+	CursorSecret string
 }
 
 func LoadConfig() (*Config, error) {
+	cfg, err := LoadDatabaseConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	serverPort := getEnv("SERVER_PORT", "8080")
-	databaseURL := getEnv("DATABASE_URL", "")
 	externalAPI := getEnv("EXTERNAL_API", "")
-	logLevel := getEnv("LOG_LEVEL", "info")
+	localMetadataFile := getEnv("LOCAL_METADATA_FILE", "")
+
+	// Внешний API необязателен: без него и без ENRICH_PROVIDERS песни добавляются только вручную
+	defaultProviders := ""
+	if externalAPI != "" {
+		defaultProviders = ProviderMusicAPI
+	}
+	enrichProviders := splitList(getEnv("ENRICH_PROVIDERS", defaultProviders), ",")
+	for _, provider := range enrichProviders {
+		switch provider {
+		case ProviderMusicAPI:
+			if externalAPI == "" {
+				return nil, ErrMissingExternalAPI
+			}
+		case ProviderLocal:
+			if localMetadataFile == "" {
+				return nil, ErrMissingLocalMetadataFile
+			}
+		default:
+			return nil, ErrInvalidEnrichProviders
+		}
+	}
+
+	enrichFieldProviders := map[string][]string{}
+	for _, rule := range splitList(getEnv("ENRICH_FIELD_PROVIDERS", ""), ";") {
+		field, providers, ok := strings.Cut(rule, "=")
+		field = strings.TrimSpace(field)
+		if !ok || field == "" {
+			return nil, ErrInvalidEnrichFieldProviders
+		}
+		enrichFieldProviders[field] = splitList(providers, ",")
+	}
+
+	trashRetention, err := time.ParseDuration(getEnv("TRASH_RETENTION", "720h"))
+	if err != nil || trashRetention < 0 {
+		return nil, ErrInvalidTrashRetention
+	}
+	trashPurgeInterval, err := time.ParseDuration(getEnv("TRASH_PURGE_INTERVAL", "1h"))
+	if err != nil || trashPurgeInterval <= 0 {
+		return nil, ErrInvalidTrashPurgeInterval
+	}
 
-	if databaseURL == "" {
-		return nil, ErrMissingDatabaseURL
+	apiTimeout, err := time.ParseDuration(getEnv("EXTERNAL_API_TIMEOUT", "10s"))
+	if err != nil || apiTimeout <= 0 {
+		return nil, ErrInvalidExternalAPITimeout
+	}
+	apiAttemptTimeout, err := time.ParseDuration(getEnv("EXTERNAL_API_ATTEMPT_TIMEOUT", "3s"))
+	if err != nil || apiAttemptTimeout <= 0 {
+		return nil, ErrInvalidExternalAPIAttemptTimeout
+	}
+	apiMaxRetries, err := strconv.Atoi(getEnv("EXTERNAL_API_MAX_RETRIES", "2"))
+	if err != nil || apiMaxRetries < 0 {
+		return nil, ErrInvalidExternalAPIMaxRetries
+	}
+	apiRetryBackoff, err := time.ParseDuration(getEnv("EXTERNAL_API_RETRY_BACKOFF", "200ms"))
+	if err != nil || apiRetryBackoff < 0 {
+		return nil, ErrInvalidExternalAPIRetryBackoff
+	}
+	apiBreakerThreshold, err := strconv.Atoi(getEnv("EXTERNAL_API_BREAKER_THRESHOLD", "5"))
+	if err != nil || apiBreakerThreshold < 0 {
+		return nil, ErrInvalidExternalAPIBreakerThreshold
+	}
+	apiBreakerCooldown, err := time.ParseDuration(getEnv("EXTERNAL_API_BREAKER_COOLDOWN", "30s"))
+	if err != nil || apiBreakerCooldown <= 0 {
+		return nil, ErrInvalidExternalAPIBreakerCooldown
 	}
 
-	if externalAPI == "" {
-		return nil, ErrMissingExternalAPI
+	enrichWorkers, err := strconv.Atoi(getEnv("ENRICH_WORKERS", "4"))
+	if err != nil || enrichWorkers <= 0 {
+		return nil, ErrInvalidEnrichWorkers
+	}
+	enrichMaxAttempts, err := strconv.Atoi(getEnv("ENRICH_MAX_ATTEMPTS", "5"))
+	if err != nil || enrichMaxAttempts <= 0 {
+		return nil, ErrInvalidEnrichMaxAttempts
+	}
+	enrichRetryBackoff, err := time.ParseDuration(getEnv("ENRICH_RETRY_BACKOFF", "30s"))
+	if err != nil || enrichRetryBackoff <= 0 {
+		return nil, ErrInvalidEnrichRetryBackoff
+	}
+
+	enrichBackfillRate, err := strconv.ParseFloat(getEnv("ENRICH_BACKFILL_RATE", "2"), 64)
+	if err != nil || enrichBackfillRate <= 0 {
+		return nil, ErrInvalidEnrichBackfillRate
+	}
+	enrichRefreshInterval, err := time.ParseDuration(getEnv("ENRICH_REFRESH_INTERVAL", "0"))
+	if err != nil || enrichRefreshInterval < 0 {
+		return nil, ErrInvalidEnrichRefreshInterval
+	}
+	enrichStaleAfter, err := time.ParseDuration(getEnv("ENRICH_STALE_AFTER", "720h"))
+	if err != nil || enrichStaleAfter <= 0 {
+		return nil, ErrInvalidEnrichStaleAfter
+	}
+
+	paginationMaxLimit, err := strconv.Atoi(getEnv("PAGINATION_MAX_LIMIT", "100"))
+	if err != nil || paginationMaxLimit <= 0 {
+		return nil, ErrInvalidPaginationMaxLimit
+	}
+	cursorSecret := getEnv("CURSOR_SECRET", "")
+
+	cfg.ServerPort = serverPort
+	cfg.ExternalAPI = externalAPI
+	cfg.ExternalAPITimeout = apiTimeout
+	cfg.ExternalAPIAttemptTimeout = apiAttemptTimeout
+	cfg.ExternalAPIMaxRetries = apiMaxRetries
+	cfg.ExternalAPIRetryBackoff = apiRetryBackoff
+	cfg.ExternalAPIBreakerThreshold = apiBreakerThreshold
+	cfg.ExternalAPIBreakerCooldown = apiBreakerCooldown
+	cfg.TrashRetention = trashRetention
+	cfg.TrashPurgeInterval = trashPurgeInterval
+	cfg.EnrichWorkers = enrichWorkers
+	cfg.EnrichMaxAttempts = enrichMaxAttempts
+	cfg.EnrichRetryBackoff = enrichRetryBackoff
+	cfg.EnrichProviders = enrichProviders
+	cfg.EnrichFieldProviders = enrichFieldProviders
+	cfg.LocalMetadataFile = localMetadataFile
+	cfg.EnrichBackfillRate = enrichBackfillRate
+	cfg.EnrichRefreshInterval = enrichRefreshInterval
+	cfg.EnrichStaleAfter = enrichStaleAfter
+	cfg.PaginationMaxLimit = paginationMaxLimit
+	cfg.CursorSecret = cursorSecret
+
+	return cfg, nil
+}
+
+// LoadDatabaseConfig загружает только настройки базы данных и логирования.
+// Используется утилитами вроде migrate, которым не нужен HTTP-сервер и внешний API.
+func LoadDatabaseConfig() (*Config, error) {
+	dbDriver := getEnv("DB_DRIVER", DBDriverPostgres)
+	databaseURL := getEnv("DATABASE_URL", "")
+	logLevel := getEnv("LOG_LEVEL", "info")
+
+	switch dbDriver {
+	case DBDriverPostgres, DBDriverSQLite:
+		if databaseURL == "" {
+			return nil, ErrMissingDatabaseURL
+		}
+	case DBDriverMemory:
+	default:
+		return nil, ErrUnsupportedDBDriver
 	}
 
 	cfg := &Config{
-		ServerPort:  serverPort,
+		DBDriver:    dbDriver,
 		DatabaseURL: databaseURL,
-		ExternalAPI: externalAPI,
 		LogLevel:    logLevel,
 	}
 
 	return cfg, nil
 }
 
+// splitList делит значение по sep, отбрасывая пробелы и пустые элементы.
+func splitList(value, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -44,8 +246,33 @@ func getEnv(key, defaultValue string) string {
 }
 
 var (
-	ErrMissingDatabaseURL = &ConfigError{"DATABASE_URL is required but not set"}
-	ErrMissingExternalAPI = &ConfigError{"EXTERNAL_API is required but not set"}
+	ErrMissingDatabaseURL  = &ConfigError{"DATABASE_URL is required but not set"}
+	ErrMissingExternalAPI  = &ConfigError{"EXTERNAL_API is required when ENRICH_PROVIDERS includes musicapi"}
+	ErrUnsupportedDBDriver = &ConfigError{"DB_DRIVER must be one of: postgres, sqlite, memory"}
+
+	ErrInvalidExternalAPITimeout          = &ConfigError{"EXTERNAL_API_TIMEOUT must be a positive duration, e.g. 10s"}
+	ErrInvalidExternalAPIAttemptTimeout   = &ConfigError{"EXTERNAL_API_ATTEMPT_TIMEOUT must be a positive duration, e.g. 3s"}
+	ErrInvalidExternalAPIMaxRetries       = &ConfigError{"EXTERNAL_API_MAX_RETRIES must be a non-negative integer"}
+	ErrInvalidExternalAPIRetryBackoff     = &ConfigError{"EXTERNAL_API_RETRY_BACKOFF must be a non-negative duration, e.g. 200ms"}
+	ErrInvalidExternalAPIBreakerThreshold = &ConfigError{"EXTERNAL_API_BREAKER_THRESHOLD must be a non-negative integer"}
+	ErrInvalidExternalAPIBreakerCooldown  = &ConfigError{"EXTERNAL_API_BREAKER_COOLDOWN must be a positive duration, e.g. 30s"}
+
+	ErrInvalidTrashRetention     = &ConfigError{"TRASH_RETENTION must be a non-negative duration, e.g. 720h"}
+	ErrInvalidTrashPurgeInterval = &ConfigError{"TRASH_PURGE_INTERVAL must be a positive duration, e.g. 1h"}
+
+	ErrInvalidEnrichWorkers      = &ConfigError{"ENRICH_WORKERS must be a positive integer"}
+	ErrInvalidEnrichMaxAttempts  = &ConfigError{"ENRICH_MAX_ATTEMPTS must be a positive integer"}
+	ErrInvalidEnrichRetryBackoff = &ConfigError{"ENRICH_RETRY_BACKOFF must be a positive duration, e.g. 30s"}
+
+	ErrInvalidEnrichBackfillRate    = &ConfigError{"ENRICH_BACKFILL_RATE must be a positive number of songs per second, e.g. 2"}
+	ErrInvalidEnrichRefreshInterval = &ConfigError{"ENRICH_REFRESH_INTERVAL must be a non-negative duration, e.g. 24h"}
+	ErrInvalidEnrichStaleAfter      = &ConfigError{"ENRICH_STALE_AFTER must be a positive duration, e.g. 720h"}
+
+	ErrInvalidPaginationMaxLimit = &ConfigError{"PAGINATION_MAX_LIMIT must be a positive integer"}
+
+	ErrMissingLocalMetadataFile    = &ConfigError{"LOCAL_METADATA_FILE is required when ENRICH_PROVIDERS includes local"}
+	ErrInvalidEnrichProviders      = &ConfigError{"ENRICH_PROVIDERS must be a comma-separated list of: musicapi, local; leave it empty to disable enrichment"}
+	ErrInvalidEnrichFieldProviders = &ConfigError{"ENRICH_FIELD_PROVIDERS must look like releaseDate=musicapi;text=local,musicapi"}
 )
 
 type ConfigError struct {
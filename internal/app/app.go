@@ -2,12 +2,16 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"song_library/configs"
 	"song_library/internal/controllers"
 	"song_library/internal/middleware"
-	"song_library/internal/repositories"
+	"song_library/internal/models"
+	"song_library/internal/providers"
 	"song_library/internal/services"
 	"song_library/internal/utils"
 	"song_library/pkg/external_api"
@@ -16,7 +20,6 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
@@ -31,26 +34,96 @@ func NewApp(cfg *configs.Config) *App {
 	logger := utils.InitLogger(cfg.LogLevel)
 	gin.DefaultWriter = logger.Writer()
 
-	db, err := gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{})
+	db, err := OpenDatabase(cfg)
 	if err != nil {
 		logger.Fatalf("Не удалось подключиться к базе данных: %v", err)
 	}
 
-	if err := repositories.AutoMigrate(db); err != nil {
-		logger.Fatalf("Не удалось выполнить миграцию базы данных: %v", err)
+	if db != nil {
+		if err := checkSchema(db); err != nil {
+			logger.Fatalf("Схема базы данных не готова (выполните migrate up): %v", err)
+		}
+	}
+
+	registry, err := newProviderRegistry(cfg)
+	if err != nil {
+		logger.Fatalf("Не удалось настроить источники метаданных: %v", err)
+	}
+
+	stores := newStores(db)
+
+	enrichmentService := services.NewEnrichmentService(stores.Songs, stores.Albums, stores.Revisions, stores.Enrichment, stores.Sources, stores.Tx, registry, cfg.EnrichWorkers, cfg.EnrichMaxAttempts, cfg.EnrichRetryBackoff)
+	backfillService := services.NewBackfillService(stores.Songs, enrichmentService, cfg.EnrichBackfillRate)
+	enrichmentController := controllers.NewEnrichmentController(enrichmentService, backfillService)
+
+	if registry.Enabled() {
+		// Источники опрашиваются в фоне, чтобы добавление песни не ждало их ответа
+		go enrichmentService.Run(context.Background())
+
+		if cfg.EnrichRefreshInterval > 0 {
+			go scheduleBackfill(backfillService, cfg.EnrichRefreshInterval, cfg.EnrichStaleAfter)
+		}
+	} else {
+		logger.Warn("Источники метаданных не настроены: песни добавляются без обогащения")
+	}
+
+	if cfg.CursorSecret == "" {
+		logger.Warn("CURSOR_SECRET не задан: курсоры списков подписываются случайным ключом и перестанут действовать после перезапуска")
 	}
+	songService := services.NewSongService(stores.Songs, stores.Artists, stores.Albums, stores.Playlists, stores.Translations, stores.Revisions, stores.Sources, stores.Tx, enrichmentService, cfg.CursorSecret)
+	songController := controllers.NewSongController(songService, cfg.PaginationMaxLimit)
+
+	// Разметка старых текстов не мешает обработке запросов, поэтому идёт в фоне
+	go func() {
+		count, err := songService.BackfillLyricsSections()
+		if err != nil {
+			logger.Errorf("Не удалось разметить тексты песен: %v", err)
+			return
+		}
+		if count > 0 {
+			logger.Infof("Размечены тексты песен: %d", count)
+		}
+	}()
+
+	artistService := services.NewArtistService(stores.Artists, stores.Songs, stores.Albums)
+	artistController := controllers.NewArtistController(artistService, cfg.PaginationMaxLimit)
 
-	externalAPIClient := external_api.NewMusicAPIClient(cfg.ExternalAPI)
+	albumService := services.NewAlbumService(stores.Albums, stores.Artists, stores.Songs)
+	albumController := controllers.NewAlbumController(albumService, cfg.PaginationMaxLimit)
 
-	songRepo := repositories.NewSongRepository(db)
-	songService := services.NewSongService(songRepo, externalAPIClient)
-	songController := controllers.NewSongController(songService)
+	playlistService := services.NewPlaylistService(stores.Playlists, stores.Songs)
+	playlistController := controllers.NewPlaylistController(playlistService, cfg.PaginationMaxLimit)
+
+	genreService := services.NewGenreService(stores.Genres, stores.Songs)
+	genreController := controllers.NewGenreController(genreService, cfg.PaginationMaxLimit)
+
+	tagService := services.NewTagService(stores.Tags, stores.Songs)
+	tagController := controllers.NewTagController(tagService)
+
+	translationService := services.NewTranslationService(stores.Translations, stores.Songs)
+	translationController := controllers.NewTranslationController(translationService)
+
+	revisionService := services.NewRevisionService(stores.Revisions, stores.Songs, stores.Artists, stores.Tx)
+	revisionController := controllers.NewRevisionController(revisionService, cfg.PaginationMaxLimit)
+
+	importService := services.NewImportService(stores.Songs, stores.Artists, stores.Revisions, stores.Sources, stores.Tx, registry)
+	importController := controllers.NewImportController(importService)
+
+	exportService := services.NewExportService(stores.Songs)
+	exportController := controllers.NewExportController(exportService)
+
+	trashService := services.NewTrashService(stores.Songs, stores.Playlists, stores.Tx, cfg.TrashRetention)
+	trashController := controllers.NewTrashController(trashService, cfg.PaginationMaxLimit)
+
+	if cfg.TrashRetention > 0 {
+		go purgeTrash(trashService, cfg.TrashPurgeInterval)
+	}
 
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(middleware.LoggingMiddleware())
 
-	RegisterRoutes(router, songController)
+	RegisterRoutes(router, songController, artistController, albumController, playlistController, genreController, tagController, translationController, revisionController, trashController, importController, exportController, enrichmentController)
 
 	return &App{
 		Config: cfg,
@@ -59,23 +132,202 @@ func NewApp(cfg *configs.Config) *App {
 	}
 }
 
+// NewImportService собирает сервис импорта для утилиты import поверх уже открытой базы данных.
+func NewImportService(cfg *configs.Config, db *gorm.DB) (*services.ImportService, error) {
+	if err := checkSchema(db); err != nil {
+		return nil, err
+	}
+
+	registry, err := newProviderRegistry(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	stores := newStores(db)
+	return services.NewImportService(stores.Songs, stores.Artists, stores.Revisions, stores.Sources, stores.Tx, registry), nil
+}
+
+// newProviderRegistry собирает источники метаданных в порядке ENRICH_PROVIDERS
+// с правилами слияния полей из ENRICH_FIELD_PROVIDERS.
+func newProviderRegistry(cfg *configs.Config) (*providers.Registry, error) {
+	var sources []providers.Provider
+	for _, name := range cfg.EnrichProviders {
+		switch name {
+		case configs.ProviderMusicAPI:
+			client := external_api.NewMusicAPIClient(cfg.ExternalAPI, external_api.Options{
+				Timeout:          cfg.ExternalAPITimeout,
+				AttemptTimeout:   cfg.ExternalAPIAttemptTimeout,
+				MaxRetries:       cfg.ExternalAPIMaxRetries,
+				RetryBackoff:     cfg.ExternalAPIRetryBackoff,
+				BreakerThreshold: cfg.ExternalAPIBreakerThreshold,
+				BreakerCooldown:  cfg.ExternalAPIBreakerCooldown,
+			})
+			sources = append(sources, providers.NewMusicAPIProvider(name, client))
+		case configs.ProviderLocal:
+			provider, err := providers.NewLocalProvider(name, cfg.LocalMetadataFile)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, provider)
+		}
+	}
+
+	fieldOrder := make(map[providers.Field][]string, len(cfg.EnrichFieldProviders))
+	for field, names := range cfg.EnrichFieldProviders {
+		fieldOrder[providers.Field(field)] = names
+	}
+	return providers.NewRegistry(sources, fieldOrder)
+}
+
 func (a *App) Run() error {
 	addr := fmt.Sprintf(":%s", a.Config.ServerPort)
 	utils.GetLogger().Infof("Запуск сервера на %s", addr)
 	return a.Router.Run(addr)
 }
 
-func RegisterRoutes(router *gin.Engine, songController *controllers.SongController) {
+// purgeTrash окончательно удаляет песни с истёкшим сроком хранения в корзине:
+// сразу при запуске и затем раз в interval.
+func purgeTrash(trashService *services.TrashService, interval time.Duration) {
+	logger := utils.GetLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := trashService.PurgeExpired()
+		if err != nil {
+			logger.Errorf("Не удалось очистить корзину: %v", err)
+		} else if count > 0 {
+			logger.Infof("Из корзины окончательно удалены песни: %d", count)
+		}
+		<-ticker.C
+	}
+}
+
+// scheduleBackfill раз в interval дозаполняет неполные песни и обновляет данные
+// источников старше staleAfter. Если прогон ещё идёт, очередной пропускается.
+func scheduleBackfill(backfillService *services.BackfillService, interval, staleAfter time.Duration) {
+	logger := utils.GetLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		_, err := backfillService.Start(models.BackfillRequest{StaleAfter: staleAfter.String()}, models.BackfillScheduled)
+		switch {
+		case errors.Is(err, services.ErrBackfillRunning):
+			logger.Infof("Плановое дозаполнение пропущено: предыдущий прогон ещё идёт")
+		case err != nil:
+			logger.Errorf("Не удалось запустить плановое дозаполнение: %v", err)
+		default:
+			logger.Infof("Запущено плановое дозаполнение")
+		}
+	}
+}
+
+func RegisterRoutes(
+	router *gin.Engine,
+	songController *controllers.SongController,
+	artistController *controllers.ArtistController,
+	albumController *controllers.AlbumController,
+	playlistController *controllers.PlaylistController,
+	genreController *controllers.GenreController,
+	tagController *controllers.TagController,
+	translationController *controllers.TranslationController,
+	revisionController *controllers.RevisionController,
+	trashController *controllers.TrashController,
+	importController *controllers.ImportController,
+	exportController *controllers.ExportController,
+	enrichmentController *controllers.EnrichmentController,
+) {
 	api := router.Group("/api")
 	{
 		songs := api.Group("/songs")
 		{
 			songs.GET("", songController.GetSongs)
 			songs.POST("", songController.AddSong)
+			songs.POST("/import", importController.ImportSongs)
+			songs.GET("/search", songController.SearchSongs)
+			songs.GET("/facets", songController.GetSongFacets)
 			songs.GET("/:id/lyrics", songController.GetSongLyrics)
+			songs.PUT("/:id/lyrics/synced", songController.ImportSyncedLyrics)
+			songs.DELETE("/:id/lyrics/synced", songController.DeleteSyncedLyrics)
+			songs.GET("/:id/lyrics/lines", songController.GetSyncedLines)
+			songs.GET("/:id/translations", translationController.GetTranslations)
+			songs.GET("/:id/translations/:lang", translationController.GetTranslation)
+			songs.PUT("/:id/translations/:lang", translationController.SaveTranslation)
+			songs.DELETE("/:id/translations/:lang", translationController.DeleteTranslation)
+			songs.GET("/:id/revisions", revisionController.GetRevisions)
+			songs.GET("/:id/revisions/diff", revisionController.DiffRevisions)
+			songs.GET("/:id/revisions/:rev", revisionController.GetRevision)
+			songs.POST("/:id/revisions/:rev/restore", revisionController.RestoreRevision)
+			songs.GET("/:id", songController.GetSong)
 			songs.PUT("/:id", songController.UpdateSong)
 			songs.DELETE("/:id", songController.DeleteSong)
+			songs.POST("/:id/restore", trashController.RestoreSong)
+			songs.POST("/:id/enrichment/retry", enrichmentController.RetrySongEnrichment)
+			songs.POST("/:id/refresh", enrichmentController.RefreshSong)
+			songs.GET("/:id/provenance", enrichmentController.GetProvenance)
+			songs.PUT("/:id/locks", enrichmentController.SetLocks)
+			songs.POST("/:id/genres", genreController.AddSongGenre)
+			songs.DELETE("/:id/genres/:genreId", genreController.RemoveSongGenre)
+			songs.POST("/:id/tags", tagController.AddSongTags)
+			songs.DELETE("/:id/tags/:tag", tagController.RemoveSongTag)
 		}
+
+		api.GET("/trash", trashController.GetTrash)
+		api.GET("/export", exportController.ExportSongs)
+		api.POST("/enrichment/retry", enrichmentController.RetryFailedEnrichment)
+		api.POST("/enrichment/backfill", enrichmentController.StartBackfill)
+		api.GET("/enrichment/backfill", enrichmentController.GetBackfill)
+		api.DELETE("/enrichment/backfill", enrichmentController.CancelBackfill)
+
+		artists := api.Group("/artists")
+		{
+			artists.GET("", artistController.GetArtists)
+			artists.POST("", artistController.AddArtist)
+			artists.GET("/:id", artistController.GetArtist)
+			artists.PUT("/:id", artistController.UpdateArtist)
+			artists.DELETE("/:id", artistController.DeleteArtist)
+			artists.GET("/:id/songs", artistController.GetArtistSongs)
+		}
+
+		albums := api.Group("/albums")
+		{
+			albums.GET("", albumController.GetAlbums)
+			albums.POST("", albumController.AddAlbum)
+			albums.GET("/:id", albumController.GetAlbum)
+			albums.PUT("/:id", albumController.UpdateAlbum)
+			albums.DELETE("/:id", albumController.DeleteAlbum)
+			albums.PUT("/:id/tracks", albumController.SetAlbumTracks)
+		}
+
+		genres := api.Group("/genres")
+		{
+			genres.GET("", genreController.GetGenres)
+			genres.POST("", genreController.AddGenre)
+			genres.GET("/:id", genreController.GetGenre)
+			genres.PUT("/:id", genreController.UpdateGenre)
+			genres.DELETE("/:id", genreController.DeleteGenre)
+		}
+
+		playlists := api.Group("/playlists")
+		{
+			playlists.GET("", playlistController.GetPlaylists)
+			playlists.POST("", playlistController.AddPlaylist)
+			playlists.GET("/:id", playlistController.GetPlaylist)
+			playlists.PUT("/:id", playlistController.UpdatePlaylist)
+			playlists.DELETE("/:id", playlistController.DeletePlaylist)
+			playlists.GET("/:id/entries", playlistController.GetEntries)
+			playlists.GET("/:id/shuffle", playlistController.GetShuffledEntries)
+			playlists.POST("/:id/entries", playlistController.AddEntry)
+			playlists.PATCH("/:id/entries/:entryId", playlistController.MoveEntry)
+			playlists.DELETE("/:id/entries/:entryId", playlistController.RemoveEntry)
+		}
+	}
+
+	// В v2 собраны методы, ответ которых изменился несовместимо; остальные доступны только в /api
+	v2 := router.Group("/api/v2")
+	{
+		v2.GET("/songs", songController.GetSongsV2)
 	}
 
 	// Маршрут для Swagger документации
@@ -0,0 +1,74 @@
+// database.go
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"song_library/configs"
+	"song_library/internal/migrations"
+	"song_library/internal/repositories"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// OpenDatabase открывает подключение к базе данных для выбранного драйвера.
+// Для драйвера memory база данных не используется и возвращается nil.
+func OpenDatabase(cfg *configs.Config) (*gorm.DB, error) {
+	switch cfg.DBDriver {
+	case configs.DBDriverPostgres:
+		return gorm.Open(postgres.Open(cfg.DatabaseURL), gormConfig())
+	case configs.DBDriverSQLite:
+		return gorm.Open(sqlite.Open(sqliteDSN(cfg.DatabaseURL)), gormConfig())
+	case configs.DBDriverMemory:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("неподдерживаемый драйвер базы данных: %s", cfg.DBDriver)
+	}
+}
+
+// gormConfig включает перевод ошибок драйвера, чтобы нарушение уникальности
+// одинаково приходило из PostgreSQL и SQLite как gorm.ErrDuplicatedKey.
+func gormConfig() *gorm.Config {
+	return &gorm.Config{TranslateError: true}
+}
+
+// sqliteDSN включает проверку внешних ключей, которая в SQLite по умолчанию выключена,
+// чтобы каскадное удаление работало так же, как в PostgreSQL.
+func sqliteDSN(dsn string) string {
+	if strings.Contains(dsn, "foreign_keys") {
+		return dsn
+	}
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	return dsn + separator + "_pragma=foreign_keys(1)"
+}
+
+// checkSchema проверяет, что к базе применены все встроенные миграции.
+func checkSchema(db *gorm.DB) error {
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	return migrator.CheckCurrent()
+}
+
+// stores - хранилища выбранного бэкенда и транзакции над ними.
+type stores struct {
+	*repositories.Stores
+	Tx repositories.Transactor
+}
+
+// newStores возвращает хранилища для выбранного драйвера.
+// Без базы данных (драйвер memory) все данные хранятся в памяти процесса.
+func newStores(db *gorm.DB) *stores {
+	if db == nil {
+		storage := repositories.NewMemoryStorage()
+		return &stores{Stores: repositories.NewMemoryStores(storage), Tx: repositories.NewMemoryTransactor(storage)}
+	}
+	return &stores{Stores: repositories.NewStores(db), Tx: repositories.NewDBTransactor(db)}
+}
@@ -0,0 +1,231 @@
+// album_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"song_library/internal/models"
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AlbumController struct {
+	AlbumService *services.AlbumService
+	MaxLimit     int
+}
+
+func NewAlbumController(albumService *services.AlbumService, maxLimit int) *AlbumController {
+	return &AlbumController{
+		AlbumService: albumService,
+		MaxLimit:     maxLimit,
+	}
+}
+
+// GetAlbums godoc
+// @Summary      Получить список альбомов
+// @Description  Получить список альбомов с фильтрацией и пагинацией
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        title   query     string  false  "Название альбома"
+// @Param        artist  query     string  false  "Название исполнителя"
+// @Param        type    query     string  false  "Тип релиза (lp, ep, single, compilation)"
+// @Param        page    query     int     false  "Номер страницы"
+// @Param        limit   query     int     false  "Количество элементов на странице"
+// @Success      200     {array}   models.Album
+// @Failure      400     {object}  utils.HTTPError
+// @Failure      500     {object}  utils.HTTPError
+// @Router       /api/albums [get]
+func (ac *AlbumController) GetAlbums(c *gin.Context) {
+	var filter models.AlbumFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректные параметры запроса"))
+		return
+	}
+
+	pagination := utils.NewPaginationFromRequest(c, ac.MaxLimit)
+
+	albums, err := ac.AlbumService.GetAlbums(filter, pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, albums)
+}
+
+// GetAlbum godoc
+// @Summary      Получить альбом
+// @Description  Получить альбом по ID вместе с трек-листом
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "ID альбома"
+// @Success      200   {object}  models.Album
+// @Failure      400   {object}  utils.HTTPError
+// @Failure      404   {object}  utils.HTTPError
+// @Failure      500   {object}  utils.HTTPError
+// @Router       /api/albums/{id} [get]
+func (ac *AlbumController) GetAlbum(c *gin.Context) {
+	albumID, ok := parseAlbumID(c)
+	if !ok {
+		return
+	}
+
+	album, err := ac.AlbumService.GetAlbum(albumID)
+	if err != nil {
+		respondAlbumError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, album)
+}
+
+// AddAlbum godoc
+// @Summary      Добавить альбом
+// @Description  Добавить альбом с необязательным трек-листом. Исполнитель создаётся, если его ещё нет
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        album  body      models.CreateAlbumRequest  true  "Данные альбома"
+// @Success      201    {object}  models.Album
+// @Failure      400    {object}  utils.HTTPError
+// @Failure      409    {object}  utils.HTTPError
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/albums [post]
+func (ac *AlbumController) AddAlbum(c *gin.Context) {
+	var req models.CreateAlbumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	album, err := ac.AlbumService.AddAlbum(req)
+	if err != nil {
+		respondAlbumError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, album)
+}
+
+// UpdateAlbum godoc
+// @Summary      Обновить альбом
+// @Description  Обновить данные альбома по ID. Пустые поля не изменяются
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                     true  "ID альбома"
+// @Param        album  body      models.UpdateAlbumRequest  true  "Новые данные альбома"
+// @Success      200    {object}  models.Album
+// @Failure      400    {object}  utils.HTTPError
+// @Failure      404    {object}  utils.HTTPError
+// @Failure      409    {object}  utils.HTTPError
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/albums/{id} [put]
+func (ac *AlbumController) UpdateAlbum(c *gin.Context) {
+	albumID, ok := parseAlbumID(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateAlbumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	album, err := ac.AlbumService.UpdateAlbum(albumID, req)
+	if err != nil {
+		respondAlbumError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, album)
+}
+
+// DeleteAlbum godoc
+// @Summary      Удалить альбом
+// @Description  Удалить альбом и его трек-лист. Сами песни не удаляются
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "ID альбома"
+// @Success      204   "No Content"
+// @Failure      400   {object}  utils.HTTPError
+// @Failure      404   {object}  utils.HTTPError
+// @Failure      500   {object}  utils.HTTPError
+// @Router       /api/albums/{id} [delete]
+func (ac *AlbumController) DeleteAlbum(c *gin.Context) {
+	albumID, ok := parseAlbumID(c)
+	if !ok {
+		return
+	}
+
+	if err := ac.AlbumService.DeleteAlbum(albumID); err != nil {
+		respondAlbumError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetAlbumTracks godoc
+// @Summary      Заменить трек-лист альбома
+// @Description  Полностью заменить трек-лист альбома. Номер диска по умолчанию 1
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                        true  "ID альбома"
+// @Param        tracks  body      models.SetAlbumTracksRequest  true  "Трек-лист"
+// @Success      200     {object}  models.Album
+// @Failure      400     {object}  utils.HTTPError
+// @Failure      404     {object}  utils.HTTPError
+// @Failure      500     {object}  utils.HTTPError
+// @Router       /api/albums/{id}/tracks [put]
+func (ac *AlbumController) SetAlbumTracks(c *gin.Context) {
+	albumID, ok := parseAlbumID(c)
+	if !ok {
+		return
+	}
+
+	var req models.SetAlbumTracksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	album, err := ac.AlbumService.SetAlbumTracks(albumID, req)
+	if err != nil {
+		respondAlbumError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, album)
+}
+
+func parseAlbumID(c *gin.Context) (uuid.UUID, bool) {
+	albumID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID альбома"))
+		return uuid.Nil, false
+	}
+	return albumID, true
+}
+
+func respondAlbumError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrAlbumNotFound:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Альбом не найден"))
+	case services.ErrEmptyAlbumTitle, services.ErrInvalidAlbumType, services.ErrInvalidReleaseDate,
+		services.ErrInvalidTracks, services.ErrTrackSongNotFound, services.ErrEmptyArtistName:
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+	case services.ErrAlbumExists:
+		c.JSON(http.StatusConflict, utils.NewHTTPError(http.StatusConflict, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+	}
+}
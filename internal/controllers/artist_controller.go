@@ -0,0 +1,226 @@
+// artist_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"song_library/internal/models"
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ArtistController struct {
+	ArtistService *services.ArtistService
+	MaxLimit      int
+}
+
+func NewArtistController(artistService *services.ArtistService, maxLimit int) *ArtistController {
+	return &ArtistController{
+		ArtistService: artistService,
+		MaxLimit:      maxLimit,
+	}
+}
+
+// GetArtists godoc
+// @Summary      Получить список исполнителей
+// @Description  Получить список исполнителей с фильтрацией по названию и пагинацией
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        name    query     string  false  "Название исполнителя"
+// @Param        page    query     int     false  "Номер страницы"
+// @Param        limit   query     int     false  "Количество элементов на странице"
+// @Success      200     {array}   models.Artist
+// @Failure      400     {object}  utils.HTTPError
+// @Failure      500     {object}  utils.HTTPError
+// @Router       /api/artists [get]
+func (ac *ArtistController) GetArtists(c *gin.Context) {
+	var filter models.ArtistFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректные параметры запроса"))
+		return
+	}
+
+	pagination := utils.NewPaginationFromRequest(c, ac.MaxLimit)
+
+	artists, err := ac.ArtistService.GetArtists(filter, pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, artists)
+}
+
+// GetArtist godoc
+// @Summary      Получить исполнителя
+// @Description  Получить исполнителя по ID
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "ID исполнителя"
+// @Success      200   {object}  models.Artist
+// @Failure      400   {object}  utils.HTTPError
+// @Failure      404   {object}  utils.HTTPError
+// @Failure      500   {object}  utils.HTTPError
+// @Router       /api/artists/{id} [get]
+func (ac *ArtistController) GetArtist(c *gin.Context) {
+	artistID, ok := parseArtistID(c)
+	if !ok {
+		return
+	}
+
+	artist, err := ac.ArtistService.GetArtist(artistID)
+	if err != nil {
+		respondArtistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, artist)
+}
+
+// AddArtist godoc
+// @Summary      Добавить исполнителя
+// @Description  Добавить нового исполнителя. Названия сравниваются без учёта регистра и лишних пробелов
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        artist  body      models.CreateArtistRequest  true  "Данные исполнителя"
+// @Success      201     {object}  models.Artist
+// @Failure      400     {object}  utils.HTTPError
+// @Failure      409     {object}  utils.HTTPError
+// @Failure      500     {object}  utils.HTTPError
+// @Router       /api/artists [post]
+func (ac *ArtistController) AddArtist(c *gin.Context) {
+	var req models.CreateArtistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	artist, err := ac.ArtistService.AddArtist(req.Name)
+	if err != nil {
+		respondArtistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, artist)
+}
+
+// UpdateArtist godoc
+// @Summary      Переименовать исполнителя
+// @Description  Изменить название исполнителя; все его песни получат новое название группы
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                      true  "ID исполнителя"
+// @Param        artist  body      models.UpdateArtistRequest  true  "Новые данные исполнителя"
+// @Success      200     {object}  models.Artist
+// @Failure      400     {object}  utils.HTTPError
+// @Failure      404     {object}  utils.HTTPError
+// @Failure      409     {object}  utils.HTTPError
+// @Failure      500     {object}  utils.HTTPError
+// @Router       /api/artists/{id} [put]
+func (ac *ArtistController) UpdateArtist(c *gin.Context) {
+	artistID, ok := parseArtistID(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateArtistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	artist, err := ac.ArtistService.UpdateArtist(artistID, req)
+	if err != nil {
+		respondArtistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, artist)
+}
+
+// DeleteArtist godoc
+// @Summary      Удалить исполнителя
+// @Description  Удалить исполнителя по ID. Исполнителя с песнями или альбомами удалить нельзя
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "ID исполнителя"
+// @Success      204   "No Content"
+// @Failure      400   {object}  utils.HTTPError
+// @Failure      404   {object}  utils.HTTPError
+// @Failure      409   {object}  utils.HTTPError
+// @Failure      500   {object}  utils.HTTPError
+// @Router       /api/artists/{id} [delete]
+func (ac *ArtistController) DeleteArtist(c *gin.Context) {
+	artistID, ok := parseArtistID(c)
+	if !ok {
+		return
+	}
+
+	if err := ac.ArtistService.DeleteArtist(artistID); err != nil {
+		respondArtistError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetArtistSongs godoc
+// @Summary      Получить песни исполнителя
+// @Description  Получить каталог песен исполнителя с пагинацией
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string  true   "ID исполнителя"
+// @Param        page    query     int     false  "Номер страницы"
+// @Param        limit   query     int     false  "Количество элементов на странице"
+// @Success      200     {array}   models.Song
+// @Failure      400     {object}  utils.HTTPError
+// @Failure      404     {object}  utils.HTTPError
+// @Failure      500     {object}  utils.HTTPError
+// @Router       /api/artists/{id}/songs [get]
+func (ac *ArtistController) GetArtistSongs(c *gin.Context) {
+	artistID, ok := parseArtistID(c)
+	if !ok {
+		return
+	}
+
+	pagination := utils.NewPaginationFromRequest(c, ac.MaxLimit)
+
+	songs, err := ac.ArtistService.GetArtistSongs(artistID, pagination)
+	if err != nil {
+		respondArtistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, songs)
+}
+
+func parseArtistID(c *gin.Context) (uuid.UUID, bool) {
+	artistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID исполнителя"))
+		return uuid.Nil, false
+	}
+	return artistID, true
+}
+
+func respondArtistError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrArtistNotFound:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Исполнитель не найден"))
+	case services.ErrArtistExists, services.ErrArtistHasSongs, services.ErrArtistHasAlbums:
+		c.JSON(http.StatusConflict, utils.NewHTTPError(http.StatusConflict, err.Error()))
+	case services.ErrEmptyArtistName:
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+	}
+}
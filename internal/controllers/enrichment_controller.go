@@ -0,0 +1,271 @@
+// enrichment_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"song_library/internal/models"
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type EnrichmentController struct {
+	EnrichmentService *services.EnrichmentService
+	BackfillService   *services.BackfillService
+}
+
+func NewEnrichmentController(enrichmentService *services.EnrichmentService, backfillService *services.BackfillService) *EnrichmentController {
+	return &EnrichmentController{
+		EnrichmentService: enrichmentService,
+		BackfillService:   backfillService,
+	}
+}
+
+// RetrySongEnrichment godoc
+// @Summary      Повторить обогащение песни
+// @Description  Вернуть в очередь песню со статусом обогащения failed или not_found; счётчик попыток начинается заново
+// @Tags         enrichment
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID песни"
+// @Success      202  {object}  models.Song
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      409  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/songs/{id}/enrichment/retry [post]
+func (ec *EnrichmentController) RetrySongEnrichment(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	song, err := ec.EnrichmentService.RetrySong(songID)
+	if err != nil {
+		switch err {
+		case services.ErrSongNotFound:
+			c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+		case services.ErrEnrichmentNotRetryable, services.ErrEnrichmentDisabled:
+			c.JSON(http.StatusConflict, utils.NewHTTPError(http.StatusConflict, err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, song)
+}
+
+// RefreshSong godoc
+// @Summary      Обновить данные песни из источников
+// @Description  Поставить песню в очередь обогащения в любом статусе, чтобы заново запросить источники метаданных.
+// @Description  Заблокированные поля не перезаписываются
+// @Tags         enrichment
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID песни"
+// @Success      202  {object}  models.Song
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      409  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/songs/{id}/refresh [post]
+func (ec *EnrichmentController) RefreshSong(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	song, err := ec.EnrichmentService.RefreshSong(songID)
+	if err != nil {
+		switch err {
+		case services.ErrSongNotFound:
+			c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+		case services.ErrEnrichmentDisabled:
+			c.JSON(http.StatusConflict, utils.NewHTTPError(http.StatusConflict, err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, song)
+}
+
+// RetryFailedEnrichment godoc
+// @Summary      Повторить неудавшиеся обогащения
+// @Description  Вернуть в очередь все песни со статусом обогащения failed
+// @Tags         enrichment
+// @Accept       json
+// @Produce      json
+// @Success      202  {object}  models.RetryEnrichmentResponse
+// @Failure      409  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/enrichment/retry [post]
+func (ec *EnrichmentController) RetryFailedEnrichment(c *gin.Context) {
+	queued, err := ec.EnrichmentService.RetryFailed()
+	if err != nil {
+		if err == services.ErrEnrichmentDisabled {
+			c.JSON(http.StatusConflict, utils.NewHTTPError(http.StatusConflict, err.Error()))
+		} else {
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, models.RetryEnrichmentResponse{Queued: queued})
+}
+
+// GetProvenance godoc
+// @Summary      Получить происхождение полей песни
+// @Description  Для даты выпуска, текста, ссылки и альбома: какой источник заполнил поле, когда и каким ответом, и заблокировано ли поле от перезаписи обогащением
+// @Tags         enrichment
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID песни"
+// @Success      200  {object}  models.SongProvenance
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/songs/{id}/provenance [get]
+func (ec *EnrichmentController) GetProvenance(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	provenance, err := ec.EnrichmentService.GetProvenance(songID)
+	if err != nil {
+		if err == services.ErrSongNotFound {
+			c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+		} else {
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, provenance)
+}
+
+// SetLocks godoc
+// @Summary      Заблокировать поля песни
+// @Description  Включить или снять блокировку даты выпуска, текста и ссылки. Заблокированное поле обогащение не перезаписывает;
+// @Description  поля, изменённые вручную, блокируются автоматически. Поля, не указанные в запросе, не меняются
+// @Tags         enrichment
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                   true  "ID песни"
+// @Param        locks  body      models.SongLocksRequest  true  "Блокировки полей"
+// @Success      200    {object}  models.SongProvenance
+// @Failure      400    {object}  utils.HTTPError
+// @Failure      404    {object}  utils.HTTPError
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/songs/{id}/locks [put]
+func (ec *EnrichmentController) SetLocks(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	var req models.SongLocksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	provenance, err := ec.EnrichmentService.SetLocks(songID, req)
+	if err != nil {
+		if err == services.ErrSongNotFound {
+			c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+		} else {
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, provenance)
+}
+
+// StartBackfill godoc
+// @Summary      Запустить дозаполнение песен
+// @Description  В фоне заново запросить источники для песен без даты выпуска, текста или ссылки, а если указан staleAfter -
+// @Description  и для песен с данными источников старше этого срока. Песни обрабатываются с ограничением скорости
+// @Description  ENRICH_BACKFILL_RATE; песни отбираются уже в фоне, поэтому total в ответе ещё 0. Ход прогона возвращает
+// @Description  GET /api/enrichment/backfill; пока источники недоступны, прогон приостанавливается до resumeAt.
+// @Description  Тело запроса необязательно
+// @Tags         enrichment
+// @Accept       json
+// @Produce      json
+// @Param        backfill  body      models.BackfillRequest  false  "Параметры прогона"
+// @Success      202       {object}  models.BackfillRun
+// @Failure      400       {object}  utils.HTTPError
+// @Failure      409       {object}  utils.HTTPError
+// @Failure      500       {object}  utils.HTTPError
+// @Router       /api/enrichment/backfill [post]
+func (ec *EnrichmentController) StartBackfill(c *gin.Context) {
+	var req models.BackfillRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+			return
+		}
+	}
+
+	run, err := ec.BackfillService.Start(req, models.BackfillManual)
+	if err != nil {
+		switch err {
+		case services.ErrInvalidStaleAfter, services.ErrInvalidBackfillLimit:
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+		case services.ErrBackfillRunning, services.ErrEnrichmentDisabled:
+			c.JSON(http.StatusConflict, utils.NewHTTPError(http.StatusConflict, err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, run)
+}
+
+// GetBackfill godoc
+// @Summary      Ход дозаполнения
+// @Description  Выполняющийся или последний завершённый прогон дозаполнения с числом обработанных песен
+// @Tags         enrichment
+// @Produce      json
+// @Success      200  {object}  models.BackfillRun
+// @Failure      404  {object}  utils.HTTPError
+// @Router       /api/enrichment/backfill [get]
+func (ec *EnrichmentController) GetBackfill(c *gin.Context) {
+	run, err := ec.BackfillService.Current()
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// CancelBackfill godoc
+// @Summary      Остановить дозаполнение
+// @Description  Остановить выполняющийся прогон; уже обновлённые песни остаются обновлёнными
+// @Tags         enrichment
+// @Produce      json
+// @Success      200  {object}  models.BackfillRun
+// @Failure      409  {object}  utils.HTTPError
+// @Router       /api/enrichment/backfill [delete]
+func (ec *EnrichmentController) CancelBackfill(c *gin.Context) {
+	run, err := ec.BackfillService.Cancel()
+	if err != nil {
+		c.JSON(http.StatusConflict, utils.NewHTTPError(http.StatusConflict, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
@@ -0,0 +1,99 @@
+// export_controller.go
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"song_library/internal/models"
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+var exportContentTypes = map[models.FileFormat]string{
+	models.FormatCSV:    "text/csv; charset=utf-8",
+	models.FormatJSON:   "application/json; charset=utf-8",
+	models.FormatNDJSON: "application/x-ndjson; charset=utf-8",
+}
+
+type ExportController struct {
+	ExportService *services.ExportService
+}
+
+func NewExportController(exportService *services.ExportService) *ExportController {
+	return &ExportController{
+		ExportService: exportService,
+	}
+}
+
+// ExportSongs godoc
+// @Summary      Выгрузить библиотеку
+// @Description  Потоковая выгрузка всех песен под фильтром в порядке добавления или sort - без пагинации и без загрузки библиотеки в память.
+// @Description  Колонки называются как поля песни в JSON, поэтому выгруженный файл можно загрузить обратно через /api/songs/import.
+// @Description  Если ошибка случится посреди выгрузки, ответ оборвётся: проверяйте, что файл дочитан до конца
+// @Tags         export
+// @Produce      json,plain
+// @Param        format    query     string    false  "Формат: ndjson (по умолчанию), csv или json"
+// @Param        columns   query     []string  false  "Колонки в нужном порядке: id, artistId, group, song, releaseDate, text, link, createdAt, updatedAt. По умолчанию - все, кроме text"
+// @Param        lyrics    query     bool      false  "Добавить текст песни"
+// @Param        group     query     string    false  "Фильтр по группе"
+// @Param        song      query     string    false  "Фильтр по названию песни"
+// @Param        tags      query     []string  false  "Фильтр по тегам"
+// @Param        tagMatch  query     string    false  "Совпадение тегов: any (по умолчанию) или all"
+// @Param        genres    query     []string  false  "Фильтр по жанрам"
+// @Param        album     query     string    false  "ID альбома"
+// @Param        enrichment  query   string    false  "Фильтр по статусу обогащения"
+// @Param        match     query     string    false  "Сравнение group и song: contains - подстрока (по умолчанию), prefix - начало названия, exact - название целиком"
+// @Param        ids       query     []string  false  "ID песен (не больше 100); можно повторять параметр или перечислить через запятую"
+// @Param        releasedFrom  query  string   false  "Дата выпуска не раньше: 2006, 2006-07 или 2006-07-16; песни без даты не попадают"
+// @Param        releasedTo    query  string   false  "Дата выпуска не позже; 2006 включает весь 2006 год"
+// @Param        createdFrom   query  string   false  "Добавлена не раньше: дата или момент в RFC 3339"
+// @Param        createdTo     query  string   false  "Добавлена не позже: дата или момент в RFC 3339"
+// @Param        updatedFrom   query  string   false  "Изменена не раньше: дата или момент в RFC 3339"
+// @Param        updatedTo     query  string   false  "Изменена не позже: дата или момент в RFC 3339"
+// @Param        hasLyrics query     bool      false  "true - только песни с текстом, false - без текста"
+// @Param        hasLink   query     bool      false  "true - только песни со ссылкой, false - без ссылки"
+// @Param        sort      query     string    false  "Сортировка: song, group, releaseDate, createdAt, updatedAt через запятую, минус - по убыванию. По умолчанию - в порядке добавления"
+// @Success      200       {file}    file
+// @Failure      400       {object}  utils.HTTPError
+// @Failure      500       {object}  utils.HTTPError
+// @Router       /api/export [get]
+func (ec *ExportController) ExportSongs(c *gin.Context) {
+	filter, ok := bindSongFilter(c)
+	if !ok {
+		return
+	}
+	var options models.ExportOptions
+	if err := c.ShouldBindQuery(&options); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	export, err := ec.ExportService.NewExport(filter, options)
+	if err != nil {
+		var filterErr *services.FilterError
+		switch {
+		case errors.As(err, &filterErr):
+			respondFilterError(c, filterErr)
+		case err == services.ErrInvalidFileFormat, err == services.ErrInvalidExportColumn:
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	filename := fmt.Sprintf("songs-%s.%s", time.Now().Format("20060102"), export.Format)
+	c.Header("Content-Type", exportContentTypes[export.Format])
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// Заголовки уже отправлены, поэтому ошибку остаётся только записать в лог
+	if err := export.Write(c.Writer); err != nil {
+		utils.GetLogger().Errorf("Выгрузка песен прервана: %v", err)
+	}
+}
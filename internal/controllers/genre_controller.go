@@ -0,0 +1,258 @@
+// genre_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"song_library/internal/models"
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GenreController struct {
+	GenreService *services.GenreService
+	MaxLimit     int
+}
+
+func NewGenreController(genreService *services.GenreService, maxLimit int) *GenreController {
+	return &GenreController{
+		GenreService: genreService,
+		MaxLimit:     maxLimit,
+	}
+}
+
+// GetGenres godoc
+// @Summary      Получить справочник жанров
+// @Description  Получить список жанров по алфавиту с пагинацией
+// @Tags         genres
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int  false  "Номер страницы"
+// @Param        limit  query     int  false  "Количество элементов на странице"
+// @Success      200    {array}   models.Genre
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/genres [get]
+func (gc *GenreController) GetGenres(c *gin.Context) {
+	pagination := utils.NewPaginationFromRequest(c, gc.MaxLimit)
+
+	genres, err := gc.GenreService.GetGenres(pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, genres)
+}
+
+// GetGenre godoc
+// @Summary      Получить жанр
+// @Description  Получить жанр по ID
+// @Tags         genres
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID жанра"
+// @Success      200  {object}  models.Genre
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/genres/{id} [get]
+func (gc *GenreController) GetGenre(c *gin.Context) {
+	genreID, ok := parseGenreID(c, "id")
+	if !ok {
+		return
+	}
+
+	genre, err := gc.GenreService.GetGenre(genreID)
+	if err != nil {
+		respondGenreError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, genre)
+}
+
+// AddGenre godoc
+// @Summary      Добавить жанр
+// @Description  Добавить жанр в справочник. Названия сравниваются без учёта регистра и лишних пробелов
+// @Tags         genres
+// @Accept       json
+// @Produce      json
+// @Param        genre  body      models.CreateGenreRequest  true  "Данные жанра"
+// @Success      201    {object}  models.Genre
+// @Failure      400    {object}  utils.HTTPError
+// @Failure      409    {object}  utils.HTTPError
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/genres [post]
+func (gc *GenreController) AddGenre(c *gin.Context) {
+	var req models.CreateGenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	genre, err := gc.GenreService.AddGenre(req)
+	if err != nil {
+		respondGenreError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, genre)
+}
+
+// UpdateGenre godoc
+// @Summary      Переименовать жанр
+// @Description  Переименовать жанр; песни с этим жанром получают новое название
+// @Tags         genres
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                     true  "ID жанра"
+// @Param        genre  body      models.UpdateGenreRequest  true  "Новое название"
+// @Success      200    {object}  models.Genre
+// @Failure      400    {object}  utils.HTTPError
+// @Failure      404    {object}  utils.HTTPError
+// @Failure      409    {object}  utils.HTTPError
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/genres/{id} [put]
+func (gc *GenreController) UpdateGenre(c *gin.Context) {
+	genreID, ok := parseGenreID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateGenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	genre, err := gc.GenreService.UpdateGenre(genreID, req)
+	if err != nil {
+		respondGenreError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, genre)
+}
+
+// DeleteGenre godoc
+// @Summary      Удалить жанр
+// @Description  Удалить жанр из справочника. Жанр снимается со всех песен
+// @Tags         genres
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID жанра"
+// @Success      204  "No Content"
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/genres/{id} [delete]
+func (gc *GenreController) DeleteGenre(c *gin.Context) {
+	genreID, ok := parseGenreID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := gc.GenreService.DeleteGenre(genreID); err != nil {
+		respondGenreError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddSongGenre godoc
+// @Summary      Назначить жанр песне
+// @Description  Назначить песне жанр из справочника. Повторное назначение ничего не меняет
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                      true  "ID песни"
+// @Param        genre  body      models.AddSongGenreRequest  true  "ID жанра"
+// @Success      200    {object}  models.Song
+// @Failure      400    {object}  utils.HTTPError
+// @Failure      404    {object}  utils.HTTPError
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/songs/{id}/genres [post]
+func (gc *GenreController) AddSongGenre(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	var req models.AddSongGenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	song, err := gc.GenreService.AddSongGenre(songID, req)
+	if err != nil {
+		respondGenreError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, song)
+}
+
+// RemoveSongGenre godoc
+// @Summary      Снять жанр с песни
+// @Description  Снять с песни назначенный жанр
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string  true  "ID песни"
+// @Param        genreId  path      string  true  "ID жанра"
+// @Success      204      "No Content"
+// @Failure      400      {object}  utils.HTTPError
+// @Failure      404      {object}  utils.HTTPError
+// @Failure      500      {object}  utils.HTTPError
+// @Router       /api/songs/{id}/genres/{genreId} [delete]
+func (gc *GenreController) RemoveSongGenre(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	genreID, ok := parseGenreID(c, "genreId")
+	if !ok {
+		return
+	}
+
+	if err := gc.GenreService.RemoveSongGenre(songID, genreID); err != nil {
+		respondGenreError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parseGenreID(c *gin.Context, param string) (uuid.UUID, bool) {
+	genreID, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID жанра"))
+		return uuid.Nil, false
+	}
+	return genreID, true
+}
+
+func respondGenreError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrGenreNotFound:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Жанр не найден"))
+	case services.ErrSongNotFound:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Песня не найдена"))
+	case services.ErrGenreNotAssigned:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+	case services.ErrGenreExists:
+		c.JSON(http.StatusConflict, utils.NewHTTPError(http.StatusConflict, err.Error()))
+	case services.ErrEmptyGenreName, services.ErrUnknownGenre:
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+	}
+}
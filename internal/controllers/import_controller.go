@@ -0,0 +1,119 @@
+// import_controller.go
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"song_library/internal/importer"
+	"song_library/internal/models"
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize - ограничение на размер файла импорта.
+const maxImportSize = 64 << 20
+
+type ImportController struct {
+	ImportService *services.ImportService
+}
+
+func NewImportController(importService *services.ImportService) *ImportController {
+	return &ImportController{
+		ImportService: importService,
+	}
+}
+
+// ImportSongs godoc
+// @Summary      Массовый импорт песен
+// @Description  Загрузить песни из CSV, JSON-массива или NDJSON в форме models.Song - в теле запроса или multipart-полем file.
+// @Description  Формат берётся из format, Content-Type или расширения файла. Колонки CSV называются как поля JSON: id, group, song, releaseDate, text, link.
+// @Description  Каждая строка проверяется отдельно; в отчёте перечислены созданные, обновлённые, пропущенные и ошибочные строки с причинами
+// @Tags         songs
+// @Accept       json,plain,mpfd
+// @Produce      json
+// @Param        file         formData  file    false  "Файл импорта"
+// @Param        format       query     string  false  "Формат файла: csv, json или ndjson"
+// @Param        onDuplicate  query     string  false  "Что делать с песнями, которые уже есть: skip (по умолчанию), overwrite или merge"
+// @Param        dryRun       query     bool    false  "Только проверить файл, ничего не сохраняя"
+// @Param        enrich       query     bool    false  "Поставить песни с пустыми датой, текстом или ссылкой в очередь обогащения"
+// @Param        X-User       header    string  false  "Автор изменения для истории ревизий"
+// @Success      200          {object}  models.ImportReport
+// @Failure      400          {object}  utils.HTTPError
+// @Failure      413          {object}  utils.HTTPError
+// @Failure      500          {object}  utils.HTTPError
+// @Router       /api/songs/import [post]
+func (ic *ImportController) ImportSongs(c *gin.Context) {
+	var options models.ImportOptions
+	if err := c.ShouldBindQuery(&options); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	body, filename, err := importBody(c)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondImportError(c, err)
+		} else {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Не удалось прочитать файл импорта"))
+		}
+		return
+	}
+	defer body.Close()
+
+	if options.Format == "" {
+		format, ok := importer.DetectFormat(c.ContentType(), filename)
+		if !ok {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Не удалось определить формат файла, укажите format"))
+			return
+		}
+		options.Format = format
+	}
+
+	report, err := ic.ImportService.Import(body, options, changedBy(c))
+	if err != nil {
+		respondImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// importBody возвращает файл из multipart-поля file или тело запроса.
+func importBody(c *gin.Context) (io.ReadCloser, string, error) {
+	if !strings.HasPrefix(c.ContentType(), "multipart/") {
+		return c.Request.Body, "", nil
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := file.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	return f, file.Filename, nil
+}
+
+func respondImportError(c *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	var fileErr *services.ImportFileError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		c.JSON(http.StatusRequestEntityTooLarge, utils.NewHTTPError(http.StatusRequestEntityTooLarge, "Файл импорта слишком большой"))
+	case errors.As(err, &fileErr):
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+	case err == services.ErrInvalidFileFormat, err == services.ErrInvalidDuplicatePolicy, err == services.ErrEnrichmentDisabled:
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+	}
+}
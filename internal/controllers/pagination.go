@@ -0,0 +1,62 @@
+// pagination.go
+package controllers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"song_library/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setPageLinks заполняет ссылки info на следующую и предыдущую страницы и пишет
+// их вместе со ссылками на первую и последнюю страницы в заголовок Link (RFC 8288).
+// Ссылки строятся из адреса запроса с теми же параметрами, кроме page, after и before.
+// В режиме курсоров ссылки ведут по курсорам, а ссылки на последнюю страницу нет.
+func setPageLinks(c *gin.Context, info *models.PageInfo) {
+	link := func(key, value string) string {
+		query := c.Request.URL.Query()
+		query.Del("page")
+		query.Del("after")
+		query.Del("before")
+		query.Set(key, value)
+		query.Set("limit", strconv.Itoa(info.Limit))
+		return (&url.URL{Path: c.Request.URL.Path, RawQuery: query.Encode()}).String()
+	}
+	page := func(number int) string {
+		return link("page", strconv.Itoa(number))
+	}
+
+	cursor := info.Page == 0
+	if cursor {
+		if info.NextCursor != "" {
+			info.Next = link("after", info.NextCursor)
+		}
+		if info.PrevCursor != "" {
+			info.Prev = link("before", info.PrevCursor)
+		}
+	} else {
+		if info.Page < *info.TotalPages {
+			info.Next = page(info.Page + 1)
+		}
+		if info.Page > 1 {
+			// Со страницы за концом списка ведём на последнюю
+			info.Prev = page(min(info.Page-1, max(*info.TotalPages, 1)))
+		}
+	}
+
+	links := []string{fmt.Sprintf(`<%s>; rel="first"`, page(1))}
+	if info.Prev != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, info.Prev))
+	}
+	if info.Next != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, info.Next))
+	}
+	if !cursor {
+		links = append(links, fmt.Sprintf(`<%s>; rel="last"`, page(max(*info.TotalPages, 1))))
+	}
+	c.Header("Link", strings.Join(links, ", "))
+}
@@ -0,0 +1,368 @@
+// playlist_controller.go
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"song_library/internal/models"
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PlaylistController struct {
+	PlaylistService *services.PlaylistService
+	MaxLimit        int
+}
+
+func NewPlaylistController(playlistService *services.PlaylistService, maxLimit int) *PlaylistController {
+	return &PlaylistController{
+		PlaylistService: playlistService,
+		MaxLimit:        maxLimit,
+	}
+}
+
+// GetPlaylists godoc
+// @Summary      Получить список плейлистов
+// @Description  Получить список плейлистов с пагинацией
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int  false  "Номер страницы"
+// @Param        limit  query     int  false  "Количество элементов на странице"
+// @Success      200    {array}   models.Playlist
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/playlists [get]
+func (pc *PlaylistController) GetPlaylists(c *gin.Context) {
+	pagination := utils.NewPaginationFromRequest(c, pc.MaxLimit)
+
+	playlists, err := pc.PlaylistService.GetPlaylists(pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, playlists)
+}
+
+// GetPlaylist godoc
+// @Summary      Получить плейлист
+// @Description  Получить плейлист по ID
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID плейлиста"
+// @Success      200  {object}  models.Playlist
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/playlists/{id} [get]
+func (pc *PlaylistController) GetPlaylist(c *gin.Context) {
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	playlist, err := pc.PlaylistService.GetPlaylist(playlistID)
+	if err != nil {
+		respondPlaylistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, playlist)
+}
+
+// AddPlaylist godoc
+// @Summary      Создать плейлист
+// @Description  Создать пустой плейлист
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        playlist  body      models.CreatePlaylistRequest  true  "Данные плейлиста"
+// @Success      201       {object}  models.Playlist
+// @Failure      400       {object}  utils.HTTPError
+// @Failure      500       {object}  utils.HTTPError
+// @Router       /api/playlists [post]
+func (pc *PlaylistController) AddPlaylist(c *gin.Context) {
+	var req models.CreatePlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	playlist, err := pc.PlaylistService.AddPlaylist(req)
+	if err != nil {
+		respondPlaylistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, playlist)
+}
+
+// UpdatePlaylist godoc
+// @Summary      Обновить плейлист
+// @Description  Обновить название и описание плейлиста. Пустые поля не изменяются
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                        true  "ID плейлиста"
+// @Param        playlist  body      models.UpdatePlaylistRequest  true  "Новые данные плейлиста"
+// @Success      200       {object}  models.Playlist
+// @Failure      400       {object}  utils.HTTPError
+// @Failure      404       {object}  utils.HTTPError
+// @Failure      500       {object}  utils.HTTPError
+// @Router       /api/playlists/{id} [put]
+func (pc *PlaylistController) UpdatePlaylist(c *gin.Context) {
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	playlist, err := pc.PlaylistService.UpdatePlaylist(playlistID, req)
+	if err != nil {
+		respondPlaylistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, playlist)
+}
+
+// DeletePlaylist godoc
+// @Summary      Удалить плейлист
+// @Description  Удалить плейлист вместе с его записями. Сами песни не удаляются
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID плейлиста"
+// @Success      204  "No Content"
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/playlists/{id} [delete]
+func (pc *PlaylistController) DeletePlaylist(c *gin.Context) {
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	if err := pc.PlaylistService.DeletePlaylist(playlistID); err != nil {
+		respondPlaylistError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetEntries godoc
+// @Summary      Получить записи плейлиста
+// @Description  Получить записи плейлиста по порядку с пагинацией. Записи удалённых песен помечаются полем songRemovedAt
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string  true   "ID плейлиста"
+// @Param        page   query     int     false  "Номер страницы"
+// @Param        limit  query     int     false  "Количество элементов на странице"
+// @Success      200    {object}  models.PlaylistEntriesResponse
+// @Failure      400    {object}  utils.HTTPError
+// @Failure      404    {object}  utils.HTTPError
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/playlists/{id}/entries [get]
+func (pc *PlaylistController) GetEntries(c *gin.Context) {
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	pagination := utils.NewPaginationFromRequest(c, pc.MaxLimit)
+
+	response, err := pc.PlaylistService.GetEntries(playlistID, pagination)
+	if err != nil {
+		respondPlaylistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetShuffledEntries godoc
+// @Summary      Перемешать плейлист
+// @Description  Получить записи плейлиста в случайном порядке. Порядок определяется seed: с тем же seed и той же страницей ответ повторяется. Если seed не передан, он генерируется и возвращается в ответе
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string  true   "ID плейлиста"
+// @Param        seed   query     int     false  "Seed для перемешивания"
+// @Param        page   query     int     false  "Номер страницы"
+// @Param        limit  query     int     false  "Количество элементов на странице"
+// @Success      200    {object}  models.PlaylistEntriesResponse
+// @Failure      400    {object}  utils.HTTPError
+// @Failure      404    {object}  utils.HTTPError
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/playlists/{id}/shuffle [get]
+func (pc *PlaylistController) GetShuffledEntries(c *gin.Context) {
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	var seed *int64
+	if raw := c.Query("seed"); raw != "" {
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный seed"))
+			return
+		}
+		seed = &value
+	}
+
+	pagination := utils.NewPaginationFromRequest(c, pc.MaxLimit)
+
+	response, err := pc.PlaylistService.GetShuffledEntries(playlistID, seed, pagination)
+	if err != nil {
+		respondPlaylistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AddEntry godoc
+// @Summary      Добавить песню в плейлист
+// @Description  Вставить песню на указанную позицию; последующие записи сдвигаются. Без позиции песня добавляется в конец. Одна песня может встречаться в плейлисте несколько раз
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                          true  "ID плейлиста"
+// @Param        entry  body      models.AddPlaylistEntryRequest  true  "Песня и позиция"
+// @Success      201    {object}  models.PlaylistEntry
+// @Failure      400    {object}  utils.HTTPError
+// @Failure      404    {object}  utils.HTTPError
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/playlists/{id}/entries [post]
+func (pc *PlaylistController) AddEntry(c *gin.Context) {
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return
+	}
+
+	var req models.AddPlaylistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	entry, err := pc.PlaylistService.AddEntry(playlistID, req)
+	if err != nil {
+		respondPlaylistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// MoveEntry godoc
+// @Summary      Переместить запись плейлиста
+// @Description  Переместить запись на новую позицию; записи между старой и новой позицией сдвигаются. Позиция больше длины плейлиста означает конец списка
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                           true  "ID плейлиста"
+// @Param        entryId  path      string                           true  "ID записи"
+// @Param        entry    body      models.MovePlaylistEntryRequest  true  "Новая позиция"
+// @Success      200      {object}  models.PlaylistEntry
+// @Failure      400      {object}  utils.HTTPError
+// @Failure      404      {object}  utils.HTTPError
+// @Failure      500      {object}  utils.HTTPError
+// @Router       /api/playlists/{id}/entries/{entryId} [patch]
+func (pc *PlaylistController) MoveEntry(c *gin.Context) {
+	playlistID, entryID, ok := parsePlaylistEntryID(c)
+	if !ok {
+		return
+	}
+
+	var req models.MovePlaylistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	entry, err := pc.PlaylistService.MoveEntry(playlistID, entryID, req)
+	if err != nil {
+		respondPlaylistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// RemoveEntry godoc
+// @Summary      Удалить запись из плейлиста
+// @Description  Удалить запись из плейлиста; последующие записи сдвигаются вверх
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string  true  "ID плейлиста"
+// @Param        entryId  path      string  true  "ID записи"
+// @Success      204      "No Content"
+// @Failure      400      {object}  utils.HTTPError
+// @Failure      404      {object}  utils.HTTPError
+// @Failure      500      {object}  utils.HTTPError
+// @Router       /api/playlists/{id}/entries/{entryId} [delete]
+func (pc *PlaylistController) RemoveEntry(c *gin.Context) {
+	playlistID, entryID, ok := parsePlaylistEntryID(c)
+	if !ok {
+		return
+	}
+
+	if err := pc.PlaylistService.RemoveEntry(playlistID, entryID); err != nil {
+		respondPlaylistError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parsePlaylistID(c *gin.Context) (uuid.UUID, bool) {
+	playlistID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID плейлиста"))
+		return uuid.Nil, false
+	}
+	return playlistID, true
+}
+
+func parsePlaylistEntryID(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	playlistID, ok := parsePlaylistID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	entryID, err := uuid.Parse(c.Param("entryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID записи плейлиста"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return playlistID, entryID, true
+}
+
+func respondPlaylistError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrPlaylistNotFound:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Плейлист не найден"))
+	case services.ErrPlaylistEntryNotFound:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Запись плейлиста не найдена"))
+	case services.ErrEmptyPlaylistName, services.ErrPlaylistSongNotFound:
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+	}
+}
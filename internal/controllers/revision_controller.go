@@ -0,0 +1,207 @@
+// revision_controller.go
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"song_library/internal/models"
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RevisionController struct {
+	RevisionService *services.RevisionService
+	MaxLimit        int
+}
+
+func NewRevisionController(revisionService *services.RevisionService, maxLimit int) *RevisionController {
+	return &RevisionController{
+		RevisionService: revisionService,
+		MaxLimit:        maxLimit,
+	}
+}
+
+// GetRevisions godoc
+// @Summary      Получить историю изменений песни
+// @Description  Получить ревизии песни, начиная с последней, с пагинацией
+// @Tags         revisions
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string  true   "ID песни"
+// @Param        page   query     int     false  "Номер страницы"
+// @Param        limit  query     int     false  "Количество ревизий на странице"
+// @Success      200    {object}  models.SongRevisionsResponse
+// @Failure      400    {object}  utils.HTTPError
+// @Failure      404    {object}  utils.HTTPError
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/songs/{id}/revisions [get]
+func (rc *RevisionController) GetRevisions(c *gin.Context) {
+	songID, ok := parseRevisionSongID(c)
+	if !ok {
+		return
+	}
+
+	pagination := utils.NewPaginationFromRequest(c, rc.MaxLimit)
+
+	revisions, err := rc.RevisionService.GetRevisions(songID, pagination)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// GetRevision godoc
+// @Summary      Получить ревизию песни
+// @Description  Получить полное состояние песни в ревизии
+// @Tags         revisions
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID песни"
+// @Param        rev  path      int     true  "Номер ревизии"
+// @Success      200  {object}  models.SongRevision
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/songs/{id}/revisions/{rev} [get]
+func (rc *RevisionController) GetRevision(c *gin.Context) {
+	songID, ok := parseRevisionSongID(c)
+	if !ok {
+		return
+	}
+	revision, ok := parseRevision(c)
+	if !ok {
+		return
+	}
+
+	found, err := rc.RevisionService.GetRevision(songID, revision)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, found)
+}
+
+// DiffRevisions godoc
+// @Summary      Сравнить ревизии песни
+// @Description  Построчный diff текста и список изменённых полей между двумя ревизиями.
+// @Description  Без to сравнивается последняя ревизия, без from - ревизия перед to
+// @Tags         revisions
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true   "ID песни"
+// @Param        from  query     int     false  "Номер старой ревизии"
+// @Param        to    query     int     false  "Номер новой ревизии"
+// @Success      200   {object}  models.RevisionDiff
+// @Failure      400   {object}  utils.HTTPError
+// @Failure      404   {object}  utils.HTTPError
+// @Failure      500   {object}  utils.HTTPError
+// @Router       /api/songs/{id}/revisions/diff [get]
+func (rc *RevisionController) DiffRevisions(c *gin.Context) {
+	songID, ok := parseRevisionSongID(c)
+	if !ok {
+		return
+	}
+
+	var bounds [2]int
+	for i, name := range []string{"from", "to"} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 1 {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, services.ErrInvalidRevision.Error()))
+			return
+		}
+		bounds[i] = number
+	}
+
+	diff, err := rc.RevisionService.DiffRevisions(songID, bounds[0], bounds[1])
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RestoreRevision godoc
+// @Summary      Восстановить ревизию песни
+// @Description  Вернуть песню к состоянию ревизии. Восстановление записывается новой ревизией, история не теряется
+// @Tags         revisions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                         true   "ID песни"
+// @Param        rev      path      int                            true   "Номер восстанавливаемой ревизии"
+// @Param        restore  body      models.RestoreRevisionRequest  false  "Комментарий к восстановлению"
+// @Param        X-User   header    string                         false  "Автор изменения для истории ревизий"
+// @Success      200      {object}  models.Song
+// @Failure      400      {object}  utils.HTTPError
+// @Failure      404      {object}  utils.HTTPError
+// @Failure      500      {object}  utils.HTTPError
+// @Router       /api/songs/{id}/revisions/{rev}/restore [post]
+func (rc *RevisionController) RestoreRevision(c *gin.Context) {
+	songID, ok := parseRevisionSongID(c)
+	if !ok {
+		return
+	}
+	revision, ok := parseRevision(c)
+	if !ok {
+		return
+	}
+
+	var req models.RestoreRevisionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+			return
+		}
+	}
+
+	change := services.SongChange{ChangedBy: changedBy(c), Note: req.Note}
+	song, err := rc.RevisionService.RestoreRevision(songID, revision, change)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, song)
+}
+
+func parseRevisionSongID(c *gin.Context) (uuid.UUID, bool) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return uuid.Nil, false
+	}
+	return songID, true
+}
+
+func parseRevision(c *gin.Context) (int, bool) {
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || revision < 1 {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, services.ErrInvalidRevision.Error()))
+		return 0, false
+	}
+	return revision, true
+}
+
+func respondRevisionError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrSongNotFound:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Песня не найдена"))
+	case services.ErrRevisionNotFound:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+	case services.ErrInvalidRevision, services.ErrEmptyArtistName:
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+	}
+}
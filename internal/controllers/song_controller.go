@@ -2,8 +2,12 @@
 package controllers
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 
+	"song_library/internal/lyrics"
 	"song_library/internal/models"
 	"song_library/internal/services"
 	"song_library/internal/utils"
@@ -14,53 +18,276 @@ import (
 
 type SongController struct {
 	SongService *services.SongService
+	MaxLimit    int
 }
 
-func NewSongController(songService *services.SongService) *SongController {
+func NewSongController(songService *services.SongService, maxLimit int) *SongController {
 	return &SongController{
 		SongService: songService,
+		MaxLimit:    maxLimit,
 	}
 }
 
 // GetSongs godoc
 // @Summary      Получить список песен
-// @Description  Получить список песен с фильтрацией и пагинацией
+// @Description  Получить список песен с фильтрацией и пагинацией. По умолчанию песни возвращаются без текста;
+// @Description  fields задаёт поля песни (id есть всегда), expand добавляет связанные данные.
+// @Description  Ссылки на соседние страницы передаются в заголовке Link; число песен и страниц возвращает /api/v2/songs.
+// @Description  Песни идут в порядке добавления; вместо номера страницы можно листать курсорами after и before
 // @Tags         songs
 // @Accept       json
 // @Produce      json
-// @Param        group   query     string  false  "Название группы"
-// @Param        song    query     string  false  "Название песни"
-// @Param        page    query     int     false  "Номер страницы"
-// @Param        limit   query     int     false  "Количество элементов на странице"
-// @Success      200     {array}   models.Song
-// @Failure      400     {object}  utils.HTTPError
-// @Failure      500     {object}  utils.HTTPError
+// @Param        group     query     string    false  "Название группы"
+// @Param        song      query     string    false  "Название песни"
+// @Param        album     query     string    false  "ID альбома (песни возвращаются в порядке трек-листа)"
+// @Param        tags      query     []string  false  "Теги; можно повторять параметр или перечислить через запятую"
+// @Param        tagMatch  query     string    false  "Как сочетать теги: any - любой из тегов (по умолчанию), all - все теги"
+// @Param        genres    query     []string  false  "Жанры; песня подходит, если у неё есть любой из них"
+// @Param        enrichment  query   string    false  "Статус обогащения: pending, enriched, failed или not_found"
+// @Param        match     query     string    false  "Сравнение group и song: contains - подстрока (по умолчанию), prefix - начало названия, exact - название целиком"
+// @Param        ids       query     []string  false  "ID песен (не больше 100); можно повторять параметр или перечислить через запятую"
+// @Param        releasedFrom  query  string   false  "Дата выпуска не раньше: 2006, 2006-07 или 2006-07-16; песни без даты не попадают"
+// @Param        releasedTo    query  string   false  "Дата выпуска не позже; 2006 включает весь 2006 год"
+// @Param        createdFrom   query  string   false  "Добавлена не раньше: дата или момент в RFC 3339"
+// @Param        createdTo     query  string   false  "Добавлена не позже: дата или момент в RFC 3339"
+// @Param        updatedFrom   query  string   false  "Изменена не раньше: дата или момент в RFC 3339"
+// @Param        updatedTo     query  string   false  "Изменена не позже: дата или момент в RFC 3339"
+// @Param        hasLyrics query     bool      false  "true - только песни с текстом, false - без текста"
+// @Param        hasLink   query     bool      false  "true - только песни со ссылкой, false - без ссылки"
+// @Param        sort      query     string    false  "Сортировка: song, group, releaseDate, createdAt, updatedAt через запятую, минус - по убыванию (например, -releaseDate,group). Несовместима с курсорами"
+// @Param        fields    query     []string  false  "Поля песни: id, artistId, group, song, releaseDate, text, link, genres, tags, enrichmentStatus, enrichment, createdAt, updatedAt"
+// @Param        expand    query     []string  false  "Связанные данные: artist, albums, provenance, translations"
+// @Param        page      query     int       false  "Номер страницы"
+// @Param        limit     query     int       false  "Количество элементов на странице (не больше PAGINATION_MAX_LIMIT)"
+// @Param        after     query     string    false  "Курсор nextCursor: песни после последней песни предыдущей страницы; page не учитывается"
+// @Param        before    query     string    false  "Курсор prevCursor: песни до первой песни следующей страницы; page не учитывается"
+// @Success      200       {array}   models.SongView
+// @Header       200       {string}  Link  "Ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+// @Failure      400       {object}  utils.HTTPError
+// @Failure      500       {object}  utils.HTTPError
 // @Router       /api/songs [get]
 func (sc *SongController) GetSongs(c *gin.Context) {
-	var filter models.SongFilter
-	if err := c.ShouldBindQuery(&filter); err != nil {
-		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректные параметры запроса"))
+	songs, ok := sc.listSongs(c)
+	if !ok {
 		return
 	}
 
-	pagination := utils.NewPaginationFromRequest(c)
+	c.JSON(http.StatusOK, songs.Items)
+}
 
-	songs, err := sc.SongService.GetSongs(filter, pagination)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+// GetSongsV2 godoc
+// @Summary      Получить страницу песен
+// @Description  То же, что /api/songs, но песни возвращаются вместе с номером и размером страницы,
+// @Description  общим числом песен и страниц и ссылками на соседние страницы. Курсоры nextCursor и prevCursor
+// @Description  (кроме списка песен альбома) можно передать в after и before: такие страницы не сдвигаются,
+// @Description  когда песни добавляются, и не замедляются с глубиной; числа песен и страниц на них нет
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        group     query     string    false  "Название группы"
+// @Param        song      query     string    false  "Название песни"
+// @Param        album     query     string    false  "ID альбома (песни возвращаются в порядке трек-листа)"
+// @Param        tags      query     []string  false  "Теги; можно повторять параметр или перечислить через запятую"
+// @Param        tagMatch  query     string    false  "Как сочетать теги: any - любой из тегов (по умолчанию), all - все теги"
+// @Param        genres    query     []string  false  "Жанры; песня подходит, если у неё есть любой из них"
+// @Param        enrichment  query   string    false  "Статус обогащения: pending, enriched, failed или not_found"
+// @Param        match     query     string    false  "Сравнение group и song: contains - подстрока (по умолчанию), prefix - начало названия, exact - название целиком"
+// @Param        ids       query     []string  false  "ID песен (не больше 100); можно повторять параметр или перечислить через запятую"
+// @Param        releasedFrom  query  string   false  "Дата выпуска не раньше: 2006, 2006-07 или 2006-07-16; песни без даты не попадают"
+// @Param        releasedTo    query  string   false  "Дата выпуска не позже; 2006 включает весь 2006 год"
+// @Param        createdFrom   query  string   false  "Добавлена не раньше: дата или момент в RFC 3339"
+// @Param        createdTo     query  string   false  "Добавлена не позже: дата или момент в RFC 3339"
+// @Param        updatedFrom   query  string   false  "Изменена не раньше: дата или момент в RFC 3339"
+// @Param        updatedTo     query  string   false  "Изменена не позже: дата или момент в RFC 3339"
+// @Param        hasLyrics query     bool      false  "true - только песни с текстом, false - без текста"
+// @Param        hasLink   query     bool      false  "true - только песни со ссылкой, false - без ссылки"
+// @Param        sort      query     string    false  "Сортировка: song, group, releaseDate, createdAt, updatedAt через запятую, минус - по убыванию (например, -releaseDate,group). Несовместима с курсорами"
+// @Param        fields    query     []string  false  "Поля песни: id, artistId, group, song, releaseDate, text, link, genres, tags, enrichmentStatus, enrichment, createdAt, updatedAt"
+// @Param        expand    query     []string  false  "Связанные данные: artist, albums, provenance, translations"
+// @Param        page      query     int       false  "Номер страницы"
+// @Param        limit     query     int       false  "Количество элементов на странице (не больше PAGINATION_MAX_LIMIT)"
+// @Param        after     query     string    false  "Курсор nextCursor: песни после последней песни предыдущей страницы; page не учитывается"
+// @Param        before    query     string    false  "Курсор prevCursor: песни до первой песни следующей страницы; page не учитывается"
+// @Success      200       {object}  models.SongListResponse
+// @Header       200       {string}  Link  "Ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
+// @Failure      400       {object}  utils.HTTPError
+// @Failure      500       {object}  utils.HTTPError
+// @Router       /api/v2/songs [get]
+func (sc *SongController) GetSongsV2(c *gin.Context) {
+	songs, ok := sc.listSongs(c)
+	if !ok {
 		return
 	}
 
 	c.JSON(http.StatusOK, songs)
 }
 
+// listSongs читает страницу песен по параметрам запроса и ставит заголовок Link;
+// при ошибке ответ уже отправлен.
+func (sc *SongController) listSongs(c *gin.Context) (*models.SongListResponse, bool) {
+	filter, ok := bindSongFilter(c)
+	if !ok {
+		return nil, false
+	}
+	options, ok := bindSongViewOptions(c)
+	if !ok {
+		return nil, false
+	}
+
+	pagination := utils.NewPaginationFromRequest(c, sc.MaxLimit)
+
+	songs, err := sc.SongService.GetSongs(filter, options, pagination)
+	if err != nil {
+		var filterErr *services.FilterError
+		switch {
+		case errors.As(err, &filterErr):
+			respondFilterError(c, filterErr)
+		case err == services.ErrInvalidSongField, err == services.ErrInvalidExpand,
+			err == services.ErrInvalidCursor, err == services.ErrCursorConflict,
+			err == services.ErrCursorWithAlbum, err == services.ErrCursorWithSort:
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return nil, false
+	}
+
+	setPageLinks(c, &songs.PageInfo)
+	return songs, true
+}
+
+// GetSong godoc
+// @Summary      Получить песню
+// @Description  Получить песню по ID. По умолчанию возвращаются все поля, включая текст;
+// @Description  fields задаёт поля песни (id есть всегда), expand добавляет связанные данные
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string    true   "ID песни"
+// @Param        fields  query     []string  false  "Поля песни: id, artistId, group, song, releaseDate, text, link, genres, tags, enrichmentStatus, enrichment, createdAt, updatedAt"
+// @Param        expand  query     []string  false  "Связанные данные: artist, albums, provenance, translations"
+// @Success      200     {object}  models.SongView
+// @Failure      400     {object}  utils.HTTPError
+// @Failure      404     {object}  utils.HTTPError
+// @Failure      500     {object}  utils.HTTPError
+// @Router       /api/songs/{id} [get]
+func (sc *SongController) GetSong(c *gin.Context) {
+	idParam := c.Param("id")
+	songID, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+	options, ok := bindSongViewOptions(c)
+	if !ok {
+		return
+	}
+
+	song, err := sc.SongService.GetSong(songID, options)
+	if err != nil {
+		if err == services.ErrSongNotFound {
+			c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Песня не найдена"))
+		} else if err == services.ErrInvalidSongField || err == services.ErrInvalidExpand {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+		} else {
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, song)
+}
+
+// GetSongFacets godoc
+// @Summary      Получить фасеты песен
+// @Description  Для песен, подходящих под фильтр, вернуть общее число и число песен с каждым тегом и жанром. Параметры фильтра те же, что у списка песен
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        group     query     string    false  "Название группы"
+// @Param        song      query     string    false  "Название песни"
+// @Param        album     query     string    false  "ID альбома"
+// @Param        tags      query     []string  false  "Теги; можно повторять параметр или перечислить через запятую"
+// @Param        tagMatch  query     string    false  "Как сочетать теги: any - любой из тегов (по умолчанию), all - все теги"
+// @Param        genres    query     []string  false  "Жанры; песня подходит, если у неё есть любой из них"
+// @Param        enrichment  query   string    false  "Статус обогащения: pending, enriched, failed или not_found"
+// @Param        match     query     string    false  "Сравнение group и song: contains - подстрока (по умолчанию), prefix - начало названия, exact - название целиком"
+// @Param        ids       query     []string  false  "ID песен (не больше 100); можно повторять параметр или перечислить через запятую"
+// @Param        releasedFrom  query  string   false  "Дата выпуска не раньше: 2006, 2006-07 или 2006-07-16; песни без даты не попадают"
+// @Param        releasedTo    query  string   false  "Дата выпуска не позже; 2006 включает весь 2006 год"
+// @Param        createdFrom   query  string   false  "Добавлена не раньше: дата или момент в RFC 3339"
+// @Param        createdTo     query  string   false  "Добавлена не позже: дата или момент в RFC 3339"
+// @Param        updatedFrom   query  string   false  "Изменена не раньше: дата или момент в RFC 3339"
+// @Param        updatedTo     query  string   false  "Изменена не позже: дата или момент в RFC 3339"
+// @Param        hasLyrics query     bool      false  "true - только песни с текстом, false - без текста"
+// @Param        hasLink   query     bool      false  "true - только песни со ссылкой, false - без ссылки"
+// @Success      200       {object}  models.SongFacetsResponse
+// @Failure      400       {object}  utils.HTTPError
+// @Failure      500       {object}  utils.HTTPError
+// @Router       /api/songs/facets [get]
+func (sc *SongController) GetSongFacets(c *gin.Context) {
+	filter, ok := bindSongFilter(c)
+	if !ok {
+		return
+	}
+
+	facets, err := sc.SongService.GetSongFacets(filter)
+	if err != nil {
+		var filterErr *services.FilterError
+		switch {
+		case errors.As(err, &filterErr):
+			respondFilterError(c, filterErr)
+		default:
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, facets)
+}
+
+// SearchSongs godoc
+// @Summary      Полнотекстовый поиск песен
+// @Description  Поиск по названию и тексту песни с ранжированием, стеммингом (русский и английский) и подсветкой совпавших куплетов
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        q       query     string  true   "Поисковый запрос"
+// @Param        page    query     int     false  "Номер страницы"
+// @Param        limit   query     int     false  "Количество элементов на странице"
+// @Success      200     {object}  models.SongSearchResponse
+// @Failure      400     {object}  utils.HTTPError
+// @Failure      500     {object}  utils.HTTPError
+// @Router       /api/songs/search [get]
+func (sc *SongController) SearchSongs(c *gin.Context) {
+	pagination := utils.NewPaginationFromRequest(c, sc.MaxLimit)
+
+	results, err := sc.SongService.SearchSongs(c.Query("q"), pagination)
+	if err != nil {
+		if err == services.ErrEmptySearchQuery {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Параметр q обязателен"))
+		} else {
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
 // AddSong godoc
 // @Summary      Добавить новую песню
-// @Description  Добавить новую песню в библиотеку
+// @Description  Добавить новую песню в библиотеку. Дату выпуска, текст и ссылку можно передать сразу; как их
+// @Description  сочетать с источниками метаданных, задаёт mode. enrich (по умолчанию) - источники заполняют поля
+// @Description  в фоне, переданное поле остаётся, если источники его не знают; enrich-if-missing - переданные поля
+// @Description  блокируются, источники заполняют недостающие; manual-only - источники не опрашиваются.
+// @Description  Пока песня ждёт источников, у неё статус обогащения pending; без настроенных источников песня
+// @Description  сохраняется без обогащения в любом режиме
 // @Tags         songs
 // @Accept       json
 // @Produce      json
-// @Param        song  body      models.AddSongRequest  true  "Данные новой песни"
+// @Param        song    body      models.AddSongRequest  true   "Данные новой песни"
+// @Param        X-User  header    string                 false  "Автор изменения для истории ревизий"
 // @Success      201   {object}  models.Song
 // @Failure      400   {object}  utils.HTTPError
 // @Failure      500   {object}  utils.HTTPError
@@ -72,9 +299,14 @@ func (sc *SongController) AddSong(c *gin.Context) {
 		return
 	}
 
-	song, err := sc.SongService.AddSong(req.GroupName, req.SongTitle)
+	song, err := sc.SongService.AddSong(req, changedBy(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		if err == services.ErrEmptyArtistName || err == services.ErrInvalidSongDate ||
+			err == services.ErrInvalidLink || err == services.ErrInvalidAddSongMode {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+		} else {
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
 		return
 	}
 
@@ -83,14 +315,21 @@ func (sc *SongController) AddSong(c *gin.Context) {
 
 // GetSongLyrics godoc
 // @Summary      Получить текст песни
-// @Description  Получить текст песни по ID с пагинацией по куплетам
+// @Description  Получить текст песни по ID, размеченный на части (куплет, припев, бридж и т.д.), с пагинацией по частям.
+// @Description  Текст можно получить в переводе или рядом с переводом; выбранный язык возвращается в Content-Language
 // @Tags         songs
 // @Accept       json
-// @Produce      json
+// @Produce      json,plain
 // @Param        id      path      string  true   "ID песни"
+// @Param        mode    query     string  false  "Повторы: expand - с полным текстом (по умолчанию), collapse - только ссылка repeatOf"
+// @Param        format  query     string  false  "Формат: json (по умолчанию), lrc - экспорт LRC, elrc - расширенный LRC с метками слов"
+// @Param        lang    query     string  false  "Язык перевода (BCP-47); original - оригинал без перевода. Без lang учитывается заголовок Accept-Language"
+// @Param        view    query     string  false  "Перевод: translated - вместо оригинала (по умолчанию), side-by-side - пары оригинал-перевод"
+// @Param        Accept-Language  header  string  false  "Предпочитаемые языки перевода"
 // @Param        page    query     int     false  "Номер страницы"
-// @Param        limit   query     int     false  "Количество куплетов на странице"
+// @Param        limit   query     int     false  "Количество частей на странице"
 // @Success      200     {object}  models.SongLyricsResponse
+// @Header       200     {string}  Link  "Ссылки на первую, предыдущую, следующую и последнюю страницы (RFC 8288)"
 // @Failure      400     {object}  utils.HTTPError
 // @Failure      404     {object}  utils.HTTPError
 // @Failure      500     {object}  utils.HTTPError
@@ -103,29 +342,66 @@ func (sc *SongController) GetSongLyrics(c *gin.Context) {
 		return
 	}
 
-	pagination := utils.NewPaginationFromRequest(c)
+	switch format := c.Query("format"); format {
+	case "", "json":
+	case "lrc", "elrc":
+		lrc, err := sc.SongService.ExportLRC(songID, format == "elrc")
+		if err != nil {
+			respondSyncedLyricsError(c, err)
+			return
+		}
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(lrc))
+		return
+	default:
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Параметр format должен быть json, lrc или elrc"))
+		return
+	}
+
+	pagination := utils.NewPaginationFromRequest(c, sc.MaxLimit)
+
+	options := models.LyricsOptions{
+		Mode:            models.LyricsMode(c.Query("mode")),
+		View:            models.LyricsView(c.Query("view")),
+		Language:        c.Query("lang"),
+		AcceptLanguages: lyrics.ParseAcceptLanguage(c.GetHeader("Accept-Language")),
+	}
+
+	// Ответ зависит от Accept-Language, поэтому кэши должны учитывать этот заголовок
+	c.Header("Vary", "Accept-Language")
 
-	lyrics, err := sc.SongService.GetSongLyrics(songID, pagination)
+	songLyrics, err := sc.SongService.GetSongLyrics(songID, options, pagination)
 	if err != nil {
-		if err == services.ErrSongNotFound {
+		switch {
+		case errors.Is(err, services.ErrSongNotFound):
 			c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Песня не найдена"))
-		} else {
+		case errors.Is(err, services.ErrTranslationNotFound):
+			c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+		case errors.Is(err, services.ErrInvalidLyricsMode), errors.Is(err, services.ErrInvalidLyricsView),
+			errors.Is(err, services.ErrInvalidLanguage), errors.Is(err, services.ErrTranslationRequired):
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+		default:
 			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, lyrics)
+	if songLyrics.Translation != nil {
+		c.Header("Content-Language", songLyrics.Translation.Language)
+	}
+	setPageLinks(c, &songLyrics.PageInfo)
+	c.JSON(http.StatusOK, songLyrics)
 }
 
 // UpdateSong godoc
 // @Summary      Обновить данные песни
-// @Description  Обновить данные существующей песни по ID
+// @Description  Обновить данные существующей песни по ID. Изменение записывается новой ревизией.
+// @Description  Изменённые дата выпуска, текст и ссылка блокируются: обогащение их больше не перезаписывает
 // @Tags         songs
 // @Accept       json
 // @Produce      json
-// @Param        id    path      string                    true  "ID песни"
-// @Param        song  body      models.UpdateSongRequest  true  "Новые данные песни"
+// @Param        id      path      string                    true   "ID песни"
+// @Param        song    body      models.UpdateSongRequest  true   "Новые данные песни"
+// @Param        X-User  header    string                    false  "Автор изменения для истории ревизий"
 // @Success      200   {object}  models.Song
 // @Failure      400   {object}  utils.HTTPError
 // @Failure      404   {object}  utils.HTTPError
@@ -145,10 +421,12 @@ func (sc *SongController) UpdateSong(c *gin.Context) {
 		return
 	}
 
-	song, err := sc.SongService.UpdateSong(songID, req)
+	song, err := sc.SongService.UpdateSong(songID, req, changedBy(c))
 	if err != nil {
 		if err == services.ErrSongNotFound {
 			c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Песня не найдена"))
+		} else if err == services.ErrEmptyArtistName || err == services.ErrInvalidSongDate || err == services.ErrInvalidLink {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
 		} else {
 			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
 		}
@@ -190,3 +468,180 @@ func (sc *SongController) DeleteSong(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// ImportSyncedLyrics godoc
+// @Summary      Загрузить синхронизированный текст (LRC)
+// @Description  Загрузить LRC или расширенный LRC с метками слов - в теле запроса или multipart-полем file. Если текст песни не совпадает со строками LRC, он заменяется текстом из LRC
+// @Tags         songs
+// @Accept       plain,mpfd
+// @Produce      json
+// @Param        id    path      string  true   "ID песни"
+// @Param        file    formData  file    false  "Файл LRC"
+// @Param        X-User  header    string  false  "Автор изменения для истории ревизий"
+// @Success      200   {object}  models.SyncedLinesResponse
+// @Failure      400   {object}  utils.HTTPError
+// @Failure      404   {object}  utils.HTTPError
+// @Failure      413   {object}  utils.HTTPError
+// @Failure      500   {object}  utils.HTTPError
+// @Router       /api/songs/{id}/lyrics/synced [put]
+func (sc *SongController) ImportSyncedLyrics(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	data, err := readLRC(c)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, utils.NewHTTPError(http.StatusRequestEntityTooLarge, "Файл LRC слишком большой"))
+		} else {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Не удалось прочитать файл LRC"))
+		}
+		return
+	}
+
+	lines, err := sc.SongService.ImportSyncedLyrics(songID, data, changedBy(c))
+	if err != nil {
+		respondSyncedLyricsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lines)
+}
+
+// DeleteSyncedLyrics godoc
+// @Summary      Удалить синхронизацию текста
+// @Description  Удалить временные метки строк. Сам текст песни не меняется
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID песни"
+// @Success      204  "No Content"
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/songs/{id}/lyrics/synced [delete]
+func (sc *SongController) DeleteSyncedLyrics(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	if err := sc.SongService.DeleteSyncedLyrics(songID); err != nil {
+		respondSyncedLyricsError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSyncedLines godoc
+// @Summary      Получить строки в окне времени
+// @Description  Получить строки синхронизированного текста, звучащие в окне [from, to). Время - в секундах (83.5) или в виде mm:ss.xx. Если from и to совпадают, возвращается строка, звучащая в этот момент; без границ - все строки
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true   "ID песни"
+// @Param        from  query     string  false  "Начало окна"
+// @Param        to    query     string  false  "Конец окна"
+// @Success      200   {object}  models.SyncedLinesResponse
+// @Failure      400   {object}  utils.HTTPError
+// @Failure      404   {object}  utils.HTTPError
+// @Failure      500   {object}  utils.HTTPError
+// @Router       /api/songs/{id}/lyrics/lines [get]
+func (sc *SongController) GetSyncedLines(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	lines, err := sc.SongService.GetSyncedLines(songID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		respondSyncedLyricsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lines)
+}
+
+// maxLRCSize - ограничение на размер загружаемого LRC.
+const maxLRCSize = 1 << 20
+
+// readLRC читает LRC из multipart-поля file или из тела запроса.
+func readLRC(c *gin.Context) (string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxLRCSize)
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			return "", err
+		}
+		f, err := file.Open()
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+
+		data, err := io.ReadAll(f)
+		return string(data), err
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+	return string(data), err
+}
+
+func respondSyncedLyricsError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrSongNotFound):
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Песня не найдена"))
+	case errors.Is(err, services.ErrNoSyncedLyrics):
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+	case errors.Is(err, services.ErrInvalidLRC), errors.Is(err, services.ErrInvalidTimeWindow):
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+	}
+}
+
+// bindSongFilter разбирает параметры фильтра списка песен.
+// ID альбома разбирается вручную: gin не умеет заполнять uuid.UUID из query.
+func bindSongFilter(c *gin.Context) (models.SongFilter, bool) {
+	var filter models.SongFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректные параметры запроса"))
+		return filter, false
+	}
+	if albumParam := c.Query("album"); albumParam != "" {
+		albumID, err := uuid.Parse(albumParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID альбома"))
+			return filter, false
+		}
+		filter.AlbumID = albumID
+	}
+	return filter, true
+}
+
+// respondFilterError отвечает 400 со списком всех ошибок в параметрах фильтра.
+func respondFilterError(c *gin.Context, err *services.FilterError) {
+	c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректные параметры фильтра").WithDetails(err.Details))
+}
+
+func bindSongViewOptions(c *gin.Context) (models.SongViewOptions, bool) {
+	var options models.SongViewOptions
+	if err := c.ShouldBindQuery(&options); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректные параметры запроса"))
+		return options, false
+	}
+	return options, true
+}
+
+// changedBy возвращает автора изменения для истории ревизий.
+// Аутентификации в сервисе нет, поэтому автор передаётся заголовком X-User.
+func changedBy(c *gin.Context) string {
+	return strings.TrimSpace(c.GetHeader("X-User"))
+}
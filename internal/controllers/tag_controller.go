@@ -0,0 +1,99 @@
+// tag_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"song_library/internal/models"
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TagController struct {
+	TagService *services.TagService
+}
+
+func NewTagController(tagService *services.TagService) *TagController {
+	return &TagController{
+		TagService: tagService,
+	}
+}
+
+// AddSongTags godoc
+// @Summary      Добавить теги песне
+// @Description  Назначить песне произвольные теги. Новые теги создаются автоматически, уже назначенные пропускаются
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                     true  "ID песни"
+// @Param        tags  body      models.AddSongTagsRequest  true  "Теги"
+// @Success      200   {object}  models.Song
+// @Failure      400   {object}  utils.HTTPError
+// @Failure      404   {object}  utils.HTTPError
+// @Failure      500   {object}  utils.HTTPError
+// @Router       /api/songs/{id}/tags [post]
+func (tc *TagController) AddSongTags(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	var req models.AddSongTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректное тело запроса"))
+		return
+	}
+
+	song, err := tc.TagService.AddSongTags(songID, req)
+	if err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, song)
+}
+
+// RemoveSongTag godoc
+// @Summary      Снять тег с песни
+// @Description  Снять с песни тег по названию (без учёта регистра)
+// @Tags         songs
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID песни"
+// @Param        tag  path      string  true  "Название тега"
+// @Success      204  "No Content"
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/songs/{id}/tags/{tag} [delete]
+func (tc *TagController) RemoveSongTag(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	if err := tc.TagService.RemoveSongTag(songID, c.Param("tag")); err != nil {
+		respondTagError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondTagError(c *gin.Context, err error) {
+	switch err {
+	case services.ErrSongNotFound:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Песня не найдена"))
+	case services.ErrTagNotAssigned:
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+	case services.ErrInvalidTag:
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+	}
+}
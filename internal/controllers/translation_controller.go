@@ -0,0 +1,170 @@
+// translation_controller.go
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"song_library/internal/models"
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TranslationController struct {
+	TranslationService *services.TranslationService
+}
+
+func NewTranslationController(translationService *services.TranslationService) *TranslationController {
+	return &TranslationController{
+		TranslationService: translationService,
+	}
+}
+
+// GetTranslations godoc
+// @Summary      Получить переводы песни
+// @Description  Получить все переводы текста песни, упорядоченные по языку
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID песни"
+// @Success      200  {array}   models.Translation
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/songs/{id}/translations [get]
+func (tc *TranslationController) GetTranslations(c *gin.Context) {
+	songID, ok := parseTranslationSongID(c)
+	if !ok {
+		return
+	}
+
+	translations, err := tc.TranslationService.GetTranslations(songID)
+	if err != nil {
+		respondTranslationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, translations)
+}
+
+// GetTranslation godoc
+// @Summary      Получить перевод песни
+// @Description  Получить перевод текста песни на язык вместе с выравниванием по частям оригинала
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "ID песни"
+// @Param        lang  path      string  true  "Язык перевода (BCP-47)"
+// @Success      200   {object}  models.Translation
+// @Failure      400   {object}  utils.HTTPError
+// @Failure      404   {object}  utils.HTTPError
+// @Failure      500   {object}  utils.HTTPError
+// @Router       /api/songs/{id}/translations/{lang} [get]
+func (tc *TranslationController) GetTranslation(c *gin.Context) {
+	songID, ok := parseTranslationSongID(c)
+	if !ok {
+		return
+	}
+
+	translation, err := tc.TranslationService.GetTranslation(songID, c.Param("lang"))
+	if err != nil {
+		respondTranslationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+// SaveTranslation godoc
+// @Summary      Сохранить перевод песни
+// @Description  Создать или заменить перевод текста песни на язык. Текст из поля text делится на части
+// @Description  и выравнивается по частям оригинала; если число частей не совпадает, передайте verses с позициями
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Param        id           path      string                         true  "ID песни"
+// @Param        lang         path      string                         true  "Язык перевода (BCP-47)"
+// @Param        translation  body      models.SaveTranslationRequest  true  "Перевод"
+// @Success      200          {object}  models.Translation
+// @Success      201          {object}  models.Translation
+// @Failure      400          {object}  utils.HTTPError
+// @Failure      404          {object}  utils.HTTPError
+// @Failure      500          {object}  utils.HTTPError
+// @Router       /api/songs/{id}/translations/{lang} [put]
+func (tc *TranslationController) SaveTranslation(c *gin.Context) {
+	songID, ok := parseTranslationSongID(c)
+	if !ok {
+		return
+	}
+
+	var req models.SaveTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	translation, created, err := tc.TranslationService.SaveTranslation(songID, c.Param("lang"), req)
+	if err != nil {
+		respondTranslationError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, translation)
+}
+
+// DeleteTranslation godoc
+// @Summary      Удалить перевод песни
+// @Description  Удалить перевод текста песни на язык
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string  true  "ID песни"
+// @Param        lang  path      string  true  "Язык перевода (BCP-47)"
+// @Success      204   "No Content"
+// @Failure      400   {object}  utils.HTTPError
+// @Failure      404   {object}  utils.HTTPError
+// @Failure      500   {object}  utils.HTTPError
+// @Router       /api/songs/{id}/translations/{lang} [delete]
+func (tc *TranslationController) DeleteTranslation(c *gin.Context) {
+	songID, ok := parseTranslationSongID(c)
+	if !ok {
+		return
+	}
+
+	if err := tc.TranslationService.DeleteTranslation(songID, c.Param("lang")); err != nil {
+		respondTranslationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parseTranslationSongID(c *gin.Context) (uuid.UUID, bool) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return uuid.Nil, false
+	}
+	return songID, true
+}
+
+func respondTranslationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrSongNotFound):
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, "Песня не найдена"))
+	case errors.Is(err, services.ErrTranslationNotFound):
+		c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+	case errors.Is(err, services.ErrInvalidLanguage), errors.Is(err, services.ErrEmptyTranslation),
+		errors.Is(err, services.ErrTranslationMisaligned), errors.Is(err, services.ErrInvalidVersePosition):
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+	}
+}
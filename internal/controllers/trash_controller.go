@@ -0,0 +1,79 @@
+// trash_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"song_library/internal/services"
+	"song_library/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TrashController struct {
+	TrashService *services.TrashService
+	MaxLimit     int
+}
+
+func NewTrashController(trashService *services.TrashService, maxLimit int) *TrashController {
+	return &TrashController{
+		TrashService: trashService,
+		MaxLimit:     maxLimit,
+	}
+}
+
+// GetTrash godoc
+// @Summary      Получить корзину
+// @Description  Получить удалённые песни, начиная с удалённых последними. purgeAt - когда песня будет удалена окончательно
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int  false  "Номер страницы"
+// @Param        limit  query     int  false  "Количество элементов на странице"
+// @Success      200    {object}  models.TrashResponse
+// @Failure      500    {object}  utils.HTTPError
+// @Router       /api/trash [get]
+func (tc *TrashController) GetTrash(c *gin.Context) {
+	pagination := utils.NewPaginationFromRequest(c, tc.MaxLimit)
+
+	trash, err := tc.TrashService.GetTrash(pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
+
+// RestoreSong godoc
+// @Summary      Восстановить песню из корзины
+// @Description  Вернуть удалённую песню в библиотеку; записи плейлистов снова указывают на неё
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ID песни"
+// @Success      200  {object}  models.Song
+// @Failure      400  {object}  utils.HTTPError
+// @Failure      404  {object}  utils.HTTPError
+// @Failure      500  {object}  utils.HTTPError
+// @Router       /api/songs/{id}/restore [post]
+func (tc *TrashController) RestoreSong(c *gin.Context) {
+	songID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHTTPError(http.StatusBadRequest, "Некорректный ID песни"))
+		return
+	}
+
+	song, err := tc.TrashService.RestoreSong(songID)
+	if err != nil {
+		if err == services.ErrSongNotInTrash {
+			c.JSON(http.StatusNotFound, utils.NewHTTPError(http.StatusNotFound, err.Error()))
+		} else {
+			c.JSON(http.StatusInternalServerError, utils.NewHTTPError(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, song)
+}
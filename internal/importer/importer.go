@@ -0,0 +1,204 @@
+// importer.go
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"song_library/internal/models"
+)
+
+// MaxRows - наибольшее число строк в одном файле импорта.
+const MaxRows = 10000
+
+var (
+	ErrMissingColumns = errors.New("в заголовке CSV нет обязательных колонок group и song")
+	ErrNotArray       = errors.New("JSON должен быть массивом песен")
+	ErrTooManyRows    = fmt.Errorf("в файле больше %d строк", MaxRows)
+)
+
+// Record - разобранная строка файла. Err - ошибка разбора строки:
+// остальные строки файла при этом всё равно разбираются.
+type Record struct {
+	Row  int
+	Song models.ImportSong
+	Err  error
+}
+
+// Parse разбирает файл импорта. Ошибка возвращается, только если файл
+// нельзя разобрать целиком; ошибки отдельных строк попадают в Record.Err.
+func Parse(format models.FileFormat, r io.Reader) ([]Record, error) {
+	r = skipBOM(r)
+	switch format {
+	case models.FormatCSV:
+		return parseCSV(r)
+	case models.FormatJSON:
+		return parseJSON(r)
+	case models.FormatNDJSON:
+		return parseNDJSON(r)
+	default:
+		return nil, fmt.Errorf("неизвестный формат импорта: %s", format)
+	}
+}
+
+// skipBOM пропускает метку порядка байтов UTF-8, с которой файлы сохраняют Excel и Блокнот.
+func skipBOM(r io.Reader) io.Reader {
+	reader := bufio.NewReader(r)
+	if bom, err := reader.Peek(3); err == nil && bytes.Equal(bom, []byte("\uFEFF")) {
+		reader.Discard(3)
+	}
+	return reader
+}
+
+// DetectFormat определяет формат по Content-Type или расширению файла.
+func DetectFormat(contentType, filename string) (models.FileFormat, bool) {
+	switch contentType {
+	case "text/csv":
+		return models.FormatCSV, true
+	case "application/json":
+		return models.FormatJSON, true
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return models.FormatNDJSON, true
+	}
+
+	name := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(name, ".csv"):
+		return models.FormatCSV, true
+	case strings.HasSuffix(name, ".ndjson"), strings.HasSuffix(name, ".jsonl"):
+		return models.FormatNDJSON, true
+	case strings.HasSuffix(name, ".json"):
+		return models.FormatJSON, true
+	}
+	return "", false
+}
+
+// csvColumns связывает колонки CSV с полями песни; названия колонок
+// совпадают с полями JSON и сравниваются без учёта регистра. Остальные колонки
+// (например, createdAt из экспорта) игнорируются.
+var csvColumns = map[string]func(song *models.ImportSong) *string{
+	"id":          func(song *models.ImportSong) *string { return &song.ID },
+	"group":       func(song *models.ImportSong) *string { return &song.GroupName },
+	"song":        func(song *models.ImportSong) *string { return &song.SongTitle },
+	"releasedate": func(song *models.ImportSong) *string { return &song.ReleaseDate },
+	"text":        func(song *models.ImportSong) *string { return &song.Text },
+	"link":        func(song *models.ImportSong) *string { return &song.Link },
+}
+
+func parseCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("не удалось прочитать заголовок CSV: %w", err)
+	}
+
+	fields := make([]func(song *models.ImportSong) *string, len(header))
+	found := map[string]bool{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		fields[i] = csvColumns[name]
+		found[name] = true
+	}
+	if !found["group"] || !found["song"] {
+		return nil, ErrMissingColumns
+	}
+
+	var records []Record
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			records = append(records, Record{Row: parseErr.StartLine, Err: fmt.Errorf("некорректная строка CSV: %v", parseErr.Err)})
+		} else if err != nil {
+			return nil, err
+		} else {
+			line, _ := reader.FieldPos(0)
+			record := Record{Row: line}
+			for i, value := range values {
+				if i < len(fields) && fields[i] != nil {
+					*fields[i](&record.Song) = value
+				}
+			}
+			records = append(records, record)
+		}
+
+		if len(records) > MaxRows {
+			return nil, ErrTooManyRows
+		}
+	}
+	return records, nil
+}
+
+func parseJSON(r io.Reader) ([]Record, error) {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("некорректный JSON: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, ErrNotArray
+	}
+
+	var records []Record
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("некорректный JSON: %w", err)
+		}
+		records = append(records, decodeRecord(len(records)+1, raw))
+		if len(records) > MaxRows {
+			return nil, ErrTooManyRows
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("некорректный JSON: %w", err)
+	}
+	return records, nil
+}
+
+func parseNDJSON(r io.Reader) ([]Record, error) {
+	reader := bufio.NewReader(r)
+
+	var records []Record
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(data)) > 0 {
+			records = append(records, decodeRecord(line, data))
+			if len(records) > MaxRows {
+				return nil, ErrTooManyRows
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func decodeRecord(row int, data []byte) Record {
+	record := Record{Row: row}
+	if err := json.Unmarshal(data, &record.Song); err != nil {
+		record.Err = fmt.Errorf("некорректная строка JSON: %v", err)
+	}
+	return record
+}
@@ -0,0 +1,174 @@
+// importer_test.go
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"song_library/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	// row - ожидаемая строка; failed означает ошибку разбора строки в Record.Err
+	type row struct {
+		row    int
+		group  string
+		song   string
+		link   string
+		failed bool
+	}
+
+	tests := []struct {
+		name    string
+		format  models.FileFormat
+		data    string
+		want    []row
+		wantErr error
+		fileErr bool
+	}{
+		{
+			name:   "CSV с BOM, регистром и лишними колонками в заголовке",
+			format: models.FormatCSV,
+			data:   "\uFEFFGroup, SONG ,createdAt,Link\nMuse,Uprising,2020-01-01,https://example.com\n",
+			want:   []row{{row: 2, group: "Muse", song: "Uprising", link: "https://example.com"}},
+		},
+		{
+			name:   "CSV с BOM перед заголовком в кавычках",
+			format: models.FormatCSV,
+			data:   "\uFEFF\"group\",\"song\"\r\nMuse,Uprising\r\n",
+			want:   []row{{row: 2, group: "Muse", song: "Uprising"}},
+		},
+		{
+			name:    "CSV без обязательной колонки",
+			format:  models.FormatCSV,
+			data:    "group,title\nMuse,Uprising\n",
+			wantErr: ErrMissingColumns,
+		},
+		{
+			name:   "пустой CSV",
+			format: models.FormatCSV,
+			data:   "",
+		},
+		{
+			name:   "CSV с некорректной, короткой и многострочной строками",
+			format: models.FormatCSV,
+			data:   "group,song,text\nMuse,Up\"rising,\nKino\n\"A\",\"B\",\"line 1\nline 2\"\nC,D,\n",
+			want: []row{
+				{row: 2, failed: true},
+				{row: 3, group: "Kino"},
+				{row: 4, group: "A", song: "B"},
+				{row: 6, group: "C", song: "D"},
+			},
+		},
+		{
+			name:   "JSON с BOM",
+			format: models.FormatJSON,
+			data:   "\uFEFF[{\"group\":\"Muse\",\"song\":\"Uprising\",\"createdAt\":\"2020\"}]",
+			want:   []row{{row: 1, group: "Muse", song: "Uprising"}},
+		},
+		{
+			name:   "JSON с элементами неверного типа",
+			format: models.FormatJSON,
+			data:   `[{"group":"Muse","song":"Uprising"}, {"group":1}, "Muse", {"group":"Kino","song":"Kukushka"}]`,
+			want: []row{
+				{row: 1, group: "Muse", song: "Uprising"},
+				{row: 2, failed: true},
+				{row: 3, failed: true},
+				{row: 4, group: "Kino", song: "Kukushka"},
+			},
+		},
+		{
+			name:    "JSON не массив",
+			format:  models.FormatJSON,
+			data:    `{"group":"Muse","song":"Uprising"}`,
+			wantErr: ErrNotArray,
+		},
+		{
+			name:    "оборванный JSON",
+			format:  models.FormatJSON,
+			data:    `[{"group":"Muse","song":"Uprising"}`,
+			fileErr: true,
+		},
+		{
+			name:   "NDJSON с BOM, пустыми и некорректными строками",
+			format: models.FormatNDJSON,
+			data:   "\uFEFF{\"group\":\"Muse\",\"song\":\"Uprising\"}\r\n\n{bad\n{\"group\":\"Kino\",\"song\":\"Kukushka\"}",
+			want: []row{
+				{row: 1, group: "Muse", song: "Uprising"},
+				{row: 3, failed: true},
+				{row: 4, group: "Kino", song: "Kukushka"},
+			},
+		},
+		{
+			name:    "слишком много строк",
+			format:  models.FormatNDJSON,
+			data:    strings.Repeat("{\"group\":\"Muse\",\"song\":\"Uprising\"}\n", MaxRows+1),
+			wantErr: ErrTooManyRows,
+		},
+		{
+			name:    "неизвестный формат",
+			format:  models.FileFormat("xml"),
+			data:    "<songs/>",
+			fileErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := Parse(tt.format, strings.NewReader(tt.data))
+			if tt.wantErr != nil || tt.fileErr {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("Parse = %v, ожидалась ошибка %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			if len(records) != len(tt.want) {
+				t.Fatalf("Parse вернул %d строк, ожидалось %d: %+v", len(records), len(tt.want), records)
+			}
+			for i, want := range tt.want {
+				got := records[i]
+				if got.Row != want.row || (got.Err != nil) != want.failed {
+					t.Errorf("строка %d: номер %d, ошибка %v; ожидались номер %d и ошибка %v",
+						i+1, got.Row, got.Err, want.row, want.failed)
+					continue
+				}
+				if want.failed {
+					continue
+				}
+				if got.Song.GroupName != want.group || got.Song.SongTitle != want.song || got.Song.Link != want.link {
+					t.Errorf("строка %d = %+v, ожидалось group %q, song %q, link %q",
+						want.row, got.Song, want.group, want.song, want.link)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		contentType string
+		filename    string
+		want        models.FileFormat
+		ok          bool
+	}{
+		{contentType: "text/csv", filename: "songs.json", want: models.FormatCSV, ok: true},
+		{contentType: "application/x-ndjson", want: models.FormatNDJSON, ok: true},
+		{contentType: "application/octet-stream", filename: "Songs.CSV", want: models.FormatCSV, ok: true},
+		{filename: "export.jsonl", want: models.FormatNDJSON, ok: true},
+		{filename: "export.json", want: models.FormatJSON, ok: true},
+		{contentType: "text/plain", filename: "songs.txt"},
+	}
+
+	for _, tt := range tests {
+		got, ok := DetectFormat(tt.contentType, tt.filename)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("DetectFormat(%q, %q) = %q, %v, ожидалось %q, %v",
+				tt.contentType, tt.filename, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// diff.go
+package lyrics
+
+import (
+	"strings"
+
+	"song_library/internal/models"
+)
+
+// DiffLines сравнивает тексты построчно по наибольшей общей подпоследовательности.
+// Удалённые строки идут перед добавленными на том же месте, как в unified diff.
+func DiffLines(oldText, newText string) []models.DiffLine {
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+
+	// common[i][j] - длина общей подпоследовательности oldLines[i:] и newLines[j:]
+	common := make([][]int, len(oldLines)+1)
+	for i := range common {
+		common[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	diff := make([]models.DiffLine, 0, max(len(oldLines), len(newLines)))
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			diff = append(diff, models.DiffLine{Op: models.DiffEqual, OldLine: position(i + 1), NewLine: position(j + 1), Text: oldLines[i]})
+			i++
+			j++
+		case j == len(newLines) || (i < len(oldLines) && common[i+1][j] >= common[i][j+1]):
+			diff = append(diff, models.DiffLine{Op: models.DiffDelete, OldLine: position(i + 1), Text: oldLines[i]})
+			i++
+		default:
+			diff = append(diff, models.DiffLine{Op: models.DiffInsert, NewLine: position(j + 1), Text: newLines[j]})
+			j++
+		}
+	}
+	return diff
+}
+
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
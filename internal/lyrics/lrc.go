@@ -0,0 +1,321 @@
+// lrc.go
+package lyrics
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"song_library/internal/models"
+)
+
+var ErrInvalidLRC = errors.New("некорректный LRC: нет строк с временными метками")
+
+var (
+	// lineTagPattern - метка времени строки: [mm:ss], [mm:ss.xx], [mm:ss.xxx], [mm:ss:xx]
+	lineTagPattern = regexp.MustCompile(`^\[(\d+):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+	// wordTagPattern - метка времени слова в расширенном LRC: <mm:ss.xx>
+	wordTagPattern = regexp.MustCompile(`<(\d+):(\d{1,2})(?:[.:](\d{1,3}))?>`)
+	// metaTagPattern - теги метаданных: [ar:...], [ti:...], [offset:...]
+	metaTagPattern = regexp.MustCompile(`^\[([a-zA-Z]+):(.*)\]$`)
+	// secondsPattern - секунды с необязательной дробной частью в ParseTimestamp
+	secondsPattern = regexp.MustCompile(`^(\d{1,9})(?:\.(\d{1,9}))?$`)
+)
+
+// LRC - разобранный файл LRC.
+type LRC struct {
+	Title  string
+	Artist string
+	Album  string
+	Lines  []models.SyncedLine
+}
+
+// ParseLRC разбирает LRC и расширенный LRC (метки слов <mm:ss.xx>).
+// Строки с несколькими метками повторяются для каждой метки, [offset:] учитывается.
+// Пустые строки с меткой времени - паузы между куплетами.
+func ParseLRC(data string) (*LRC, error) {
+	data = strings.TrimPrefix(data, "\uFEFF")
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+
+	result := &LRC{}
+	var offset, length int64
+
+	for _, raw := range strings.Split(data, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+
+		var starts []int64
+		for {
+			match := lineTagPattern.FindStringSubmatch(line)
+			if match == nil {
+				break
+			}
+			starts = append(starts, tagMillis(match[1], match[2], match[3]))
+			line = line[len(match[0]):]
+		}
+
+		if len(starts) == 0 {
+			if meta := metaTagPattern.FindStringSubmatch(line); meta != nil {
+				value := strings.TrimSpace(meta[2])
+				switch strings.ToLower(meta[1]) {
+				case "ti":
+					result.Title = value
+				case "ar":
+					result.Artist = value
+				case "al":
+					result.Album = value
+				case "offset":
+					offset, _ = strconv.ParseInt(strings.TrimPrefix(value, "+"), 10, 64)
+				case "length":
+					length, _ = ParseTimestamp(value)
+				}
+			}
+			continue
+		}
+
+		text, words := parseWords(line)
+		for _, start := range starts {
+			result.Lines = append(result.Lines, models.SyncedLine{
+				StartMs: start,
+				Text:    text,
+				// Метки слов записаны для первого появления строки, для повторов их нужно сдвинуть
+				Words: shiftWords(words, start-starts[0]),
+			})
+		}
+	}
+
+	if len(result.Lines) == 0 {
+		return nil, ErrInvalidLRC
+	}
+
+	// Положительный offset означает, что текст нужно показывать раньше
+	for i := range result.Lines {
+		result.Lines[i].StartMs = clampMillis(result.Lines[i].StartMs - offset)
+		result.Lines[i].Words = shiftWords(result.Lines[i].Words, -offset)
+	}
+
+	sort.SliceStable(result.Lines, func(i, j int) bool {
+		return result.Lines[i].StartMs < result.Lines[j].StartMs
+	})
+
+	for i := range result.Lines {
+		result.Lines[i].LineNo = i + 1
+		if i+1 < len(result.Lines) {
+			end := result.Lines[i+1].StartMs
+			result.Lines[i].EndMs = &end
+		} else if length > result.Lines[i].StartMs {
+			end := length
+			result.Lines[i].EndMs = &end
+		}
+	}
+
+	return result, nil
+}
+
+// PlainText собирает текст песни из строк LRC; паузы становятся границами куплетов.
+func (l *LRC) PlainText() string {
+	var verses []string
+	var current []string
+	for _, line := range l.Lines {
+		if line.Text == "" {
+			if len(current) > 0 {
+				verses = append(verses, strings.Join(current, "\n"))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line.Text)
+	}
+	if len(current) > 0 {
+		verses = append(verses, strings.Join(current, "\n"))
+	}
+	return strings.Join(verses, "\n\n")
+}
+
+// AssignVerses проставляет строкам позиции частей текста, сопоставляя строки по порядку.
+// Возвращает false, если какая-то строка не нашлась в тексте - тогда текст песни
+// не соответствует LRC.
+func AssignVerses(lines []models.SyncedLine, sections []models.LyricsSection) bool {
+	type sectionLine struct {
+		position int
+		text     string
+	}
+	var textLines []sectionLine
+	for _, section := range sections {
+		for _, line := range strings.Split(section.Text, "\n") {
+			if key := normalizeText(line); key != "" {
+				textLines = append(textLines, sectionLine{section.Position, key})
+			}
+		}
+	}
+
+	matched := true
+	next := 0
+	for i := range lines {
+		lines[i].Verse = 0
+		key := normalizeText(lines[i].Text)
+		if key == "" {
+			continue
+		}
+		found := false
+		for j := next; j < len(textLines); j++ {
+			if textLines[j].text == key {
+				lines[i].Verse = textLines[j].position
+				next = j + 1
+				found = true
+				break
+			}
+		}
+		matched = matched && found
+	}
+	return matched
+}
+
+// FormatLRC собирает LRC из строк. При withWords добавляются метки слов (расширенный LRC).
+func FormatLRC(title, artist string, lines []models.SyncedLine, withWords bool) string {
+	var b strings.Builder
+	if title != "" {
+		fmt.Fprintf(&b, "[ti:%s]\n", title)
+	}
+	if artist != "" {
+		fmt.Fprintf(&b, "[ar:%s]\n", artist)
+	}
+	if n := len(lines); n > 0 && lines[n-1].EndMs != nil {
+		fmt.Fprintf(&b, "[length:%s]\n", FormatTimestamp(*lines[n-1].EndMs))
+	}
+
+	for _, line := range lines {
+		fmt.Fprintf(&b, "[%s]", FormatTimestamp(line.StartMs))
+		if withWords && len(line.Words) > 0 {
+			for i, word := range line.Words {
+				if i > 0 {
+					b.WriteByte(' ')
+				}
+				fmt.Fprintf(&b, "<%s>%s", FormatTimestamp(word.StartMs), word.Text)
+			}
+		} else {
+			b.WriteString(line.Text)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// FormatTimestamp форматирует миллисекунды как mm:ss.xx.
+func FormatTimestamp(ms int64) string {
+	centis := ms / 10
+	return fmt.Sprintf("%02d:%02d.%02d", centis/6000, centis/100%60, centis%100)
+}
+
+// ParseTimestamp разбирает время в секундах ("83.5") или в виде
+// mm:ss(.xx) и hh:mm:ss(.xx) и возвращает миллисекунды. Допускаются только цифры,
+// поэтому NaN, Inf и экспоненциальная запись отклоняются.
+func ParseTimestamp(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	parts := strings.Split(value, ":")
+	if value == "" || len(parts) > 3 {
+		return 0, fmt.Errorf("некорректное время: %q", value)
+	}
+
+	match := secondsPattern.FindStringSubmatch(parts[len(parts)-1])
+	if match == nil {
+		return 0, fmt.Errorf("некорректное время: %q", value)
+	}
+	seconds, _ := strconv.ParseInt(match[1], 10, 64)
+
+	var total int64
+	for _, part := range parts[:len(parts)-1] {
+		n, err := strconv.ParseUint(part, 10, 16)
+		if err != nil {
+			return 0, fmt.Errorf("некорректное время: %q", value)
+		}
+		total = total*60 + int64(n)
+	}
+
+	// Доли секунды округляются до миллисекунд
+	var millis int64
+	if fraction := match[2]; fraction != "" {
+		padded := fraction + "000"
+		millis, _ = strconv.ParseInt(padded[:3], 10, 64)
+		if len(fraction) > 3 && fraction[3] >= '5' {
+			millis++
+		}
+	}
+
+	return (total*60+seconds)*1000 + millis, nil
+}
+
+// parseWords выделяет метки слов расширенного LRC. Текст строки возвращается без меток.
+func parseWords(line string) (string, []models.SyncedWord) {
+	locs := wordTagPattern.FindAllStringSubmatchIndex(line, -1)
+	if locs == nil {
+		return strings.TrimSpace(line), nil
+	}
+
+	words := make([]models.SyncedWord, 0, len(locs))
+	var text strings.Builder
+	text.WriteString(line[:locs[0][0]])
+	for i, loc := range locs {
+		end := len(line)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		segment := line[loc[1]:end]
+		text.WriteString(segment)
+
+		if word := strings.TrimSpace(segment); word != "" {
+			start := tagMillis(line[loc[2]:loc[3]], line[loc[4]:loc[5]], submatch(line, loc, 6))
+			words = append(words, models.SyncedWord{StartMs: start, Text: word})
+		}
+	}
+
+	return strings.Join(strings.Fields(text.String()), " "), words
+}
+
+func submatch(s string, loc []int, group int) string {
+	if loc[group] < 0 {
+		return ""
+	}
+	return s[loc[group]:loc[group+1]]
+}
+
+// tagMillis переводит части метки времени в миллисекунды. Дробная часть
+// интерпретируется по числу цифр: .5 - 500 мс, .50 - 500 мс, .500 - 500 мс.
+func tagMillis(minutes, seconds, fraction string) int64 {
+	m, _ := strconv.ParseInt(minutes, 10, 64)
+	s, _ := strconv.ParseInt(seconds, 10, 64)
+	ms := (m*60 + s) * 1000
+	if fraction != "" {
+		f, _ := strconv.ParseInt(fraction, 10, 64)
+		for i := len(fraction); i < 3; i++ {
+			f *= 10
+		}
+		ms += f
+	}
+	return ms
+}
+
+// shiftWords возвращает копию меток слов, сдвинутых на delta миллисекунд.
+func shiftWords(words []models.SyncedWord, delta int64) []models.SyncedWord {
+	if words == nil {
+		return nil
+	}
+	shifted := make([]models.SyncedWord, len(words))
+	for i, word := range words {
+		shifted[i] = models.SyncedWord{StartMs: clampMillis(word.StartMs + delta), Text: word.Text}
+	}
+	return shifted
+}
+
+func clampMillis(ms int64) int64 {
+	if ms < 0 {
+		return 0
+	}
+	return ms
+}
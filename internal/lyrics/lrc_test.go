@@ -0,0 +1,120 @@
+// lrc_test.go
+package lyrics
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "83.5", want: 83500},
+		{value: "0", want: 0},
+		{value: "01:23", want: 83000},
+		{value: "01:23.45", want: 83450},
+		{value: "1:02:03.004", want: 3723004},
+		{value: " 12.3456 ", want: 12346},
+		{value: "", wantErr: true},
+		{value: "NaN", wantErr: true},
+		{value: "Inf", wantErr: true},
+		{value: "+Inf", wantErr: true},
+		{value: "1e300", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "1.", wantErr: true},
+		{value: ".5", wantErr: true},
+		{value: "1:2:3:4", wantErr: true},
+		{value: "a:10", wantErr: true},
+		{value: "99999999999:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimestamp(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseTimestamp(%q) = %d, ожидалась ошибка", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseTimestamp(%q): %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimestamp(%q) = %d, ожидалось %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseLRC(t *testing.T) {
+	type line struct {
+		start int64
+		end   int64
+		text  string
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    []line
+		wantErr error
+	}{
+		{
+			name: "простые строки",
+			data: "[ti:Song]\n[00:01.00]Первая\n[00:03.50]Вторая",
+			want: []line{{1000, 3500, "Первая"}, {3500, -1, "Вторая"}},
+		},
+		{
+			name: "несколько меток на строке",
+			data: "[00:05.00][00:01.00]Припев\n[00:03.00]Куплет",
+			want: []line{{1000, 3000, "Припев"}, {3000, 5000, "Куплет"}, {5000, -1, "Припев"}},
+		},
+		{
+			name: "offset и length",
+			data: "[offset:+500]\n[length:00:10]\n[00:01.00]Строка",
+			want: []line{{500, 10000, "Строка"}},
+		},
+		{
+			name: "метки слов и перевод строк \\r\\n",
+			data: "\uFEFF[00:01.00]<00:01.00>Раз <00:01.50>два\r\n[00:02]",
+			want: []line{{1000, 2000, "Раз два"}, {2000, -1, ""}},
+		},
+		{
+			name:    "нет меток времени",
+			data:    "[ar:Artist]\nпросто текст",
+			wantErr: ErrInvalidLRC,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseLRC(tt.data)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ошибка %v, ожидалась %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(parsed.Lines) != len(tt.want) {
+				t.Fatalf("строк %d, ожидалось %d", len(parsed.Lines), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := parsed.Lines[i]
+				end := int64(-1)
+				if got.EndMs != nil {
+					end = *got.EndMs
+				}
+				if got.LineNo != i+1 || got.StartMs != want.start || end != want.end || got.Text != want.text {
+					t.Errorf("строка %d = {%d %d %d %q}, ожидалось {%d %d %d %q}",
+						i, got.LineNo, got.StartMs, end, got.Text, i+1, want.start, want.end, want.text)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+// sections.go
+
+// Package lyrics разбирает текст песни на размеченные части: куплеты, припевы, бриджи.
+package lyrics
+
+import (
+	"strings"
+	"unicode"
+
+	"song_library/internal/models"
+)
+
+// sectionKeywords сопоставляет слова из меток вида "[Chorus]" или "Припев:" с типами частей.
+var sectionKeywords = map[string]models.LyricsSectionType{
+	"intro":        models.SectionIntro,
+	"интро":        models.SectionIntro,
+	"вступление":   models.SectionIntro,
+	"verse":        models.SectionVerse,
+	"куплет":       models.SectionVerse,
+	"pre-chorus":   models.SectionPreChorus,
+	"pre chorus":   models.SectionPreChorus,
+	"prechorus":    models.SectionPreChorus,
+	"предприпев":   models.SectionPreChorus,
+	"пред-припев":  models.SectionPreChorus,
+	"chorus":       models.SectionChorus,
+	"refrain":      models.SectionChorus,
+	"hook":         models.SectionChorus,
+	"припев":       models.SectionChorus,
+	"рефрен":       models.SectionChorus,
+	"post-chorus":  models.SectionPostChorus,
+	"post chorus":  models.SectionPostChorus,
+	"postchorus":   models.SectionPostChorus,
+	"bridge":       models.SectionBridge,
+	"бридж":        models.SectionBridge,
+	"переход":      models.SectionBridge,
+	"instrumental": models.SectionInstrumental,
+	"interlude":    models.SectionInstrumental,
+	"solo":         models.SectionInstrumental,
+	"guitar solo":  models.SectionInstrumental,
+	"инструментал": models.SectionInstrumental,
+	"проигрыш":     models.SectionInstrumental,
+	"соло":         models.SectionInstrumental,
+	"outro":        models.SectionOutro,
+	"coda":         models.SectionOutro,
+	"аутро":        models.SectionOutro,
+	"концовка":     models.SectionOutro,
+	"кода":         models.SectionOutro,
+}
+
+type block struct {
+	typ    models.LyricsSectionType
+	label  string
+	lines  []string
+	marked bool
+}
+
+// Parse делит текст на части. Граница части - пустая строка или строка-метка
+// в скобках или с двоеточием ("[Chorus]", "(Припев)", "Куплет 2:"); строка из одного
+// слова вроде "Bridge" остаётся текстом песни. Переводы строк \r\n и \r
+// приводятся к \n.
+//
+// Неразмеченный блок, который повторяется в песне, считается припевом, остальные
+// неразмеченные блоки - куплетами. Метка без текста ("[Chorus x2]") означает повтор
+// первой части того же типа.
+func Parse(text string) []models.LyricsSection {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	blocks := splitBlocks(text)
+	if len(blocks) == 0 {
+		return []models.LyricsSection{}
+	}
+
+	sections := make([]models.LyricsSection, len(blocks))
+	keys := make([]string, len(blocks))
+	for i, b := range blocks {
+		sections[i] = models.LyricsSection{
+			Position: i + 1,
+			Type:     b.typ,
+			Label:    b.label,
+			Text:     strings.Join(b.lines, "\n"),
+		}
+		keys[i] = normalizeText(sections[i].Text)
+	}
+
+	// Повторы одинакового текста: первая копия задаёт тип для неразмеченных
+	for i := range sections {
+		if keys[i] == "" {
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if keys[j] != keys[i] || sections[j].RepeatOf != nil {
+				continue
+			}
+			if sections[j].Type == "" {
+				sections[j].Type = sections[i].Type
+				if sections[j].Type == "" {
+					sections[j].Type = models.SectionChorus
+				}
+			}
+			if sections[i].Type == "" {
+				sections[i].Type = sections[j].Type
+			}
+			sections[i].RepeatOf = position(sections[j].Position)
+			break
+		}
+	}
+
+	for i := range sections {
+		if sections[i].Type == "" {
+			sections[i].Type = models.SectionVerse
+		}
+	}
+
+	// Метки без текста повторяют первую часть того же типа
+	for i := range sections {
+		if keys[i] != "" {
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if sections[j].Type == sections[i].Type && keys[j] != "" {
+				sections[i].Text = sections[j].Text
+				sections[i].RepeatOf = position(sections[j].Position)
+				break
+			}
+		}
+	}
+
+	counters := make(map[models.LyricsSectionType]int)
+	for i := range sections {
+		if origin := sections[i].RepeatOf; origin != nil && sections[*origin-1].Type == sections[i].Type {
+			sections[i].Number = sections[*origin-1].Number
+			continue
+		}
+		counters[sections[i].Type]++
+		sections[i].Number = counters[sections[i].Type]
+	}
+
+	return sections
+}
+
+// splitBlocks делит текст на блоки по пустым строкам и строкам-меткам.
+// Метка, за которой после пустой строки идёт неразмеченный текст, относится к нему.
+func splitBlocks(text string) []block {
+	var blocks []block
+	var current, pending *block
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if len(current.lines) == 0 && current.marked {
+			if pending != nil {
+				blocks = append(blocks, *pending)
+			}
+			pending = current
+		} else if len(current.lines) > 0 {
+			blocks = append(blocks, *current)
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+
+		if typ, label, rest, ok := parseMarker(line); ok {
+			flush()
+			if pending != nil {
+				blocks = append(blocks, *pending)
+				pending = nil
+			}
+			current = &block{typ: typ, label: label, marked: true}
+			if rest != "" {
+				current.lines = append(current.lines, rest)
+			}
+			continue
+		}
+
+		if current == nil {
+			if pending != nil {
+				current, pending = pending, nil
+			} else {
+				current = &block{}
+			}
+		}
+		current.lines = append(current.lines, line)
+	}
+	flush()
+	if pending != nil {
+		blocks = append(blocks, *pending)
+	}
+
+	return blocks
+}
+
+// parseMarker распознаёт строку-метку. rest - текст после двоеточия в метках
+// вида "Припев: ла-ла-ла", он становится первой строкой части.
+func parseMarker(line string) (typ models.LyricsSectionType, label, rest string, ok bool) {
+	trimmed := strings.TrimSpace(line)
+
+	if open := trimmed[0]; open == '[' || open == '(' {
+		closing := "]"
+		if open == '(' {
+			closing = ")"
+		}
+		end := strings.Index(trimmed, closing)
+		if end < 0 {
+			return "", "", "", false
+		}
+		label = strings.TrimSpace(trimmed[1:end])
+		rest = strings.TrimSpace(trimmed[end+1:])
+		if isRepeatCount(rest) {
+			rest = ""
+		}
+		typ, ok = classify(label)
+		if !ok && open == '[' && rest == "" && label != "" {
+			// Квадратные скобки в тексте песен почти всегда означают метку
+			return models.SectionOther, label, "", true
+		}
+		return typ, label, rest, ok
+	}
+
+	if head, tail, found := strings.Cut(trimmed, ":"); found {
+		if typ, ok = classify(head); ok {
+			return typ, strings.TrimSpace(head), strings.TrimSpace(tail), true
+		}
+	}
+	return "", "", "", false
+}
+
+// classify определяет тип по тексту метки: "Verse 2", "2-й куплет",
+// "Chorus: Eminem", "Припев x2" и т.п.
+func classify(label string) (models.LyricsSectionType, bool) {
+	label = strings.ToLower(label)
+	if head, _, found := strings.Cut(label, ":"); found {
+		label = head
+	}
+
+	words := strings.FieldsFunc(label, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '#' || r == '.'
+	})
+	kept := words[:0]
+	for i, word := range words {
+		// Римский номер идёт после названия части ("Verse II"), иначе это слово текста
+		if isRepeatCount(word) || isOrdinal(word) || (i > 0 && isRoman(word)) {
+			continue
+		}
+		kept = append(kept, word)
+	}
+
+	typ, ok := sectionKeywords[strings.Join(kept, " ")]
+	return typ, ok
+}
+
+// isOrdinal распознаёт номера частей цифрами: "2", "2-й", "2nd".
+func isOrdinal(word string) bool {
+	return word != "" && unicode.IsDigit(rune(word[0]))
+}
+
+// romanNumerals - римские номера частей в нижнем регистре.
+var romanNumerals = map[string]bool{
+	"i": true, "ii": true, "iii": true, "iv": true, "v": true,
+	"vi": true, "vii": true, "viii": true, "ix": true, "x": true,
+}
+
+// isRoman распознаёт римский номер части от I до X.
+func isRoman(word string) bool {
+	return romanNumerals[word]
+}
+
+// isRepeatCount распознаёт пометки о повторе: "x2", "х2" (кириллица), "×2", "(x2)".
+func isRepeatCount(s string) bool {
+	s = strings.Trim(strings.ToLower(strings.TrimSpace(s)), "()")
+	for _, prefix := range []string{"x", "х", "×"} {
+		if rest, found := strings.CutPrefix(s, prefix); found {
+			s = rest
+			break
+		}
+	}
+	if s == "" {
+		return false
+	}
+	return strings.TrimFunc(s, unicode.IsDigit) == ""
+}
+
+// normalizeText приводит текст части к виду, по которому ищутся повторы.
+func normalizeText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+func position(p int) *int {
+	return &p
+}
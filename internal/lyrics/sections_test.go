@@ -0,0 +1,160 @@
+// sections_test.go
+package lyrics
+
+import (
+	"testing"
+
+	"song_library/internal/models"
+)
+
+func TestParse(t *testing.T) {
+	// section - ожидаемая часть; repeatOf 0 означает, что часть ничего не повторяет
+	type section struct {
+		typ      models.LyricsSectionType
+		number   int
+		label    string
+		repeatOf int
+		text     string
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want []section
+	}{
+		{
+			name: "пустой текст",
+			text: " \n\n ",
+			want: []section{},
+		},
+		{
+			name: "блоки по пустым строкам",
+			text: "a\nb\n\n\n\nc\nd",
+			want: []section{
+				{typ: models.SectionVerse, number: 1, text: "a\nb"},
+				{typ: models.SectionVerse, number: 2, text: "c\nd"},
+			},
+		},
+		{
+			name: "переводы строк \\r\\n и \\r",
+			text: "a\r\n\r\nb\r\rc",
+			want: []section{
+				{typ: models.SectionVerse, number: 1, text: "a"},
+				{typ: models.SectionVerse, number: 2, text: "b"},
+				{typ: models.SectionVerse, number: 3, text: "c"},
+			},
+		},
+		{
+			name: "неразмеченный повтор - припев",
+			text: "first\n\nla la\nla\n\nsecond\n\nLa  la\nla",
+			want: []section{
+				{typ: models.SectionVerse, number: 1, text: "first"},
+				{typ: models.SectionChorus, number: 1, text: "la la\nla"},
+				{typ: models.SectionVerse, number: 2, text: "second"},
+				{typ: models.SectionChorus, number: 1, repeatOf: 2, text: "La  la\nla"},
+			},
+		},
+		{
+			name: "метки в скобках и повтор меткой без текста",
+			text: "[Verse 1]\na\n\n[Chorus]\nb\n\n[Verse II]\nc\n\n[Chorus x2]",
+			want: []section{
+				{typ: models.SectionVerse, number: 1, label: "Verse 1", text: "a"},
+				{typ: models.SectionChorus, number: 1, label: "Chorus", text: "b"},
+				{typ: models.SectionVerse, number: 2, label: "Verse II", text: "c"},
+				{typ: models.SectionChorus, number: 1, label: "Chorus x2", repeatOf: 2, text: "b"},
+			},
+		},
+		{
+			name: "метка в круглых скобках и пустая строка после метки",
+			text: "(Bridge)\n\nover the hills\n\n(Outro) (x2)\nbye",
+			want: []section{
+				{typ: models.SectionBridge, number: 1, label: "Bridge", text: "over the hills"},
+				{typ: models.SectionOutro, number: 1, label: "Outro", text: "bye"},
+			},
+		},
+		{
+			name: "метка с двоеточием и текстом после него",
+			text: "Куплет 2:\nраз\n\nПрипев: ла-ла\nла\n\n2-й куплет:\nдва",
+			want: []section{
+				{typ: models.SectionVerse, number: 1, label: "Куплет 2", text: "раз"},
+				{typ: models.SectionChorus, number: 1, label: "Припев", text: "ла-ла\nла"},
+				{typ: models.SectionVerse, number: 2, label: "2-й куплет", text: "два"},
+			},
+		},
+		{
+			name: "неизвестная метка в квадратных скобках",
+			text: "[Eminem]\nyo\n\n[Chorus: Rihanna]\nhey",
+			want: []section{
+				{typ: models.SectionOther, number: 1, label: "Eminem", text: "yo"},
+				{typ: models.SectionChorus, number: 1, label: "Chorus: Rihanna", text: "hey"},
+			},
+		},
+		{
+			name: "строки, похожие на метки, остаются текстом",
+			text: "Bridge\nover troubled water\n(oh yeah)\nTime: is running out\nChorus of angels\nI need you (x2)",
+			want: []section{
+				{typ: models.SectionVerse, number: 1, text: "Bridge\nover troubled water\n(oh yeah)\nTime: is running out\nChorus of angels\nI need you (x2)"},
+			},
+		},
+		{
+			name: "метка разрывает блок без пустой строки",
+			text: "a\n[Chorus]\nb\nc",
+			want: []section{
+				{typ: models.SectionVerse, number: 1, text: "a"},
+				{typ: models.SectionChorus, number: 1, label: "Chorus", text: "b\nc"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.text)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Parse вернул %d частей, ожидалось %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				s := got[i]
+				repeatOf := 0
+				if s.RepeatOf != nil {
+					repeatOf = *s.RepeatOf
+				}
+				if s.Position != i+1 || s.Type != want.typ || s.Number != want.number ||
+					s.Label != want.label || repeatOf != want.repeatOf || s.Text != want.text {
+					t.Errorf("часть %d = {%s %d %q повтор %d %q}, ожидалось {%s %d %q повтор %d %q}",
+						i+1, s.Type, s.Number, s.Label, repeatOf, s.Text,
+						want.typ, want.number, want.label, want.repeatOf, want.text)
+				}
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		label string
+		want  models.LyricsSectionType
+		ok    bool
+	}{
+		{label: "Chorus", want: models.SectionChorus, ok: true},
+		{label: "PRE-CHORUS", want: models.SectionPreChorus, ok: true},
+		{label: "Pre Chorus 2", want: models.SectionPreChorus, ok: true},
+		{label: "Verse #3", want: models.SectionVerse, ok: true},
+		{label: "Verse IV", want: models.SectionVerse, ok: true},
+		{label: "2nd verse", want: models.SectionVerse, ok: true},
+		{label: "Chorus: Eminem", want: models.SectionChorus, ok: true},
+		{label: "Припев х2", want: models.SectionChorus, ok: true},
+		{label: "Guitar Solo", want: models.SectionInstrumental, ok: true},
+		{label: "Кода", want: models.SectionOutro, ok: true},
+		{label: "I Chorus"},
+		{label: "Chorus of angels"},
+		{label: "oh yeah"},
+		{label: ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := classify(tt.label)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("classify(%q) = %q, %v, ожидалось %q, %v", tt.label, got, ok, tt.want, tt.ok)
+		}
+	}
+}
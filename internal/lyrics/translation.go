@@ -0,0 +1,135 @@
+// translation.go
+package lyrics
+
+import (
+	"errors"
+	"strings"
+
+	"song_library/internal/models"
+
+	"golang.org/x/text/language"
+)
+
+var ErrInvalidLanguage = errors.New("некорректный тег языка BCP-47")
+
+// CanonicalLanguage проверяет тег BCP-47 и приводит его к каноническому виду: "EN_us" -> "en-US".
+func CanonicalLanguage(tag string) (string, error) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	if tag == "" || tag == "*" {
+		return "", ErrInvalidLanguage
+	}
+	parsed, err := language.Parse(tag)
+	if err != nil || parsed == language.Und {
+		return "", ErrInvalidLanguage
+	}
+	return parsed.String(), nil
+}
+
+// MatchLanguage выбирает из доступных языков лучший для предпочтений клиента,
+// перечисленных по убыванию приоритета. Подходит точное совпадение или близкий
+// вариант того же языка (en-GB для en); если такого нет, возвращается false.
+func MatchLanguage(available []string, preferred []string) (string, bool) {
+	if len(available) == 0 || len(preferred) == 0 {
+		return "", false
+	}
+
+	supported := make([]language.Tag, len(available))
+	for i, tag := range available {
+		supported[i] = language.Make(tag)
+	}
+	matcher := language.NewMatcher(supported)
+
+	// Предпочтения проверяются по одному, чтобы язык с меньшим приоритетом
+	// не выиграл у приблизительного совпадения с более приоритетным
+	for _, tag := range preferred {
+		parsed, err := language.Parse(tag)
+		if err != nil {
+			continue
+		}
+		_, index, confidence := matcher.Match(parsed)
+		if confidence >= language.High {
+			return available[index], true
+		}
+	}
+	return "", false
+}
+
+// ParseAcceptLanguage возвращает языки из заголовка Accept-Language по убыванию приоритета.
+// Некорректный заголовок считается пустым.
+func ParseAcceptLanguage(header string) []string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	preferred := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag != language.Und {
+			preferred = append(preferred, tag.String())
+		}
+	}
+	return preferred
+}
+
+// AlignTranslation делит перевод на части и привязывает их к частям оригинала.
+// Перевод выравнивается, если в нём столько же частей, сколько в оригинале,
+// или столько же, сколько неповторяющихся частей: тогда повторы оригинала
+// берут перевод своей первой части при чтении.
+func AlignTranslation(original []models.LyricsSection, text string) ([]models.TranslatedVerse, bool) {
+	translated := Parse(text)
+	if len(translated) == 0 {
+		return nil, false
+	}
+
+	if len(translated) == len(original) {
+		verses := make([]models.TranslatedVerse, len(original))
+		for i, section := range original {
+			verses[i] = models.TranslatedVerse{Position: section.Position, Text: translated[i].Text}
+		}
+		return verses, true
+	}
+
+	var verses []models.TranslatedVerse
+	for _, section := range original {
+		if section.RepeatOf != nil {
+			continue
+		}
+		if len(verses) == len(translated) {
+			return nil, false
+		}
+		verses = append(verses, models.TranslatedVerse{Position: section.Position, Text: translated[len(verses)].Text})
+	}
+	if len(verses) != len(translated) {
+		return nil, false
+	}
+	return verses, true
+}
+
+// RealignVerses переносит части перевода на части нового текста после правки оригинала.
+// Часть перевода остаётся у части с тем же текстом (без учёта регистра и пробелов),
+// даже если та сдвинулась; переводы изменённых и удалённых частей отбрасываются.
+func RealignVerses(before, after []models.LyricsSection, verses []models.TranslatedVerse) []models.TranslatedVerse {
+	byPosition := make(map[int]string, len(before))
+	for _, section := range before {
+		byPosition[section.Position] = normalizeText(section.Text)
+	}
+
+	// Одинаковые части берут переводы по очереди, чтобы повторы сохранили свои варианты
+	byText := make(map[string][]string)
+	for _, verse := range verses {
+		if key, ok := byPosition[verse.Position]; ok && key != "" {
+			byText[key] = append(byText[key], verse.Text)
+		}
+	}
+
+	realigned := make([]models.TranslatedVerse, 0, len(verses))
+	for _, section := range after {
+		key := normalizeText(section.Text)
+		queue := byText[key]
+		if len(queue) == 0 {
+			continue
+		}
+		realigned = append(realigned, models.TranslatedVerse{Position: section.Position, Text: queue[0]})
+		byText[key] = queue[1:]
+	}
+	return realigned
+}
@@ -0,0 +1,69 @@
+// translation_test.go
+package lyrics
+
+import (
+	"reflect"
+	"testing"
+
+	"song_library/internal/models"
+)
+
+func TestRealignVerses(t *testing.T) {
+	verses := []models.TranslatedVerse{
+		{Position: 1, Text: "один"},
+		{Position: 2, Text: "припев"},
+		{Position: 3, Text: "два"},
+		{Position: 4, Text: "припев ещё раз"},
+	}
+	original := "one\n\nchorus\n\ntwo\n\nchorus"
+
+	tests := []struct {
+		name string
+		text string
+		want []models.TranslatedVerse
+	}{
+		{
+			name: "текст не изменился",
+			text: original,
+			want: verses,
+		},
+		{
+			name: "регистр и пробелы не важны",
+			text: "One\n\n  chorus\n\nTWO\n\nchorus",
+			want: verses,
+		},
+		{
+			name: "добавлена часть в начале",
+			text: "intro\n\none\n\nchorus\n\ntwo\n\nchorus",
+			want: []models.TranslatedVerse{
+				{Position: 2, Text: "один"},
+				{Position: 3, Text: "припев"},
+				{Position: 4, Text: "два"},
+				{Position: 5, Text: "припев ещё раз"},
+			},
+		},
+		{
+			name: "изменённая часть теряет перевод",
+			text: "one\n\nchorus\n\nthree\n\nchorus",
+			want: []models.TranslatedVerse{
+				{Position: 1, Text: "один"},
+				{Position: 2, Text: "припев"},
+				{Position: 4, Text: "припев ещё раз"},
+			},
+		},
+		{
+			name: "текст удалён",
+			text: "",
+			want: []models.TranslatedVerse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RealignVerses(Parse(original), Parse(tt.text), verses)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RealignVerses = %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// backfill.go
+package migrations
+
+import (
+	"song_library/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// backfills - шаги миграций на Go по диалекту и версии.
+var backfills = map[string]map[int]func(tx *gorm.DB) error{
+	"sqlite": {
+		3: mergeSQLiteArtists,
+	},
+}
+
+// mergeSQLiteArtists доводит нормализацию исполнителей после 0003_artists в SQLite:
+// SQL там не складывает регистр кириллицы и пробелы внутри названия, поэтому "Кино",
+// "КИНО" и "Кино  " могли стать разными исполнителями. Исполнители с одинаковым
+// NormalizeArtistName объединяются в того, у кого больше песен (при равенстве - в самого
+// раннего), песни переносятся к нему.
+func mergeSQLiteArtists(tx *gorm.DB) error {
+	type artistRow struct {
+		ID    string
+		Name  string
+		Songs int
+	}
+	var artists []artistRow
+	err := tx.Raw(`SELECT a.id, a.name, (SELECT count(*) FROM songs s WHERE s.artist_id = a.id) AS songs
+FROM artists a
+ORDER BY songs DESC, a.created_at, a.id`).Scan(&artists).Error
+	if err != nil {
+		return err
+	}
+
+	survivors := make(map[string]string, len(artists))
+	for _, artist := range artists {
+		normalized := models.NormalizeArtistName(artist.Name)
+		survivor, ok := survivors[normalized]
+		if !ok {
+			survivors[normalized] = artist.ID
+			continue
+		}
+		if err := tx.Exec("UPDATE songs SET artist_id = ? WHERE artist_id = ?", survivor, artist.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM artists WHERE id = ?", artist.ID).Error; err != nil {
+			return err
+		}
+	}
+
+	// Обновляем после удаления дублей, чтобы не нарушить уникальность normalized_name
+	for _, artist := range artists {
+		normalized := models.NormalizeArtistName(artist.Name)
+		if survivors[normalized] != artist.ID {
+			continue
+		}
+		err := tx.Exec("UPDATE artists SET name = ?, normalized_name = ? WHERE id = ?",
+			models.CleanArtistName(artist.Name), normalized, artist.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,338 @@
+// migrations.go
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// advisoryLockKey - произвольный, но постоянный ключ блокировки миграций в PostgreSQL.
+const advisoryLockKey = 720417
+
+var (
+	ErrSchemaOutdated   = errors.New("схема базы данных устарела")
+	ErrUnknownVersion   = errors.New("неизвестная версия миграции")
+	ErrUnsupportedDB    = errors.New("миграции не поддерживаются для этой базы данных")
+	ErrMissingMigration = errors.New("отсутствует файл миграции")
+)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+	// Backfill выполняется в той же транзакции сразу после Up - для переноса данных,
+	// который нельзя выразить на SQL диалекта
+	Backfill func(tx *gorm.DB) error
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// schemaMigration - строка таблицы schema_migrations.
+type schemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := Load(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Load читает встроенные файлы миграций для диалекта и сортирует их по версии.
+// Файлы называются <версия>_<имя>.up.sql и <версия>_<имя>.down.sql.
+func Load(dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDB, dialect)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+
+		var direction string
+		switch {
+		case strings.HasSuffix(fileName, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(fileName, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(fileName, "."+direction+".sql")
+		versionStr, name, found := strings.Cut(base, "_")
+		if !found {
+			return nil, fmt.Errorf("некорректное имя файла миграции: %s", fileName)
+		}
+		version, err := strconv.Atoi(versionStr)
+		if err != nil {
+			return nil, fmt.Errorf("некорректное имя файла миграции: %s", fileName)
+		}
+
+		content, err := files.ReadFile(path.Join(dialect, fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("%w: версия %d", ErrMissingMigration, migration.Version)
+		}
+		migration.Backfill = backfills[dialect][migration.Version]
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// LatestVersion возвращает номер последней известной миграции.
+func (m *Migrator) LatestVersion() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// CurrentVersion возвращает номер последней применённой миграции.
+func (m *Migrator) CurrentVersion() (int, error) {
+	return currentVersion(m.db)
+}
+
+// CheckCurrent возвращает ErrSchemaOutdated, если в базе применены не все миграции.
+func (m *Migrator) CheckCurrent() error {
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return err
+	}
+	if latest := m.LatestVersion(); current < latest {
+		return fmt.Errorf("%w: текущая версия %d, требуется %d", ErrSchemaOutdated, current, latest)
+	}
+	return nil
+}
+
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	var applied []schemaMigration
+	if m.db.Migrator().HasTable(&schemaMigration{}) {
+		if err := m.db.Order("version").Find(&applied).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	appliedAt := make(map[int]time.Time, len(applied))
+	for _, row := range applied {
+		appliedAt[row.Version] = row.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+		if at, ok := appliedAt[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Up применяет все неприменённые миграции.
+func (m *Migrator) Up() error {
+	return m.To(m.LatestVersion())
+}
+
+// Down откатывает последнюю применённую миграцию.
+func (m *Migrator) Down() error {
+	return m.withLock(func(tx *gorm.DB) error {
+		current, err := currentVersion(tx)
+		if err != nil {
+			return err
+		}
+
+		target := 0
+		for _, migration := range m.migrations {
+			if migration.Version < current {
+				target = migration.Version
+			}
+		}
+
+		return m.migrate(tx, current, target)
+	})
+}
+
+// To приводит схему к указанной версии, применяя или откатывая миграции.
+func (m *Migrator) To(version int) error {
+	if version != 0 && !m.hasVersion(version) {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.withLock(func(tx *gorm.DB) error {
+		current, err := currentVersion(tx)
+		if err != nil {
+			return err
+		}
+		return m.migrate(tx, current, version)
+	})
+}
+
+func (m *Migrator) migrate(db *gorm.DB, current, target int) error {
+	if target > current {
+		for _, migration := range m.migrations {
+			if migration.Version <= current || migration.Version > target {
+				continue
+			}
+			if err := m.apply(db, migration); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version > current || migration.Version <= target {
+			continue
+		}
+		if err := m.revert(db, migration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) apply(db *gorm.DB, migration Migration) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return fmt.Errorf("миграция %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if migration.Backfill != nil {
+			if err := migration.Backfill(tx); err != nil {
+				return fmt.Errorf("миграция %d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+		return tx.Create(&schemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Now(),
+		}).Error
+	})
+}
+
+func (m *Migrator) revert(db *gorm.DB, migration Migration) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return fmt.Errorf("откат миграции %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		return tx.Delete(&schemaMigration{}, "version = ?", migration.Version).Error
+	})
+}
+
+// withLock выполняет fn на одном соединении, удерживая advisory lock в PostgreSQL,
+// чтобы несколько реплик не применяли миграции одновременно.
+// SQLite сам сериализует запись, поэтому для него блокировка не нужна.
+func (m *Migrator) withLock(fn func(tx *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		conn = conn.Session(&gorm.Session{NewDB: true})
+
+		if conn.Dialector.Name() == "postgres" {
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", advisoryLockKey).Error; err != nil {
+				return err
+			}
+			defer conn.Exec("SELECT pg_advisory_unlock(?)", advisoryLockKey)
+		}
+
+		if err := m.ensureTable(conn); err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) ensureTable(db *gorm.DB) error {
+	return db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    bigint PRIMARY KEY,
+    name       text NOT NULL,
+    applied_at timestamp NOT NULL
+)`).Error
+}
+
+func (m *Migrator) hasVersion(version int) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func currentVersion(db *gorm.DB) (int, error) {
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return 0, nil
+	}
+
+	var version *int
+	err := db.Model(&schemaMigration{}).Select("MAX(version)").Scan(&version).Error
+	if err != nil {
+		return 0, err
+	}
+	if version == nil {
+		return 0, nil
+	}
+	return *version, nil
+}
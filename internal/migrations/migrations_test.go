@@ -0,0 +1,159 @@
+// migrations_test.go
+package migrations
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestSQLiteRoundTrip применяет встроенные миграции по одной, откатывает их все
+// и применяет снова: после каждого отката схема должна совпадать со схемой
+// той же версии на пути вверх, а песни - пережить переход к исполнителям и обратно.
+func TestSQLiteRoundTrip(t *testing.T) {
+	db := openSQLite(t)
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas := map[int]string{0: schemaOf(t, db)}
+	for _, migration := range migrator.migrations {
+		if err := migrator.To(migration.Version); err != nil {
+			t.Fatalf("применение %d_%s: %v", migration.Version, migration.Name, err)
+		}
+		schemas[migration.Version] = schemaOf(t, db)
+
+		if migration.Version == 1 {
+			// Один исполнитель записан по-разному: 0003 должна объединить его
+			err := db.Exec(`INSERT INTO songs (id, group_name, song_title, created_at, updated_at) VALUES
+    ('00000000-0000-0000-0000-000000000001', 'Кино', 'Группа крови', '2024-01-01', '2024-01-01'),
+    ('00000000-0000-0000-0000-000000000002', 'КИНО', 'Звезда по имени Солнце', '2024-01-02', '2024-01-02'),
+    ('00000000-0000-0000-0000-000000000003', '  Кино   ', 'Кукушка', '2024-01-03', '2024-01-03'),
+    ('00000000-0000-0000-0000-000000000004', 'Muse', 'Uprising', '2024-01-04', '2024-01-04')`).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	latest := migrator.LatestVersion()
+	if current, err := migrator.CurrentVersion(); err != nil || current != latest {
+		t.Fatalf("после Up версия %d (%v), ожидалась %d", current, err, latest)
+	}
+	var artists []string
+	if err := db.Raw("SELECT name FROM artists ORDER BY name").Scan(&artists).Error; err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(artists, ",") != "Muse,Кино" {
+		t.Errorf("исполнители после Up: %v, ожидались Muse и Кино", artists)
+	}
+	var kinoSongs int64
+	err = db.Raw("SELECT count(*) FROM songs s JOIN artists a ON a.id = s.artist_id WHERE a.normalized_name = ?", "кино").
+		Scan(&kinoSongs).Error
+	if err != nil || kinoSongs != 3 {
+		t.Errorf("песен у исполнителя Кино %d (%v), ожидалось 3", kinoSongs, err)
+	}
+
+	for i := len(migrator.migrations) - 1; i >= 0; i-- {
+		migration := migrator.migrations[i]
+		if err := migrator.Down(); err != nil {
+			t.Fatalf("откат %d_%s: %v", migration.Version, migration.Name, err)
+		}
+
+		previous := 0
+		if i > 0 {
+			previous = migrator.migrations[i-1].Version
+		}
+		if current, err := migrator.CurrentVersion(); err != nil || current != previous {
+			t.Fatalf("после отката %d версия %d (%v), ожидалась %d", migration.Version, current, err, previous)
+		}
+		if got := schemaOf(t, db); got != schemas[previous] {
+			t.Errorf("после отката %d_%s схема не совпадает со схемой версии %d:\n%s\nожидалось:\n%s",
+				migration.Version, migration.Name, previous, got, schemas[previous])
+		}
+
+		if previous == 1 {
+			var groups []string
+			if err := db.Raw("SELECT group_name FROM songs ORDER BY created_at").Scan(&groups).Error; err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(groups, ",") != "Кино,Кино,Кино,Muse" {
+				t.Errorf("группы песен после отката к 1: %v", groups)
+			}
+		}
+	}
+
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("повторный Up: %v", err)
+	}
+	if got := schemaOf(t, db); got != schemas[latest] {
+		t.Errorf("схема после повторного Up не совпадает с первой:\n%s\nожидалось:\n%s", got, schemas[latest])
+	}
+}
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "migrations.db") + "?_pragma=foreign_keys(1)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// schemaOf описывает таблицы, их колонки и индексы базы, кроме schema_migrations.
+func schemaOf(t *testing.T, db *gorm.DB) string {
+	t.Helper()
+	type object struct {
+		Type    string
+		Name    string
+		TblName string
+	}
+	var objects []object
+	err := db.Raw(`SELECT type, name, tbl_name FROM sqlite_master
+WHERE name NOT LIKE 'sqlite_%' AND tbl_name <> 'schema_migrations'
+ORDER BY type, name`).Scan(&objects).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema strings.Builder
+	for _, o := range objects {
+		schema.WriteString(o.Type + " " + o.Name + " on " + o.TblName + "\n")
+		if o.Type != "table" {
+			continue
+		}
+		type column struct {
+			Name    string
+			Type    string
+			NotNull bool
+			PK      int
+		}
+		var columns []column
+		if err := db.Raw("SELECT name, type, \"notnull\" AS not_null, pk FROM pragma_table_info(?) ORDER BY cid", o.Name).
+			Scan(&columns).Error; err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range columns {
+			schema.WriteString("  " + c.Name + " " + strings.ToLower(c.Type))
+			if c.NotNull {
+				schema.WriteString(" not null")
+			}
+			if c.PK > 0 {
+				schema.WriteString(" pk")
+			}
+			schema.WriteString("\n")
+		}
+	}
+	return schema.String()
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS songs (
+    id           uuid PRIMARY KEY,
+    group_name   text NOT NULL,
+    song_title   text NOT NULL,
+    release_date timestamptz,
+    text         text,
+    link         text,
+    created_at   timestamptz,
+    updated_at   timestamptz
+);
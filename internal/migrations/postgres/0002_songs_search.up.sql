@@ -0,0 +1,10 @@
+-- Конфигурация russian стеммит кириллицу русским словарём, а латиницу - английским,
+-- english добавлена для английских стоп-слов и составных слов.
+ALTER TABLE songs ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(song_title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(song_title, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(text, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(text, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_songs_search_vector ON songs USING GIN (search_vector);
@@ -0,0 +1,12 @@
+ALTER TABLE songs ADD COLUMN group_name text;
+
+UPDATE songs s
+SET group_name = a.name
+FROM artists a
+WHERE a.id = s.artist_id;
+
+ALTER TABLE songs ALTER COLUMN group_name SET NOT NULL;
+
+ALTER TABLE songs DROP COLUMN artist_id;
+
+DROP TABLE artists;
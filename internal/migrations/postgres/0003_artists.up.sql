@@ -0,0 +1,39 @@
+CREATE TABLE artists (
+    id              uuid PRIMARY KEY,
+    name            text NOT NULL,
+    normalized_name text NOT NULL,
+    created_at      timestamptz,
+    updated_at      timestamptz
+);
+
+CREATE UNIQUE INDEX idx_artists_normalized_name ON artists (normalized_name);
+
+-- Для каждой группы написаний ("Muse", "muse ", "MUSE") берём самое частое,
+-- при равенстве - самое раннее.
+INSERT INTO artists (id, name, normalized_name, created_at, updated_at)
+SELECT gen_random_uuid(), name, normalized_name, now(), now()
+FROM (
+    SELECT DISTINCT ON (normalized_name) normalized_name, name
+    FROM (
+        SELECT lower(regexp_replace(btrim(group_name), '\s+', ' ', 'g')) AS normalized_name,
+               regexp_replace(btrim(group_name), '\s+', ' ', 'g') AS name,
+               count(*) AS uses,
+               min(created_at) AS first_seen
+        FROM songs
+        GROUP BY 1, 2
+    ) variants
+    ORDER BY normalized_name, uses DESC, first_seen
+) chosen;
+
+ALTER TABLE songs ADD COLUMN artist_id uuid REFERENCES artists (id);
+
+UPDATE songs s
+SET artist_id = a.id
+FROM artists a
+WHERE a.normalized_name = lower(regexp_replace(btrim(s.group_name), '\s+', ' ', 'g'));
+
+ALTER TABLE songs ALTER COLUMN artist_id SET NOT NULL;
+
+CREATE INDEX idx_songs_artist_id ON songs (artist_id);
+
+ALTER TABLE songs DROP COLUMN group_name;
@@ -0,0 +1,23 @@
+CREATE TABLE albums (
+    id               uuid PRIMARY KEY,
+    artist_id        uuid NOT NULL REFERENCES artists (id),
+    title            text NOT NULL,
+    normalized_title text NOT NULL,
+    release_date     date,
+    type             text NOT NULL CHECK (type IN ('lp', 'ep', 'single', 'compilation')),
+    created_at       timestamptz,
+    updated_at       timestamptz
+);
+
+CREATE INDEX idx_albums_artist_title ON albums (artist_id, normalized_title);
+
+CREATE TABLE album_tracks (
+    album_id     uuid NOT NULL REFERENCES albums (id) ON DELETE CASCADE,
+    song_id      uuid NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    disc_number  integer NOT NULL DEFAULT 1 CHECK (disc_number > 0),
+    track_number integer NOT NULL CHECK (track_number > 0),
+    PRIMARY KEY (album_id, song_id),
+    UNIQUE (album_id, disc_number, track_number)
+);
+
+CREATE INDEX idx_album_tracks_song_id ON album_tracks (song_id);
@@ -0,0 +1,11 @@
+CREATE TABLE translations (
+    song_id    uuid NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    language   text NOT NULL,
+    text       text NOT NULL,
+    author     text NOT NULL DEFAULT '',
+    source     text NOT NULL DEFAULT '',
+    verses     text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (song_id, language)
+);
@@ -0,0 +1,6 @@
+-- Без колонки deleted_at песни из корзины снова стали бы видны, поэтому они удаляются окончательно.
+DELETE FROM songs WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_songs_deleted_at;
+
+ALTER TABLE songs DROP COLUMN deleted_at;
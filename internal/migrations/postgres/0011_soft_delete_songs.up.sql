@@ -0,0 +1,3 @@
+ALTER TABLE songs ADD COLUMN deleted_at timestamptz;
+
+CREATE INDEX idx_songs_deleted_at ON songs (deleted_at);
@@ -0,0 +1 @@
+ALTER TABLE songs DROP COLUMN release_date_precision;
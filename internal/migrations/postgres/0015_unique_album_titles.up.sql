@@ -0,0 +1,17 @@
+-- Одновременное создание альбома могло оставить у исполнителя два альбома с одним
+-- названием. Более поздние дубли получают к названию суффикс с ID: данные и трек-листы
+-- сохраняются, а найти и объединить такие альбомы можно вручную.
+UPDATE albums
+SET normalized_title = normalized_title || ' #' || id::text
+WHERE EXISTS (
+    SELECT 1
+    FROM albums earlier
+    WHERE earlier.artist_id = albums.artist_id
+        AND earlier.normalized_title = albums.normalized_title
+        AND (earlier.created_at < albums.created_at
+            OR (earlier.created_at = albums.created_at AND earlier.id < albums.id))
+);
+
+DROP INDEX idx_albums_artist_title;
+
+CREATE UNIQUE INDEX idx_albums_artist_title ON albums (artist_id, normalized_title);
@@ -0,0 +1,21 @@
+-- До 0013_song_field_sources происхождение полей не записывалось, и обогащение
+-- считало незаблокированным любое значение. Непустые дата, текст и ссылка без
+-- записи о происхождении заданы вручную (или источником до 0013), поэтому
+-- блокируются: обогащение не должно затирать старые ручные правки.
+INSERT INTO song_field_sources (song_id, field, locked, updated_at)
+SELECT id, 'releaseDate', true, now()
+FROM songs
+WHERE release_date >= '0001-01-02'
+    AND NOT EXISTS (SELECT 1 FROM song_field_sources s WHERE s.song_id = songs.id AND s.field = 'releaseDate');
+
+INSERT INTO song_field_sources (song_id, field, locked, updated_at)
+SELECT id, 'text', true, now()
+FROM songs
+WHERE COALESCE(text, '') <> ''
+    AND NOT EXISTS (SELECT 1 FROM song_field_sources s WHERE s.song_id = songs.id AND s.field = 'text');
+
+INSERT INTO song_field_sources (song_id, field, locked, updated_at)
+SELECT id, 'link', true, now()
+FROM songs
+WHERE COALESCE(link, '') <> ''
+    AND NOT EXISTS (SELECT 1 FROM song_field_sources s WHERE s.song_id = songs.id AND s.field = 'link');
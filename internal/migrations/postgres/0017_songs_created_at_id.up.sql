@@ -0,0 +1,2 @@
+-- Ключ страниц по курсору: песни в порядке добавления, при равном времени - по id
+CREATE INDEX idx_songs_created_at_id ON songs (created_at, id);
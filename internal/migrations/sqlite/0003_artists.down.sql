@@ -0,0 +1,21 @@
+CREATE TABLE songs_old (
+    id           uuid PRIMARY KEY,
+    group_name   text NOT NULL,
+    song_title   text NOT NULL,
+    release_date datetime,
+    text         text,
+    link         text,
+    created_at   datetime,
+    updated_at   datetime
+);
+
+INSERT INTO songs_old (id, group_name, song_title, release_date, text, link, created_at, updated_at)
+SELECT s.id, a.name, s.song_title, s.release_date, s.text, s.link, s.created_at, s.updated_at
+FROM songs s
+JOIN artists a ON a.id = s.artist_id;
+
+DROP TABLE songs;
+
+ALTER TABLE songs_old RENAME TO songs;
+
+DROP TABLE artists;
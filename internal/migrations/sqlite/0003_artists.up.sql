@@ -0,0 +1,45 @@
+CREATE TABLE artists (
+    id              uuid PRIMARY KEY,
+    name            text NOT NULL,
+    normalized_name text NOT NULL,
+    created_at      datetime,
+    updated_at      datetime
+);
+
+CREATE UNIQUE INDEX idx_artists_normalized_name ON artists (normalized_name);
+
+-- В SQLite нет regexp_replace, а lower() работает только с ASCII, поэтому здесь
+-- исполнители группируются грубо; после SQL миграция объединяет их в Go по
+-- models.NormalizeArtistName (см. mergeSQLiteArtists).
+INSERT INTO artists (id, name, normalized_name, created_at, updated_at)
+SELECT lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' ||
+             substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))),
+       name, normalized_name, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM (
+    SELECT lower(trim(group_name)) AS normalized_name, trim(group_name) AS name, min(created_at)
+    FROM songs
+    GROUP BY lower(trim(group_name))
+);
+
+-- SQLite не умеет добавлять NOT NULL к существующей колонке, поэтому таблица пересоздаётся.
+CREATE TABLE songs_new (
+    id           uuid PRIMARY KEY,
+    artist_id    uuid NOT NULL REFERENCES artists (id),
+    song_title   text NOT NULL,
+    release_date datetime,
+    text         text,
+    link         text,
+    created_at   datetime,
+    updated_at   datetime
+);
+
+INSERT INTO songs_new (id, artist_id, song_title, release_date, text, link, created_at, updated_at)
+SELECT s.id, a.id, s.song_title, s.release_date, s.text, s.link, s.created_at, s.updated_at
+FROM songs s
+JOIN artists a ON a.normalized_name = lower(trim(s.group_name));
+
+DROP TABLE songs;
+
+ALTER TABLE songs_new RENAME TO songs;
+
+CREATE INDEX idx_songs_artist_id ON songs (artist_id);
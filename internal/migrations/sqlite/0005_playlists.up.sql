@@ -0,0 +1,22 @@
+CREATE TABLE playlists (
+    id          uuid PRIMARY KEY,
+    name        text NOT NULL,
+    description text NOT NULL DEFAULT '',
+    created_at  datetime,
+    updated_at  datetime
+);
+
+-- song_id намеренно без внешнего ключа: при удалении песни запись
+-- остаётся в плейлисте и помечается в song_removed_at.
+CREATE TABLE playlist_entries (
+    id              uuid PRIMARY KEY,
+    playlist_id     uuid NOT NULL REFERENCES playlists (id) ON DELETE CASCADE,
+    song_id         uuid NOT NULL,
+    position        integer NOT NULL CHECK (position > 0),
+    added_at        datetime NOT NULL,
+    song_removed_at datetime
+);
+
+CREATE INDEX idx_playlist_entries_playlist_position ON playlist_entries (playlist_id, position);
+
+CREATE INDEX idx_playlist_entries_song_id ON playlist_entries (song_id);
@@ -0,0 +1,34 @@
+CREATE TABLE genres (
+    id              uuid PRIMARY KEY,
+    name            text NOT NULL,
+    normalized_name text NOT NULL,
+    created_at      datetime,
+    updated_at      datetime
+);
+
+CREATE UNIQUE INDEX idx_genres_normalized_name ON genres (normalized_name);
+
+CREATE TABLE tags (
+    id              uuid PRIMARY KEY,
+    name            text NOT NULL,
+    normalized_name text NOT NULL,
+    created_at      datetime
+);
+
+CREATE UNIQUE INDEX idx_tags_normalized_name ON tags (normalized_name);
+
+CREATE TABLE song_genres (
+    song_id  uuid NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    genre_id uuid NOT NULL REFERENCES genres (id) ON DELETE CASCADE,
+    PRIMARY KEY (song_id, genre_id)
+);
+
+CREATE INDEX idx_song_genres_genre_id ON song_genres (genre_id);
+
+CREATE TABLE song_tags (
+    song_id uuid NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    tag_id  uuid NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (song_id, tag_id)
+);
+
+CREATE INDEX idx_song_tags_tag_id ON song_tags (tag_id);
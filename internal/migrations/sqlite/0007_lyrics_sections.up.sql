@@ -0,0 +1,12 @@
+-- Части текста заполняются приложением: разбор текста выполняется в Go
+-- (пакет internal/lyrics), для уже существующих песен - при запуске сервера.
+CREATE TABLE lyrics_sections (
+    song_id   uuid NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    position  integer NOT NULL CHECK (position > 0),
+    type      text NOT NULL,
+    number    integer NOT NULL,
+    label     text NOT NULL DEFAULT '',
+    text      text NOT NULL,
+    repeat_of integer,
+    PRIMARY KEY (song_id, position)
+);
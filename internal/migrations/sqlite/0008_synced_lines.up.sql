@@ -0,0 +1,12 @@
+CREATE TABLE synced_lines (
+    song_id  uuid NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    line_no  integer NOT NULL CHECK (line_no > 0),
+    start_ms bigint NOT NULL CHECK (start_ms >= 0),
+    end_ms   bigint,
+    text     text NOT NULL,
+    verse    integer NOT NULL DEFAULT 0,
+    words    text,
+    PRIMARY KEY (song_id, line_no)
+);
+
+CREATE INDEX idx_synced_lines_song_start ON synced_lines (song_id, start_ms);
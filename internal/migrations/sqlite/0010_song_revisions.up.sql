@@ -0,0 +1,10 @@
+CREATE TABLE song_revisions (
+    song_id       uuid NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    revision      integer NOT NULL CHECK (revision > 0),
+    snapshot      text NOT NULL,
+    changed_by    text NOT NULL DEFAULT '',
+    note          text NOT NULL DEFAULT '',
+    restored_from integer,
+    created_at    datetime,
+    PRIMARY KEY (song_id, revision)
+);
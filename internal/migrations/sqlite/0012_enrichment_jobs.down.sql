@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS enrichment_jobs;
+
+DROP INDEX IF EXISTS idx_songs_enrichment_status;
+
+ALTER TABLE songs DROP COLUMN enrichment_status;
@@ -0,0 +1,17 @@
+-- Пустой статус - песня добавлена до очереди обогащения или импортом.
+ALTER TABLE songs ADD COLUMN enrichment_status text NOT NULL DEFAULT '';
+
+CREATE INDEX idx_songs_enrichment_status ON songs (enrichment_status);
+
+-- Задание живёт, пока песня ждёт обогащения или оно не удалось; после успеха удаляется.
+CREATE TABLE enrichment_jobs (
+    song_id         uuid PRIMARY KEY REFERENCES songs (id) ON DELETE CASCADE,
+    attempts        integer NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    last_error      text NOT NULL DEFAULT '',
+    next_attempt_at datetime NOT NULL,
+    locked_until    datetime,
+    created_at      datetime,
+    updated_at      datetime
+);
+
+CREATE INDEX idx_enrichment_jobs_next_attempt_at ON enrichment_jobs (next_attempt_at);
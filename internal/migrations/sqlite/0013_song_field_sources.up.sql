@@ -0,0 +1,13 @@
+-- Происхождение полей песни: какой источник заполнил поле, когда и каким ответом.
+-- locked - поле правили вручную, и обогащение его больше не перезаписывает.
+-- У заблокированного поля, которое источники не заполняли, provider пустой.
+CREATE TABLE song_field_sources (
+    song_id    uuid NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    field      text NOT NULL,
+    provider   text NOT NULL DEFAULT '',
+    fetched_at datetime,
+    payload    text NOT NULL DEFAULT '',
+    locked     boolean NOT NULL DEFAULT false,
+    updated_at datetime,
+    PRIMARY KEY (song_id, field)
+);
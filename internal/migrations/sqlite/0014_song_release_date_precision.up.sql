@@ -0,0 +1,6 @@
+-- Точность даты выпуска: year, month или day. release_date хранит начало периода,
+-- поэтому "1975" - это 1975-01-01 с точностью year. Пусто, если дата неизвестна.
+ALTER TABLE songs ADD COLUMN release_date_precision text NOT NULL DEFAULT '';
+
+-- До этой миграции даты принимались только целиком
+UPDATE songs SET release_date_precision = 'day' WHERE release_date >= '0001-01-02';
@@ -0,0 +1,3 @@
+DROP INDEX idx_albums_artist_title;
+
+CREATE INDEX idx_albums_artist_title ON albums (artist_id, normalized_title);
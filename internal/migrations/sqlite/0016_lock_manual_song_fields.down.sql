@@ -0,0 +1,2 @@
+-- Блокировки, поставленные миграцией, не отличить от поставленных вручную - они остаются
+SELECT 1;
@@ -0,0 +1,102 @@
+// album.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AlbumType string
+
+const (
+	AlbumTypeLP          AlbumType = "lp"
+	AlbumTypeEP          AlbumType = "ep"
+	AlbumTypeSingle      AlbumType = "single"
+	AlbumTypeCompilation AlbumType = "compilation"
+)
+
+func (t AlbumType) Valid() bool {
+	switch t {
+	case AlbumTypeLP, AlbumTypeEP, AlbumTypeSingle, AlbumTypeCompilation:
+		return true
+	}
+	return false
+}
+
+type Album struct {
+	ID              uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey" example:"4b0a3d5e-1f43-4b8a-9c59-0a4c2f6c7f10"`
+	ArtistID        uuid.UUID    `json:"artistId" gorm:"type:uuid;not null" example:"7d444840-9dc0-11d1-b245-5ffdce74fad2"`
+	Artist          *Artist      `json:"-" gorm:"foreignKey:ArtistID"`
+	ArtistName      string       `json:"artist" gorm:"-" example:"Muse"`
+	Title           string       `json:"title" gorm:"not null" example:"Black Holes and Revelations"`
+	NormalizedTitle string       `json:"-" gorm:"not null"`
+	ReleaseDate     *time.Time   `json:"releaseDate,omitempty" example:"2006-07-03T00:00:00Z"`
+	Type            AlbumType    `json:"type" gorm:"not null" example:"lp"`
+	Tracks          []AlbumTrack `json:"tracks,omitempty" gorm:"foreignKey:AlbumID"`
+	CreatedAt       time.Time    `json:"createdAt"`
+	UpdatedAt       time.Time    `json:"updatedAt"`
+}
+
+// CleanAlbumTitle убирает лишние пробелы в названии альбома.
+func CleanAlbumTitle(title string) string {
+	return CleanArtistName(title)
+}
+
+// NormalizeAlbumTitle приводит название альбома к виду для сравнения,
+// по тем же правилам, что и названия исполнителей.
+func NormalizeAlbumTitle(title string) string {
+	return NormalizeArtistName(title)
+}
+
+// AfterFind заполняет название исполнителя из связанной записи.
+func (a *Album) AfterFind(tx *gorm.DB) error {
+	if a.Artist != nil {
+		a.ArtistName = a.Artist.Name
+	}
+	return nil
+}
+
+// AlbumTrack - позиция песни в трек-листе альбома.
+type AlbumTrack struct {
+	AlbumID     uuid.UUID `json:"-" gorm:"type:uuid;primaryKey"`
+	SongID      uuid.UUID `json:"songId" gorm:"type:uuid;primaryKey" example:"123e4567-e89b-12d3-a456-426614174000"`
+	DiscNumber  int       `json:"disc" gorm:"not null" example:"1"`
+	TrackNumber int       `json:"track" gorm:"not null" example:"3"`
+	Song        *Song     `json:"song,omitempty" gorm:"foreignKey:SongID"`
+}
+
+type AlbumTrackRequest struct {
+	SongID      string `json:"songId" binding:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	DiscNumber  int    `json:"disc" binding:"omitempty,min=1" example:"1"`
+	TrackNumber int    `json:"track" binding:"required,min=1" example:"3"`
+}
+
+type CreateAlbumRequest struct {
+	Title       string              `json:"title" binding:"required" example:"Black Holes and Revelations"`
+	Artist      string              `json:"artist" binding:"required" example:"Muse"`
+	ReleaseDate string              `json:"releaseDate" example:"2006-07-03"`
+	Type        AlbumType           `json:"type" binding:"required" example:"lp"`
+	Tracks      []AlbumTrackRequest `json:"tracks" binding:"dive"`
+}
+
+type UpdateAlbumRequest struct {
+	Title       string    `json:"title" example:"Black Holes and Revelations"`
+	Artist      string    `json:"artist" example:"Muse"`
+	ReleaseDate string    `json:"releaseDate" example:"2006-07-03"`
+	Type        AlbumType `json:"type" example:"lp"`
+}
+
+type SetAlbumTracksRequest struct {
+	Tracks []AlbumTrackRequest `json:"tracks" binding:"required,dive"`
+}
+
+type AlbumFilter struct {
+	Title    string    `form:"title"`
+	Artist   string    `form:"artist"`
+	Type     AlbumType `form:"type"`
+	ArtistID uuid.UUID `form:"-"`
+	// SongID отбирает альбомы с этой песней; в Tracks остаётся только её трек
+	SongID uuid.UUID `form:"-"`
+}
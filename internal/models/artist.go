@@ -0,0 +1,40 @@
+// artist.go
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Artist struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primaryKey" example:"7d444840-9dc0-11d1-b245-5ffdce74fad2"`
+	Name           string    `json:"name" gorm:"not null" example:"Muse"`
+	NormalizedName string    `json:"-" gorm:"not null;uniqueIndex"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type CreateArtistRequest struct {
+	Name string `json:"name" binding:"required" example:"Muse"`
+}
+
+type UpdateArtistRequest struct {
+	Name string `json:"name" binding:"required" example:"Muse"`
+}
+
+type ArtistFilter struct {
+	Name string `form:"name"`
+}
+
+// CleanArtistName убирает лишние пробелы в названии исполнителя.
+func CleanArtistName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// NormalizeArtistName приводит название к виду, по которому сравниваются исполнители:
+// "Muse", "muse " и "MUSE" считаются одним исполнителем.
+func NormalizeArtistName(name string) string {
+	return strings.ToLower(CleanArtistName(name))
+}
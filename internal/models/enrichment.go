@@ -0,0 +1,98 @@
+// enrichment.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EnrichmentStatus - состояние обогащения песни из источников метаданных.
+// Пустой статус у песен, добавленных до очереди обогащения или импортом.
+type EnrichmentStatus string
+
+const (
+	EnrichmentPending  EnrichmentStatus = "pending"
+	EnrichmentEnriched EnrichmentStatus = "enriched"
+	EnrichmentFailed   EnrichmentStatus = "failed"
+	// EnrichmentNotFound - ни один источник не знает такой песни; повторять попытки бессмысленно
+	EnrichmentNotFound EnrichmentStatus = "not_found"
+)
+
+func (s EnrichmentStatus) Valid() bool {
+	switch s {
+	case EnrichmentPending, EnrichmentEnriched, EnrichmentFailed, EnrichmentNotFound:
+		return true
+	}
+	return false
+}
+
+// EnrichmentJob - задание очереди обогащения. Пока задание захвачено обработчиком,
+// LockedUntil не даёт взять его другому; если обработчик упал, задание
+// освобождается по истечении этого времени.
+type EnrichmentJob struct {
+	SongID        uuid.UUID  `json:"-" gorm:"type:uuid;primaryKey"`
+	Attempts      int        `json:"attempts" gorm:"not null" example:"2"`
+	LastError     string     `json:"lastError,omitempty" gorm:"not null" example:"внешний API вернул статус 503"`
+	NextAttemptAt time.Time  `json:"nextAttemptAt" gorm:"not null"`
+	LockedUntil   *time.Time `json:"-"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+type RetryEnrichmentResponse struct {
+	// Queued - сколько песен возвращено в очередь
+	Queued int `json:"queued" example:"3"`
+}
+
+// BackfillStatus - состояние прогона дозаполнения.
+type BackfillStatus string
+
+const (
+	BackfillRunning   BackfillStatus = "running"
+	BackfillCompleted BackfillStatus = "completed"
+	BackfillCancelled BackfillStatus = "cancelled"
+	// BackfillFailed - песни для прогона не удалось отобрать, причина в LastError
+	BackfillFailed BackfillStatus = "failed"
+)
+
+// BackfillTrigger - кто запустил прогон дозаполнения.
+type BackfillTrigger string
+
+const (
+	BackfillManual    BackfillTrigger = "manual"
+	BackfillScheduled BackfillTrigger = "scheduled"
+)
+
+// BackfillRequest - параметры прогона дозаполнения.
+type BackfillRequest struct {
+	// StaleAfter - также обновить песни, данные источников в которых старше этого срока;
+	// без него обрабатываются только неполные песни
+	StaleAfter string `json:"staleAfter" example:"720h"`
+	// Limit ограничивает число песен за прогон; 0 - без ограничения
+	Limit int `json:"limit" example:"100"`
+}
+
+// BackfillRun - прогон дозаполнения: сколько песен найдено и что с ними стало.
+type BackfillRun struct {
+	ID         uuid.UUID       `json:"id" example:"c3c2a1f0-6a3e-4a8f-9b8e-0d6f4f5c1a2b"`
+	Status     BackfillStatus  `json:"status" example:"running"`
+	Trigger    BackfillTrigger `json:"trigger" example:"manual"`
+	StaleAfter string          `json:"staleAfter,omitempty" example:"720h0m0s"`
+	// Total - сколько песен отобрано для прогона; 0, пока песни отбираются
+	Total     int `json:"total" example:"120"`
+	Processed int `json:"processed" example:"45"`
+	// Enriched - песни, данные которых источники нашли
+	Enriched int `json:"enriched" example:"40"`
+	// NotFound - песни, которых не знает ни один источник
+	NotFound int `json:"notFound" example:"3"`
+	// Failed - песни, для которых источники ответили ошибкой; их статус не меняется
+	Failed int `json:"failed" example:"1"`
+	// Skipped - песни, которые ждут в очереди обогащения или удалены во время прогона
+	Skipped   int    `json:"skipped" example:"1"`
+	LastError string `json:"lastError,omitempty" example:"внешний API временно недоступен"`
+	// ResumeAt - источники временно недоступны, и прогон приостановлен до этого времени
+	ResumeAt   *time.Time `json:"resumeAt,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
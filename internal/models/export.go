@@ -0,0 +1,11 @@
+// export.go
+package models
+
+// ExportOptions - параметры выгрузки песен. Фильтр песен передаётся отдельно, как SongFilter.
+type ExportOptions struct {
+	Format FileFormat `form:"format"`
+	// Columns - выгружаемые колонки в нужном порядке; пусто - все, кроме текста
+	Columns []string `form:"columns"`
+	// Lyrics добавляет к колонкам текст песни
+	Lyrics bool `form:"lyrics"`
+}
@@ -0,0 +1,35 @@
+// genre.go
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Genre - жанр из управляемого справочника. Песне можно назначить только существующий жанр.
+type Genre struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primaryKey" example:"3f2b1c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"`
+	Name           string    `json:"name" gorm:"not null" example:"Alternative Rock"`
+	NormalizedName string    `json:"-" gorm:"not null;uniqueIndex"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type CreateGenreRequest struct {
+	Name string `json:"name" binding:"required" example:"Alternative Rock"`
+}
+
+type UpdateGenreRequest struct {
+	Name string `json:"name" binding:"required" example:"Alternative Rock"`
+}
+
+type AddSongGenreRequest struct {
+	GenreID string `json:"genreId" binding:"required,uuid" example:"3f2b1c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"`
+}
+
+// NormalizeGenreName приводит название жанра к виду, по которому жанры сравниваются.
+func NormalizeGenreName(name string) string {
+	return strings.ToLower(CleanArtistName(name))
+}
@@ -0,0 +1,95 @@
+// import.go
+package models
+
+import "github.com/google/uuid"
+
+// FileFormat - формат файла массового импорта и экспорта песен.
+type FileFormat string
+
+const (
+	FormatCSV    FileFormat = "csv"
+	FormatJSON   FileFormat = "json"
+	FormatNDJSON FileFormat = "ndjson"
+)
+
+func (f FileFormat) Valid() bool {
+	switch f {
+	case FormatCSV, FormatJSON, FormatNDJSON:
+		return true
+	}
+	return false
+}
+
+// DuplicatePolicy определяет, что делать со строкой, если песня уже есть в библиотеке:
+// skip - оставить песню как есть, overwrite - заменить все поля значениями из строки,
+// merge - заменить только поля, заполненные в строке.
+type DuplicatePolicy string
+
+const (
+	DuplicateSkip      DuplicatePolicy = "skip"
+	DuplicateOverwrite DuplicatePolicy = "overwrite"
+	DuplicateMerge     DuplicatePolicy = "merge"
+)
+
+func (p DuplicatePolicy) Valid() bool {
+	switch p {
+	case DuplicateSkip, DuplicateOverwrite, DuplicateMerge:
+		return true
+	}
+	return false
+}
+
+type ImportOptions struct {
+	Format      FileFormat      `form:"format"`
+	OnDuplicate DuplicatePolicy `form:"onDuplicate"`
+	// DryRun проверяет файл и строит отчёт, ничего не сохраняя
+	DryRun bool `form:"dryRun"`
+	// Enrich ставит песни с пустыми датой, текстом или ссылкой в очередь обогащения;
+	// при DryRun очередь не трогается
+	Enrich bool `form:"enrich"`
+}
+
+// ImportSong - строка файла импорта в форме models.Song. Значения остаются строками,
+// чтобы ошибку в одной строке можно было показать в отчёте, не прерывая импорт.
+// Песня с указанным id считается той же песней, без id - песня с тем же
+// исполнителем и названием без учёта регистра.
+type ImportSong struct {
+	ID          string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	GroupName   string `json:"group" example:"Muse"`
+	SongTitle   string `json:"song" example:"Supermassive Black Hole"`
+	ReleaseDate string `json:"releaseDate" example:"2006-07-16"`
+	Text        string `json:"text" example:"Ooh baby, don't you know I suffer?..."`
+	Link        string `json:"link" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+}
+
+// ImportStatus - итог обработки строки импорта.
+type ImportStatus string
+
+const (
+	ImportCreated ImportStatus = "created"
+	ImportUpdated ImportStatus = "updated"
+	ImportSkipped ImportStatus = "skipped"
+	ImportFailed  ImportStatus = "failed"
+)
+
+// ImportRowResult - итог строки. Row - номер строки файла для CSV и NDJSON
+// и номер элемента массива с единицы для JSON.
+type ImportRowResult struct {
+	Row       int          `json:"row" example:"2"`
+	Status    ImportStatus `json:"status" example:"created"`
+	SongID    *uuid.UUID   `json:"songId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	GroupName string       `json:"group,omitempty" example:"Muse"`
+	SongTitle string       `json:"song,omitempty" example:"Supermassive Black Hole"`
+	// Reason объясняет пропуск или ошибку
+	Reason string `json:"reason,omitempty" example:"песня уже есть в библиотеке"`
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dryRun"`
+	Total   int               `json:"total" example:"4"`
+	Created int               `json:"created" example:"2"`
+	Updated int               `json:"updated" example:"1"`
+	Skipped int               `json:"skipped" example:"0"`
+	Failed  int               `json:"failed" example:"1"`
+	Rows    []ImportRowResult `json:"rows"`
+}
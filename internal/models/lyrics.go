@@ -0,0 +1,61 @@
+// lyrics.go
+package models
+
+import "github.com/google/uuid"
+
+// LyricsSectionType - тип части текста песни.
+type LyricsSectionType string
+
+const (
+	SectionIntro        LyricsSectionType = "intro"
+	SectionVerse        LyricsSectionType = "verse"
+	SectionPreChorus    LyricsSectionType = "pre-chorus"
+	SectionChorus       LyricsSectionType = "chorus"
+	SectionPostChorus   LyricsSectionType = "post-chorus"
+	SectionBridge       LyricsSectionType = "bridge"
+	SectionInstrumental LyricsSectionType = "instrumental"
+	SectionOutro        LyricsSectionType = "outro"
+	SectionOther        LyricsSectionType = "other"
+)
+
+// LyricsSection - размеченная часть текста песни.
+// Position - порядковый номер части в песне с единицы, Number - номер среди частей
+// того же типа: второй куплет имеет Number 2, а все повторы одного припева - один номер.
+type LyricsSection struct {
+	SongID   uuid.UUID         `json:"-" gorm:"type:uuid;primaryKey"`
+	Position int               `json:"position" gorm:"primaryKey;autoIncrement:false" example:"2"`
+	Type     LyricsSectionType `json:"type" gorm:"not null" example:"chorus"`
+	Number   int               `json:"number" gorm:"not null" example:"1"`
+	// Label - исходная метка из текста, например "Chorus: Eminem" или "Припев"
+	Label string `json:"label,omitempty" gorm:"not null" example:"Припев"`
+	Text  string `json:"text" gorm:"not null" example:"Ooh baby, don't you know I suffer?"`
+	// RepeatOf - позиция первого появления, если часть повторяет уже встречавшуюся
+	RepeatOf *int `json:"repeatOf,omitempty" example:"1"`
+}
+
+// LyricsMode определяет, как в ответе показываются повторяющиеся части.
+type LyricsMode string
+
+const (
+	// LyricsExpand возвращает повторы с полным текстом
+	LyricsExpand LyricsMode = "expand"
+	// LyricsCollapse возвращает повторы без текста, только со ссылкой repeatOf
+	LyricsCollapse LyricsMode = "collapse"
+)
+
+func (m LyricsMode) Valid() bool {
+	return m == LyricsExpand || m == LyricsCollapse
+}
+
+// LyricsOptions - параметры ответа /lyrics.
+// Language выбирает перевод явно, AcceptLanguages - языки из Accept-Language
+// по убыванию приоритета, которые учитываются, только если Language не задан.
+type LyricsOptions struct {
+	Mode            LyricsMode
+	View            LyricsView
+	Language        string
+	AcceptLanguages []string
+}
+
+// LyricsOriginal в параметре lang запрашивает оригинальный текст без перевода.
+const LyricsOriginal = "original"
@@ -0,0 +1,32 @@
+// pagination.go
+package models
+
+// PageInfo описывает страницу списка: её номер и размер, общее число элементов и страниц
+// и ссылки на соседние страницы. Если список поддерживает курсоры, NextCursor и PrevCursor
+// указывают на последний и первый элементы страницы; в режиме курсоров нет номера страницы
+// и общего числа элементов и страниц: их подсчёт на каждой странице обходил бы весь список.
+// Ссылки заполняют контроллеры.
+type PageInfo struct {
+	Page       int    `json:"page,omitempty" example:"2"`
+	Limit      int    `json:"limit" example:"10"`
+	Total      *int64 `json:"total,omitempty" example:"42"`
+	TotalPages *int   `json:"totalPages,omitempty" example:"5"`
+	NextCursor string `json:"nextCursor,omitempty" example:"eyJjIjoiMjAyNC0wMS0wMlQxMDowMDowMFoiLCJpIjoiLi4uIn0.c2lnbmF0dXJl"`
+	PrevCursor string `json:"prevCursor,omitempty" example:"eyJjIjoiMjAyNC0wMS0wMVQxMDowMDowMFoiLCJpIjoiLi4uIn0.c2lnbmF0dXJl"`
+	Next       string `json:"next,omitempty" example:"/api/v2/songs?limit=10&page=3"`
+	Prev       string `json:"prev,omitempty" example:"/api/v2/songs?limit=10&page=1"`
+}
+
+// NewPageInfo описывает страницу page размером limit в списке из total элементов.
+func NewPageInfo(page, limit int, total int64) PageInfo {
+	totalPages := 0
+	if total > 0 {
+		totalPages = int((total + int64(limit) - 1) / int64(limit))
+	}
+	return PageInfo{
+		Page:       page,
+		Limit:      limit,
+		Total:      &total,
+		TotalPages: &totalPages,
+	}
+}
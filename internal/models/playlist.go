@@ -0,0 +1,58 @@
+// playlist.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Playlist struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey" example:"0b7e6a3c-2a8e-4b3f-9a5e-6f1d2c3b4a59"`
+	Name        string    `json:"name" gorm:"not null" example:"Вечер у камина"`
+	Description string    `json:"description" gorm:"not null" example:"Спокойная музыка"`
+	EntryCount  int64     `json:"entryCount" gorm:"->" example:"12"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// PlaylistEntry - позиция в плейлисте. Позиции нумеруются с единицы без пропусков.
+// Если песню удалили, запись остаётся в плейлисте и помечается через SongRemovedAt.
+type PlaylistEntry struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey" example:"5f0c8a1e-7d2b-4c3a-8e9f-1a2b3c4d5e6f"`
+	PlaylistID    uuid.UUID  `json:"-" gorm:"type:uuid;not null"`
+	SongID        uuid.UUID  `json:"songId" gorm:"type:uuid;not null" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Position      int        `json:"position" gorm:"not null" example:"1"`
+	AddedAt       time.Time  `json:"addedAt" gorm:"not null"`
+	SongRemovedAt *time.Time `json:"songRemovedAt,omitempty"`
+	Song          *Song      `json:"song,omitempty" gorm:"foreignKey:SongID"`
+}
+
+type CreatePlaylistRequest struct {
+	Name        string `json:"name" binding:"required" example:"Вечер у камина"`
+	Description string `json:"description" example:"Спокойная музыка"`
+}
+
+type UpdatePlaylistRequest struct {
+	Name        string  `json:"name" example:"Вечер у камина"`
+	Description *string `json:"description" example:"Спокойная музыка"`
+}
+
+type AddPlaylistEntryRequest struct {
+	SongID string `json:"songId" binding:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	// Position - куда вставить песню; если не указана, песня добавляется в конец
+	Position int `json:"position" binding:"omitempty,min=1" example:"3"`
+}
+
+type MovePlaylistEntryRequest struct {
+	Position int `json:"position" binding:"required,min=1" example:"1"`
+}
+
+type PlaylistEntriesResponse struct {
+	Entries []PlaylistEntry `json:"entries"`
+	Page    int             `json:"page"`
+	Limit   int             `json:"limit"`
+	Total   int64           `json:"total"`
+	// Seed возвращается в режиме перемешивания, чтобы клиент мог воспроизвести порядок
+	Seed *int64 `json:"seed,omitempty" example:"42"`
+}
@@ -0,0 +1,63 @@
+// provenance.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Поля песни, которые заполняют источники метаданных.
+const (
+	SongFieldReleaseDate = "releaseDate"
+	SongFieldText        = "text"
+	SongFieldLink        = "link"
+	// SongFieldAlbum - альбом, к которому источник привязал песню; заблокировать его нельзя
+	SongFieldAlbum = "album"
+)
+
+// LockableSongFields - поля, которые можно защитить от перезаписи обогащением.
+var LockableSongFields = []string{SongFieldReleaseDate, SongFieldText, SongFieldLink}
+
+// RawJSON - ответ источника как есть. В API выводится JSON-значением,
+// а если ответ не JSON - строкой.
+type RawJSON string
+
+func (r RawJSON) MarshalJSON() ([]byte, error) {
+	if r == "" {
+		return []byte("null"), nil
+	}
+	if !json.Valid([]byte(r)) {
+		return json.Marshal(string(r))
+	}
+	return []byte(r), nil
+}
+
+// SongFieldSource - происхождение поля песни: какой источник его заполнил,
+// когда и каким ответом. Locked - поле правили вручную, и обогащение
+// его больше не перезаписывает.
+type SongFieldSource struct {
+	SongID    uuid.UUID  `json:"-" gorm:"type:uuid;primaryKey"`
+	Field     string     `json:"field" gorm:"primaryKey" example:"text"`
+	Provider  string     `json:"provider,omitempty" gorm:"not null" example:"musicapi"`
+	FetchedAt *time.Time `json:"fetchedAt,omitempty"`
+	Payload   RawJSON    `json:"payload,omitempty" gorm:"not null" swaggertype:"object"`
+	Locked    bool       `json:"locked" gorm:"not null"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// SongProvenance - происхождение полей песни. В Fields есть все поля,
+// которые заполняют источники, даже если о них ещё ничего не известно.
+type SongProvenance struct {
+	SongID           uuid.UUID         `json:"songId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	EnrichmentStatus EnrichmentStatus  `json:"enrichmentStatus,omitempty" example:"enriched"`
+	Fields           []SongFieldSource `json:"fields"`
+}
+
+// SongLocksRequest включает и снимает блокировку полей; поле без значения не меняется.
+type SongLocksRequest struct {
+	ReleaseDate *bool `json:"releaseDate" example:"true"`
+	Text        *bool `json:"text" example:"true"`
+	Link        *bool `json:"link" example:"false"`
+}
@@ -0,0 +1,227 @@
+// release_date.go
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DatePrecision - с какой точностью известна дата выпуска.
+type DatePrecision string
+
+const (
+	PrecisionYear  DatePrecision = "year"
+	PrecisionMonth DatePrecision = "month"
+	PrecisionDay   DatePrecision = "day"
+)
+
+// MinKnownReleaseDate - даты раньше этой считаются незаполненными: так хранится
+// дата, которую источник не знал или прислал в непонятном формате.
+var MinKnownReleaseDate = time.Date(1, time.January, 2, 0, 0, 0, 0, time.UTC)
+
+// ReleaseDate - дата выпуска песни с точностью до года, месяца или дня. Time - начало
+// периода в UTC: "1975" хранится как 1 января 1975 года с точностью year.
+// В API дата передаётся сокращённой записью ISO 8601 - 1975, 1975-07 или 1975-07-16,
+// а неизвестная - null.
+type ReleaseDate struct {
+	Time      time.Time     `gorm:"column:release_date"`
+	Precision DatePrecision `gorm:"column:release_date_precision;not null"`
+}
+
+// NewReleaseDate возвращает дату с точностью precision; лишние части t отбрасываются.
+func NewReleaseDate(t time.Time, precision DatePrecision) ReleaseDate {
+	year, month, day := t.Date()
+	switch precision {
+	case PrecisionYear:
+		month, day = time.January, 1
+	case PrecisionMonth:
+		day = 1
+	default:
+		precision = PrecisionDay
+	}
+	return ReleaseDate{
+		Time:      time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+		Precision: precision,
+	}
+}
+
+// End возвращает начало следующего периода: для 1975 - 1 января 1976 года,
+// для 1975-07 - 1 августа 1975 года.
+func (d ReleaseDate) End() time.Time {
+	switch d.Precision {
+	case PrecisionYear:
+		return d.Time.AddDate(1, 0, 0)
+	case PrecisionMonth:
+		return d.Time.AddDate(0, 1, 0)
+	}
+	return d.Time.AddDate(0, 0, 1)
+}
+
+// IsZero сообщает, что дата выпуска неизвестна.
+func (d ReleaseDate) IsZero() bool {
+	return d.Time.Before(MinKnownReleaseDate)
+}
+
+// Equal сравнивает даты вместе с точностью; все неизвестные даты равны.
+func (d ReleaseDate) Equal(other ReleaseDate) bool {
+	if d.IsZero() || other.IsZero() {
+		return d.IsZero() == other.IsZero()
+	}
+	return d.Time.Equal(other.Time) && d.Precision == other.Precision
+}
+
+// String возвращает дату в сокращённой записи ISO 8601 или пустую строку, если она неизвестна.
+func (d ReleaseDate) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	t := d.Time.UTC()
+	switch d.Precision {
+	case PrecisionYear:
+		return t.Format("2006")
+	case PrecisionMonth:
+		return t.Format("2006-01")
+	}
+	return t.Format("2006-01-02")
+}
+
+func (d ReleaseDate) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON принимает те же форматы, что и ParseReleaseDate, поэтому читает
+// и ревизии, сохранённые, когда дата передавалась как RFC 3339.
+func (d *ReleaseDate) UnmarshalJSON(data []byte) error {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == nil || strings.TrimSpace(*value) == "" {
+		*d = ReleaseDate{}
+		return nil
+	}
+	parsed, ok := ParseReleaseDate(*value)
+	if !ok {
+		// Так неизвестная дата записана в старых ревизиях
+		if t, err := time.Parse(time.RFC3339, *value); err == nil && t.Before(MinKnownReleaseDate) {
+			*d = ReleaseDate{}
+			return nil
+		}
+		return fmt.Errorf("некорректная дата выпуска %q", *value)
+	}
+	*d = parsed
+	return nil
+}
+
+// releaseDateLayouts - числовые форматы даты выпуска: ISO 8601 полностью и с точностью
+// до месяца или года, а также формат внешнего API.
+var releaseDateLayouts = []struct {
+	layout    string
+	precision DatePrecision
+}{
+	{time.RFC3339, PrecisionDay},
+	{"2006-01-02T15:04:05", PrecisionDay},
+	{"2006-01-02", PrecisionDay},
+	{"2006-01", PrecisionMonth},
+	{"2006", PrecisionYear},
+	{"2.1.2006", PrecisionDay},
+	{"1.2006", PrecisionMonth},
+}
+
+// monthNames - названия месяцев по-английски и по-русски, полные и сокращённые;
+// русские - в именительном и родительном падеже.
+var monthNames = buildMonthNames()
+
+func buildMonthNames() map[string]time.Month {
+	names := [12][]string{
+		{"january", "jan", "январь", "января", "янв"},
+		{"february", "feb", "февраль", "февраля", "фев", "февр"},
+		{"march", "mar", "март", "марта", "мар"},
+		{"april", "apr", "апрель", "апреля", "апр"},
+		{"may", "май", "мая"},
+		{"june", "jun", "июнь", "июня", "июн"},
+		{"july", "jul", "июль", "июля", "июл"},
+		{"august", "aug", "август", "августа", "авг"},
+		{"september", "sep", "sept", "сентябрь", "сентября", "сен", "сент"},
+		{"october", "oct", "октябрь", "октября", "окт"},
+		{"november", "nov", "ноябрь", "ноября", "ноя", "нояб"},
+		{"december", "dec", "декабрь", "декабря", "дек"},
+	}
+	months := map[string]time.Month{}
+	for i, forms := range names {
+		for _, name := range forms {
+			months[name] = time.Month(i + 1)
+		}
+	}
+	return months
+}
+
+// ParseReleaseDate разбирает дату выпуска: ISO 8601 (2006-07-16, 2006-07, 2006 или
+// с временем), 16.07.2006, 07.2006, а также даты с названием месяца по-английски
+// и по-русски: July 16, 2006, 16 July 2006, July 2006, 16 июля 2006 г., июль 2006.
+func ParseReleaseDate(value string) (ReleaseDate, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ReleaseDate{}, false
+	}
+
+	for _, format := range releaseDateLayouts {
+		if parsed, err := time.Parse(format.layout, value); err == nil {
+			date := NewReleaseDate(parsed, format.precision)
+			return date, !date.IsZero()
+		}
+	}
+	return parseWordDate(value)
+}
+
+// parseWordDate разбирает дату с названием месяца: число и год в любом порядке
+// вокруг месяца или только год.
+func parseWordDate(value string) (ReleaseDate, bool) {
+	words := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == '.'
+	})
+
+	var month time.Month
+	var year, day int
+	for _, word := range words {
+		if m, ok := monthNames[word]; ok && month == 0 {
+			month = m
+			continue
+		}
+		switch word {
+		case "г", "год", "года":
+			continue
+		}
+		number, err := strconv.Atoi(word)
+		switch {
+		case err != nil:
+			return ReleaseDate{}, false
+		case len(word) == 4 && year == 0:
+			year = number
+		case len(word) <= 2 && day == 0 && number > 0:
+			day = number
+		default:
+			return ReleaseDate{}, false
+		}
+	}
+	if month == 0 || year == 0 {
+		return ReleaseDate{}, false
+	}
+
+	if day == 0 {
+		return NewReleaseDate(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), PrecisionMonth), true
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day {
+		// 31 апреля и подобные time.Date переносит на следующий месяц
+		return ReleaseDate{}, false
+	}
+	return NewReleaseDate(date, PrecisionDay), true
+}
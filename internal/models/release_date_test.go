@@ -0,0 +1,115 @@
+// release_date_test.go
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseReleaseDate(t *testing.T) {
+	tests := []struct {
+		value     string
+		want      string
+		precision DatePrecision
+		ok        bool
+	}{
+		{value: "2006-07-16", want: "2006-07-16", precision: PrecisionDay, ok: true},
+		{value: " 2006-07 ", want: "2006-07", precision: PrecisionMonth, ok: true},
+		{value: "2006", want: "2006", precision: PrecisionYear, ok: true},
+		{value: "2006-07-16T23:30:00+03:00", want: "2006-07-16", precision: PrecisionDay, ok: true},
+		{value: "2006-07-16T10:00:00", want: "2006-07-16", precision: PrecisionDay, ok: true},
+		{value: "16.07.2006", want: "2006-07-16", precision: PrecisionDay, ok: true},
+		{value: "07.2006", want: "2006-07", precision: PrecisionMonth, ok: true},
+		{value: "July 16, 2006", want: "2006-07-16", precision: PrecisionDay, ok: true},
+		{value: "16 Jul 2006", want: "2006-07-16", precision: PrecisionDay, ok: true},
+		{value: "July 2006", want: "2006-07", precision: PrecisionMonth, ok: true},
+		{value: "16 июля 2006 г.", want: "2006-07-16", precision: PrecisionDay, ok: true},
+		{value: "Июль 2006", want: "2006-07", precision: PrecisionMonth, ok: true},
+		{value: "29 февраля 2004", want: "2004-02-29", precision: PrecisionDay, ok: true},
+		{value: ""},
+		{value: "0001-01-01"},
+		{value: "2006-13-01"},
+		{value: "31 апреля 2006"},
+		{value: "29 февраля 2005"},
+		{value: "July"},
+		{value: "16 2006"},
+		{value: "July 16 17 2006"},
+		{value: "когда-то в 2006"},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseReleaseDate(tt.value)
+		if ok != tt.ok {
+			t.Errorf("ParseReleaseDate(%q): ok = %v, ожидалось %v", tt.value, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if got.String() != tt.want || got.Precision != tt.precision {
+			t.Errorf("ParseReleaseDate(%q) = %s (%s), ожидалось %s (%s)",
+				tt.value, got, got.Precision, tt.want, tt.precision)
+		}
+	}
+}
+
+func TestReleaseDateEnd(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "1999", want: "2000-01-01"},
+		{value: "1999-12", want: "2000-01-01"},
+		{value: "1999-12-31", want: "2000-01-01"},
+		{value: "2004-02", want: "2004-03-01"},
+	}
+
+	for _, tt := range tests {
+		date, ok := ParseReleaseDate(tt.value)
+		if !ok {
+			t.Fatalf("ParseReleaseDate(%q) не разобрал дату", tt.value)
+		}
+		if got := date.End().Format("2006-01-02"); got != tt.want {
+			t.Errorf("End(%q) = %s, ожидалось %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestReleaseDateJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{data: `"1975-07"`, want: `"1975-07"`},
+		{data: `null`, want: `null`},
+		{data: `""`, want: `null`},
+		// Так неизвестная дата записана в старых ревизиях
+		{data: `"0001-01-01T00:00:00Z"`, want: `null`},
+		{data: `"1975-07-16T00:00:00Z"`, want: `"1975-07-16"`},
+		{data: `"вчера"`, wantErr: true},
+		{data: `1975`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var date ReleaseDate
+		err := json.Unmarshal([]byte(tt.data), &date)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Unmarshal(%s) = %s, ожидалась ошибка", tt.data, date)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.data, err)
+			continue
+		}
+		got, err := json.Marshal(date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("Unmarshal(%s) и Marshal = %s, ожидалось %s", tt.data, got, tt.want)
+		}
+	}
+}
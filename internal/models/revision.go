@@ -0,0 +1,100 @@
+// revision.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SongRevision - сохранённое состояние песни после изменения.
+// Ревизии нумеруются с единицы отдельно для каждой песни.
+type SongRevision struct {
+	SongID   uuid.UUID    `json:"songId" gorm:"type:uuid;primaryKey" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Revision int          `json:"revision" gorm:"primaryKey;autoIncrement:false" example:"3"`
+	Snapshot SongSnapshot `json:"snapshot" gorm:"serializer:json;not null"`
+	// ChangedBy - автор изменения из заголовка X-User; пусто, если он не указан
+	ChangedBy string `json:"changedBy,omitempty" gorm:"not null" example:"editor"`
+	Note      string `json:"note,omitempty" gorm:"not null" example:"Исправлена опечатка в припеве"`
+	// RestoredFrom - номер ревизии, которая была восстановлена этим изменением
+	RestoredFrom *int      `json:"restoredFrom,omitempty" example:"1"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// SongSnapshot - полное состояние редактируемых полей песни.
+type SongSnapshot struct {
+	ArtistID    uuid.UUID   `json:"artistId" example:"7d444840-9dc0-11d1-b245-5ffdce74fad2"`
+	GroupName   string      `json:"group" example:"Muse"`
+	SongTitle   string      `json:"song" example:"Supermassive Black Hole"`
+	ReleaseDate ReleaseDate `json:"releaseDate" swaggertype:"string" example:"2006-07-16"`
+	Text        string      `json:"text" example:"Ooh baby, don't you know I suffer?..."`
+	Link        string      `json:"link" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+}
+
+// SnapshotOf возвращает состояние песни для ревизии.
+func SnapshotOf(song *Song) SongSnapshot {
+	return SongSnapshot{
+		ArtistID:    song.ArtistID,
+		GroupName:   song.GroupName,
+		SongTitle:   song.SongTitle,
+		ReleaseDate: song.ReleaseDate,
+		Text:        song.Text,
+		Link:        song.Link,
+	}
+}
+
+// Equal сравнивает состояния; даты сравниваются вместе с точностью.
+func (s SongSnapshot) Equal(other SongSnapshot) bool {
+	return s.ArtistID == other.ArtistID &&
+		s.GroupName == other.GroupName &&
+		s.SongTitle == other.SongTitle &&
+		s.ReleaseDate.Equal(other.ReleaseDate) &&
+		s.Text == other.Text &&
+		s.Link == other.Link
+}
+
+type SongRevisionsResponse struct {
+	Revisions []SongRevision `json:"revisions"`
+	Page      int            `json:"page"`
+	Limit     int            `json:"limit"`
+	Total     int64          `json:"total"`
+}
+
+type RestoreRevisionRequest struct {
+	Note string `json:"note" example:"Откат случайной правки"`
+}
+
+// DiffOp - вид строки в построчном сравнении.
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// DiffLine - строка сравнения. OldLine и NewLine - номера строк с единицы
+// в старом и новом тексте; у добавленной строки нет OldLine, у удалённой - NewLine.
+type DiffLine struct {
+	Op      DiffOp `json:"op" example:"insert"`
+	OldLine *int   `json:"oldLine,omitempty" example:"4"`
+	NewLine *int   `json:"newLine,omitempty" example:"5"`
+	Text    string `json:"text" example:"Supermassive black hole"`
+}
+
+// FieldChange - изменение поля песни между ревизиями.
+type FieldChange struct {
+	Field string `json:"field" example:"song"`
+	From  string `json:"from" example:"Supermasive Black Hole"`
+	To    string `json:"to" example:"Supermassive Black Hole"`
+}
+
+// RevisionDiff - сравнение двух ревизий: изменённые поля и построчный diff текста.
+type RevisionDiff struct {
+	From    int           `json:"from" example:"1"`
+	To      int           `json:"to" example:"3"`
+	Fields  []FieldChange `json:"fields"`
+	Lines   []DiffLine    `json:"lines"`
+	Added   int           `json:"added" example:"2"`
+	Removed int           `json:"removed" example:"1"`
+}
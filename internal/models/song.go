@@ -5,37 +5,253 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Song struct {
-	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey" example:"123e4567-e89b-12d3-a456-426614174000"`
-	GroupName   string    `json:"group" gorm:"not null;column:group_name" example:"Muse"`
-	SongTitle   string    `json:"song" gorm:"not null;column:song_title" example:"Supermassive Black Hole"`
-	ReleaseDate time.Time `json:"releaseDate" example:"2006-07-16T00:00:00Z"`
-	Text        string    `json:"text" example:"Ooh baby, don't you know I suffer?..."`
-	Link        string    `json:"link" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ArtistID  uuid.UUID `json:"artistId" gorm:"type:uuid;not null" example:"7d444840-9dc0-11d1-b245-5ffdce74fad2"`
+	Artist    *Artist   `json:"-" gorm:"foreignKey:ArtistID"`
+	GroupName string    `json:"group" gorm:"-" example:"Muse"`
+	SongTitle string    `json:"song" gorm:"not null;column:song_title" example:"Supermassive Black Hole"`
+	// ReleaseDate - дата выпуска с точностью до года, месяца или дня: 1975, 1975-07 или 1975-07-16
+	ReleaseDate ReleaseDate `json:"releaseDate" gorm:"embedded" swaggertype:"string" example:"2006-07-16"`
+	Text        string      `json:"text" example:"Ooh baby, don't you know I suffer?..."`
+	Link        string      `json:"link" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+	Genres      []Genre     `json:"genres,omitempty" gorm:"many2many:song_genres"`
+	Tags        []Tag       `json:"tags,omitempty" gorm:"many2many:song_tags"`
+	// EnrichmentStatus - состояние обогащения из источников метаданных, Enrichment - задание
+	// в очереди, пока обогащение не завершилось успешно
+	EnrichmentStatus EnrichmentStatus `json:"enrichmentStatus,omitempty" gorm:"not null" example:"pending"`
+	Enrichment       *EnrichmentJob   `json:"enrichment,omitempty" gorm:"foreignKey:SongID"`
+	CreatedAt        time.Time        `json:"createdAt"`
+	UpdatedAt        time.Time        `json:"updatedAt"`
+	// DeletedAt - время перемещения в корзину; GORM не видит такие песни без Unscoped
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index" swaggerignore:"true"`
+}
+
+// AfterFind заполняет название группы из связанного исполнителя,
+// чтобы поле group в API продолжало работать.
+func (s *Song) AfterFind(tx *gorm.DB) error {
+	if s.Artist != nil {
+		s.GroupName = s.Artist.Name
+	}
+	return nil
 }
 
 type AddSongRequest struct {
 	GroupName string `json:"group" binding:"required" example:"Muse"`
 	SongTitle string `json:"song" binding:"required" example:"Supermassive Black Hole"`
+	// ReleaseDate, Text и Link необязательны; дата принимается в форматах ParseReleaseDate
+	ReleaseDate string `json:"releaseDate" example:"2006-07-16"`
+	Text        string `json:"text" example:"Ooh baby, don't you know I suffer?..."`
+	Link        string `json:"link" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+	// Mode - как сочетать переданные поля с данными источников; по умолчанию enrich
+	Mode AddSongMode `json:"mode" enums:"enrich,enrich-if-missing,manual-only" example:"enrich-if-missing"`
+}
+
+// AddSongMode - как заполнить дату выпуска, текст и ссылку новой песни.
+type AddSongMode string
+
+const (
+	// AddSongEnrich - данные источников важнее переданных; переданное поле остаётся,
+	// если источники его не знают
+	AddSongEnrich AddSongMode = "enrich"
+	// AddSongEnrichIfMissing - переданные поля сохраняются и блокируются, источники
+	// заполняют только недостающие
+	AddSongEnrichIfMissing AddSongMode = "enrich-if-missing"
+	// AddSongManualOnly - источники не опрашиваются, а поля блокируются, чтобы их
+	// не заполнило дозаполнение
+	AddSongManualOnly AddSongMode = "manual-only"
+)
+
+func (m AddSongMode) Valid() bool {
+	switch m {
+	case AddSongEnrich, AddSongEnrichIfMissing, AddSongManualOnly:
+		return true
+	}
+	return false
 }
 
 type UpdateSongRequest struct {
 	GroupName string `json:"group" example:"Muse"`
 	SongTitle string `json:"song" example:"Uprising"`
+	// ReleaseDate принимается в форматах ParseReleaseDate: 2006-07-16, 2006-07, 2006,
+	// 16.07.2006, July 16, 2006, 16 июля 2006 и т. п.
+	ReleaseDate string `json:"releaseDate" example:"2009-09-07"`
+	Text        string `json:"text" example:"Paranoia is in bloom..."`
+	Link        string `json:"link" example:"https://www.youtube.com/watch?v=w8KQmps-Sog"`
+	// Note - комментарий к изменению для истории ревизий
+	Note string `json:"note" example:"Исправлено название"`
 }
 
+// SongFilter - параметры отбора песен. Параметры запроса с диапазонами дат, флагами,
+// списком ID и сортировкой приходят строками; prepareSongFilter в сервисах проверяет
+// их и заполняет разобранные поля с тегом form:"-".
 type SongFilter struct {
 	GroupName string `form:"group"`
 	SongTitle string `form:"song"`
+	// Match - как group и song сравниваются с названиями; по умолчанию contains
+	Match    TextMatch `form:"match"`
+	ArtistID uuid.UUID `form:"-"`
+	AlbumID  uuid.UUID `form:"-"`
+	// Tags и Genres содержат нормализованные названия
+	Tags     []string `form:"tags"`
+	TagMatch TagMatch `form:"tagMatch"`
+	Genres   []string `form:"genres"`
+	// Enrichment - фильтр по статусу обогащения
+	Enrichment EnrichmentStatus `form:"enrichment"`
+	// WithTrashed включает в выборку песни из корзины
+	WithTrashed bool `form:"-"`
+	// Incomplete отбирает песни без даты выпуска, текста или ссылки;
+	// пустое заблокированное поле песню неполной не делает
+	Incomplete bool `form:"-"`
+	// StaleBefore отбирает песни, у которых есть незаблокированное поле с данными
+	// источника, полученными раньше этого момента
+	StaleBefore time.Time `form:"-"`
+	// After и Before оставляют в списке песни строго после или до ключа в порядке
+	// добавления; на общее число песен под фильтром не влияют
+	After  *SongKey `form:"-"`
+	Before *SongKey `form:"-"`
+
+	// Параметры запроса в том виде, в каком их передал клиент
+	IDsParam          []string `form:"ids"`
+	ReleasedFromParam string   `form:"releasedFrom"`
+	ReleasedToParam   string   `form:"releasedTo"`
+	CreatedFromParam  string   `form:"createdFrom"`
+	CreatedToParam    string   `form:"createdTo"`
+	UpdatedFromParam  string   `form:"updatedFrom"`
+	UpdatedToParam    string   `form:"updatedTo"`
+	HasLyricsParam    string   `form:"hasLyrics"`
+	HasLinkParam      string   `form:"hasLink"`
+	SortParam         string   `form:"sort"`
+
+	// IDs оставляет только песни с этими ID
+	IDs []uuid.UUID `form:"-"`
+	// Released, Created и Updated - диапазоны даты выпуска, добавления и изменения;
+	// песни с неизвестной датой выпуска под диапазон Released не попадают
+	Released TimeRange `form:"-"`
+	Created  TimeRange `form:"-"`
+	Updated  TimeRange `form:"-"`
+	// HasLyrics и HasLink отбирают песни с текстом и ссылкой или без них; nil - не важно
+	HasLyrics *bool `form:"-"`
+	HasLink   *bool `form:"-"`
+	// Order - порядок списка; пустой - по добавлению, а с фильтром по альбому - по трек-листу
+	Order []SongOrder `form:"-"`
+}
+
+// TextMatch - как название в фильтре сравнивается с названием песни или группы.
+// Регистр и лишние пробелы не учитываются.
+type TextMatch string
+
+const (
+	TextMatchContains TextMatch = "contains"
+	TextMatchPrefix   TextMatch = "prefix"
+	TextMatchExact    TextMatch = "exact"
+)
+
+func (m TextMatch) Valid() bool {
+	switch m {
+	case TextMatchContains, TextMatchPrefix, TextMatchExact:
+		return true
+	}
+	return false
+}
+
+// TimeRange - полуинтервал [From, Before); нулевая граница не ограничивает.
+type TimeRange struct {
+	From   time.Time
+	Before time.Time
+}
+
+func (r TimeRange) IsZero() bool {
+	return r.From.IsZero() && r.Before.IsZero()
+}
+
+// Contains сообщает, что t попадает в диапазон.
+func (r TimeRange) Contains(t time.Time) bool {
+	return (r.From.IsZero() || !t.Before(r.From)) && (r.Before.IsZero() || t.Before(r.Before))
 }
 
+// SongSortField - поле, по которому можно сортировать список песен.
+type SongSortField string
+
+const (
+	SortSong        SongSortField = "song"
+	SortGroup       SongSortField = "group"
+	SortReleaseDate SongSortField = "releaseDate"
+	SortCreatedAt   SongSortField = "createdAt"
+	SortUpdatedAt   SongSortField = "updatedAt"
+)
+
+// SongSortFields - поля, по которым разрешена сортировка.
+var SongSortFields = []SongSortField{SortSong, SortGroup, SortReleaseDate, SortCreatedAt, SortUpdatedAt}
+
+// SongOrder - одно поле сортировки. Песни с неизвестной датой выпуска при сортировке
+// по ней идут последними в обоих направлениях.
+type SongOrder struct {
+	Field SongSortField
+	Desc  bool
+}
+
+// SongKey - позиция песни в списке по порядку добавления: время добавления,
+// а при равном времени - ID. На ней основаны курсоры списка песен.
+type SongKey struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"i"`
+}
+
+// KeyOf возвращает позицию песни в списке.
+func KeyOf(song *Song) SongKey {
+	return SongKey{CreatedAt: song.CreatedAt, ID: song.ID}
+}
+
+// Less сообщает, что песня с ключом k идёт в списке раньше песни с ключом other.
+func (k SongKey) Less(other SongKey) bool {
+	if !k.CreatedAt.Equal(other.CreatedAt) {
+		return k.CreatedAt.Before(other.CreatedAt)
+	}
+	return k.ID.String() < other.ID.String()
+}
+
+// SongLyricsResponse - страница частей текста песни.
+// Verses содержит те же тексты, что и Sections, для клиентов, которые не знают о разметке.
+// Если выбран перевод, Translation описывает его, а в режиме side-by-side
+// Pairs содержит части оригинала вместе с переводом.
 type SongLyricsResponse struct {
-	Verses []string `json:"verses"`
-	Page   int      `json:"page"`
-	Limit  int      `json:"limit"`
-	Total  int      `json:"total"`
+	Verses      []string         `json:"verses"`
+	Sections    []LyricsSection  `json:"sections"`
+	Translation *TranslationInfo `json:"translation,omitempty"`
+	Pairs       []LyricsPair     `json:"pairs,omitempty"`
+	PageInfo
+}
+
+// SongListResponse - страница списка песен. В Items у песен только поля из fields
+// и связанные данные из expand.
+type SongListResponse struct {
+	Items []SongView `json:"items"`
+	PageInfo
+}
+
+type SongSearchResponse struct {
+	Results []SongSearchResult `json:"results"`
+	Page    int                `json:"page"`
+	Limit   int                `json:"limit"`
+	Total   int64              `json:"total"`
+}
+
+// SongSearchResult - найденная песня. TitleSnippet и Snippet в VerseMatch экранированы
+// для HTML, совпадения в них выделены тегом <mark>.
+type SongSearchResult struct {
+	Song         Song         `json:"song"`
+	Rank         float64      `json:"rank" example:"0.6"`
+	TitleSnippet string       `json:"titleSnippet" example:"Supermassive <mark>Black</mark> Hole"`
+	Matches      []VerseMatch `json:"matches"`
+}
+
+// VerseMatch - куплет, в котором найдено совпадение.
+// Verse - позиция части текста с единицы в той же нумерации, что и в /lyrics.
+type VerseMatch struct {
+	Verse   int    `json:"verse" example:"2"`
+	Snippet string `json:"snippet" example:"<mark>Supermassive</mark> black hole"`
 }
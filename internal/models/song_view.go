@@ -0,0 +1,80 @@
+// song_view.go
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// Связи песни, которые хранилище подгружает вместе с ней.
+const (
+	SongRelationArtist     = "Artist"
+	SongRelationGenres     = "Genres"
+	SongRelationTags       = "Tags"
+	SongRelationEnrichment = "Enrichment"
+)
+
+// SongRelations - все связи, которые подгружаются вместе с песней.
+var SongRelations = []string{SongRelationArtist, SongRelationGenres, SongRelationTags, SongRelationEnrichment}
+
+// SongProjection - какие колонки песни читать из хранилища и какие связи подгружать.
+// Нулевое значение - песня целиком со всеми связями.
+type SongProjection struct {
+	// Columns - колонки таблицы songs; id читается всегда, пустой список - все колонки
+	Columns []string
+	// Without - связи из SongRelations, которые не нужно подгружать
+	Without []string
+}
+
+// Loads сообщает, нужно ли подгружать связь relation.
+func (p SongProjection) Loads(relation string) bool {
+	return !slices.Contains(p.Without, relation)
+}
+
+// SongViewOptions - параметры fields и expand: какие поля песни вернуть
+// и какие связанные данные добавить к ней.
+type SongViewOptions struct {
+	Fields []string `form:"fields"`
+	Expand []string `form:"expand"`
+}
+
+// SongView - песня в ответе API только с выбранными полями и связанными данными,
+// в том порядке, в котором они добавлены. В JSON это объект; поля у разных
+// запросов разные, поэтому в документации API они не перечисляются.
+type SongView struct {
+	fields []songViewField
+}
+
+type songViewField struct {
+	name  string
+	value any
+}
+
+// Add добавляет поле в конец песни.
+func (v *SongView) Add(name string, value any) {
+	v.fields = append(v.fields, songViewField{name: name, value: value})
+}
+
+func (v SongView) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range v.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,29 @@
+// synced_lyrics.go
+package models
+
+import "github.com/google/uuid"
+
+// SyncedLine - строка текста с временной меткой (LRC).
+// EndMs - начало следующей строки; у последней строки конец известен,
+// только если в LRC указан [length:].
+type SyncedLine struct {
+	SongID  uuid.UUID `json:"-" gorm:"type:uuid;primaryKey"`
+	LineNo  int       `json:"line" gorm:"primaryKey;autoIncrement:false" example:"1"`
+	StartMs int64     `json:"startMs" gorm:"not null" example:"12500"`
+	EndMs   *int64    `json:"endMs,omitempty" example:"15800"`
+	Text    string    `json:"text" gorm:"not null" example:"Ooh baby, don't you know I suffer?"`
+	// Verse - позиция части текста из /lyrics, к которой относится строка; 0 - строка-пауза
+	Verse int          `json:"verse" gorm:"not null" example:"1"`
+	Words []SyncedWord `json:"words,omitempty" gorm:"serializer:json"`
+}
+
+// SyncedWord - слово с временной меткой из расширенного LRC.
+type SyncedWord struct {
+	StartMs int64  `json:"startMs" example:"12500"`
+	Text    string `json:"text" example:"Ooh"`
+}
+
+type SyncedLinesResponse struct {
+	Lines []SyncedLine `json:"lines"`
+	Total int          `json:"total"`
+}
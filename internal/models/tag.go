@@ -0,0 +1,59 @@
+// tag.go
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxTagLength - максимальная длина тега в символах.
+const MaxTagLength = 64
+
+// TagMatch определяет, как фильтр по нескольким тегам сочетает их.
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any"
+	TagMatchAll TagMatch = "all"
+)
+
+func (m TagMatch) Valid() bool {
+	switch m {
+	case TagMatchAny, TagMatchAll:
+		return true
+	}
+	return false
+}
+
+// Tag - произвольная метка песни. Теги создаются при первом назначении.
+type Tag struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primaryKey" example:"9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d"`
+	Name           string    `json:"name" gorm:"not null" example:"для бега"`
+	NormalizedName string    `json:"-" gorm:"not null;uniqueIndex"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type AddSongTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,min=1" example:"для бега,лето"`
+}
+
+// FacetCount - число песен с тегом или жанром среди песен, подходящих под фильтр.
+type FacetCount struct {
+	ID    uuid.UUID `json:"id" example:"9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d"`
+	Name  string    `json:"name" example:"для бега"`
+	Count int64     `json:"count" example:"12"`
+}
+
+type SongFacetsResponse struct {
+	Total  int64        `json:"total" example:"42"`
+	Tags   []FacetCount `json:"tags"`
+	Genres []FacetCount `json:"genres"`
+}
+
+// NormalizeTagName приводит тег к виду, по которому теги сравниваются:
+// "Для бега", "для  бега" и "ДЛЯ БЕГА" - один тег.
+func NormalizeTagName(name string) string {
+	return strings.ToLower(CleanArtistName(name))
+}
@@ -0,0 +1,66 @@
+// translation.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Translation - перевод текста песни на один язык.
+// Language - тег BCP-47 в каноническом виде (en, pt-BR, zh-Hant).
+type Translation struct {
+	SongID   uuid.UUID `json:"-" gorm:"type:uuid;primaryKey"`
+	Language string    `json:"language" gorm:"primaryKey" example:"en"`
+	Text     string    `json:"text" gorm:"not null" example:"Ooh baby, don't you know I suffer?"`
+	// Author и Source - кто перевёл и откуда взят перевод
+	Author string `json:"author,omitempty" gorm:"not null" example:"Jane Doe"`
+	Source string `json:"source,omitempty" gorm:"not null" example:"https://example.com/translations/42"`
+	// Verses - переводы частей текста, привязанные к позициям частей оригинала из /lyrics
+	Verses    []TranslatedVerse `json:"verses" gorm:"serializer:json"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// TranslatedVerse - перевод одной части текста.
+type TranslatedVerse struct {
+	Position int    `json:"position" example:"1"`
+	Text     string `json:"text" example:"Ooh baby, don't you know I suffer?"`
+}
+
+// SaveTranslationRequest задаёт перевод либо целым текстом, который выравнивается
+// по частям оригинала автоматически, либо явным списком частей с позициями.
+type SaveTranslationRequest struct {
+	Text   string            `json:"text" example:"Ooh baby, don't you know I suffer?"`
+	Author string            `json:"author" example:"Jane Doe"`
+	Source string            `json:"source" example:"https://example.com/translations/42"`
+	Verses []TranslatedVerse `json:"verses"`
+}
+
+// LyricsView определяет, как перевод показывается в ответе /lyrics.
+type LyricsView string
+
+const (
+	// LyricsTranslated заменяет текст частей переводом
+	LyricsTranslated LyricsView = "translated"
+	// LyricsSideBySide оставляет оригинал и добавляет пары оригинал-перевод
+	LyricsSideBySide LyricsView = "side-by-side"
+)
+
+func (v LyricsView) Valid() bool {
+	return v == LyricsTranslated || v == LyricsSideBySide
+}
+
+// LyricsPair - часть оригинала и её перевод для показа рядом.
+type LyricsPair struct {
+	Position    int    `json:"position" example:"1"`
+	Original    string `json:"original" example:"Ooh baby, don't you know I suffer?"`
+	Translation string `json:"translation" example:"О, детка, разве ты не знаешь, что я страдаю?"`
+}
+
+// TranslationInfo - сведения о переводе, использованном в ответе /lyrics.
+type TranslationInfo struct {
+	Language string `json:"language" example:"en"`
+	Author   string `json:"author,omitempty" example:"Jane Doe"`
+	Source   string `json:"source,omitempty" example:"https://example.com/translations/42"`
+}
@@ -0,0 +1,19 @@
+// trash.go
+package models
+
+import "time"
+
+// TrashedSong - песня в корзине. PurgeAt - когда песня будет удалена окончательно;
+// не указывается, если автоматическая очистка выключена.
+type TrashedSong struct {
+	Song
+	TrashedAt time.Time  `json:"deletedAt" example:"2024-03-01T12:00:00Z"`
+	PurgeAt   *time.Time `json:"purgeAt,omitempty" example:"2024-03-31T12:00:00Z"`
+}
+
+type TrashResponse struct {
+	Songs []TrashedSong `json:"songs"`
+	Page  int           `json:"page"`
+	Limit int           `json:"limit"`
+	Total int64         `json:"total"`
+}
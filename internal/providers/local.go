@@ -0,0 +1,94 @@
+// local.go
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"song_library/internal/models"
+)
+
+// localEntry - песня в файле локального источника: поля как у ответа внешнего API
+// плюс исполнитель и название, по которым песня ищется.
+type localEntry struct {
+	GroupName   string `json:"group"`
+	SongTitle   string `json:"song"`
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+	Album       string `json:"album"`
+	AlbumType   string `json:"albumType"`
+	DiscNumber  int    `json:"discNumber"`
+	TrackNumber int    `json:"trackNumber"`
+}
+
+// LocalProvider отвечает из JSON-файла с массивом песен. Файл читается один раз
+// при создании; годится для работы без сети и для тестов.
+type LocalProvider struct {
+	name  string
+	songs map[string]*Metadata
+}
+
+func NewLocalProvider(name, path string) (*LocalProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("не удалось разобрать %s: %w", path, err)
+	}
+
+	songs := make(map[string]*Metadata, len(raw))
+	for i, element := range raw {
+		var entry localEntry
+		if err := json.Unmarshal(element, &entry); err != nil {
+			return nil, fmt.Errorf("не удалось разобрать песню %d в %s: %w", i+1, path, err)
+		}
+		metadata := &Metadata{
+			Text:        entry.Text,
+			Link:        entry.Link,
+			Album:       entry.Album,
+			AlbumType:   entry.AlbumType,
+			DiscNumber:  entry.DiscNumber,
+			TrackNumber: entry.TrackNumber,
+			Payload:     string(element),
+		}
+		if entry.ReleaseDate != "" {
+			releaseDate, ok := models.ParseReleaseDate(entry.ReleaseDate)
+			if !ok {
+				return nil, fmt.Errorf("%s: некорректная дата выпуска у песни %d: %s", path, i+1, entry.ReleaseDate)
+			}
+			metadata.ReleaseDate = releaseDate
+		}
+		songs[localKey(entry.GroupName, entry.SongTitle)] = metadata
+	}
+
+	return &LocalProvider{
+		name:  name,
+		songs: songs,
+	}, nil
+}
+
+// localKey сравнивает исполнителя и название без учёта регистра и лишних пробелов.
+func localKey(group, song string) string {
+	return models.NormalizeArtistName(group) + "\x00" + models.NormalizeArtistName(song)
+}
+
+func (p *LocalProvider) Name() string {
+	return p.name
+}
+
+func (p *LocalProvider) Lookup(ctx context.Context, group, song string) (*Metadata, error) {
+	metadata, ok := p.songs[localKey(group, song)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	found := *metadata
+	found.FetchedAt = time.Now()
+	return &found, nil
+}
@@ -0,0 +1,56 @@
+// music_api.go
+package providers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"song_library/internal/models"
+	"song_library/pkg/external_api"
+)
+
+// MusicAPIProvider - источник поверх внешнего музыкального API.
+type MusicAPIProvider struct {
+	name   string
+	client *external_api.MusicAPIClient
+}
+
+func NewMusicAPIProvider(name string, client *external_api.MusicAPIClient) *MusicAPIProvider {
+	return &MusicAPIProvider{
+		name:   name,
+		client: client,
+	}
+}
+
+func (p *MusicAPIProvider) Name() string {
+	return p.name
+}
+
+func (p *MusicAPIProvider) Lookup(ctx context.Context, group, song string) (*Metadata, error) {
+	songDetail, err := p.client.GetSongInfo(ctx, group, song)
+	switch {
+	case errors.Is(err, external_api.ErrSongNotFound):
+		return nil, ErrNotFound
+	case errors.Is(err, external_api.ErrCircuitOpen):
+		return nil, ErrUnavailable
+	case err != nil:
+		return nil, err
+	}
+
+	metadata := &Metadata{
+		Text:        songDetail.Text,
+		Link:        songDetail.Link,
+		Album:       songDetail.Album,
+		AlbumType:   songDetail.AlbumType,
+		DiscNumber:  songDetail.DiscNumber,
+		TrackNumber: songDetail.TrackNumber,
+		FetchedAt:   time.Now(),
+		Payload:     string(songDetail.Raw),
+	}
+	// Непонятная дата не мешает остальным полям: она останется пустой
+	if releaseDate, ok := models.ParseReleaseDate(songDetail.ReleaseDate); ok {
+		metadata.ReleaseDate = releaseDate
+	}
+	return metadata, nil
+}
@@ -0,0 +1,102 @@
+// provider.go
+package providers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"song_library/internal/models"
+)
+
+var (
+	// ErrNotFound - источник не знает такой песни
+	ErrNotFound = errors.New("источник не знает такой песни")
+	// ErrUnavailable - источник временно отклоняет запросы, не обращаясь к сервису
+	ErrUnavailable = errors.New("источник временно недоступен")
+)
+
+// Field - поле песни, которое может заполнить источник метаданных.
+type Field string
+
+const (
+	FieldReleaseDate Field = models.SongFieldReleaseDate
+	// FieldAlbum - альбом вместе с типом релиза и позицией песни в нём
+	FieldAlbum Field = models.SongFieldAlbum
+	FieldText  Field = models.SongFieldText
+	FieldLink  Field = models.SongFieldLink
+)
+
+// Fields перечисляет поля в порядке, в котором они заполняются.
+var Fields = []Field{FieldReleaseDate, FieldText, FieldLink, FieldAlbum}
+
+func (f Field) Valid() bool {
+	for _, field := range Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Metadata - данные песни из источника. Пустое поле значит, что источник его не знает.
+type Metadata struct {
+	ReleaseDate models.ReleaseDate
+	Text        string
+	Link        string
+	Album       string
+	AlbumType   string
+	DiscNumber  int
+	TrackNumber int
+	// FetchedAt и Payload - когда получен ответ источника и сам ответ как есть
+	FetchedAt time.Time
+	Payload   string
+	// Sources - какой источник заполнил каждое поле; задаёт Registry
+	Sources map[Field]Source
+}
+
+// Source - происхождение поля в собранной Registry записи.
+type Source struct {
+	Provider  string
+	FetchedAt time.Time
+	Payload   string
+}
+
+// has сообщает, заполнено ли поле.
+func (m *Metadata) has(field Field) bool {
+	switch field {
+	case FieldReleaseDate:
+		return !m.ReleaseDate.IsZero()
+	case FieldText:
+		return m.Text != ""
+	case FieldLink:
+		return m.Link != ""
+	case FieldAlbum:
+		return m.Album != ""
+	}
+	return false
+}
+
+// copyField переносит поле из другого ответа.
+func (m *Metadata) copyField(field Field, from *Metadata) {
+	switch field {
+	case FieldReleaseDate:
+		m.ReleaseDate = from.ReleaseDate
+	case FieldText:
+		m.Text = from.Text
+	case FieldLink:
+		m.Link = from.Link
+	case FieldAlbum:
+		m.Album = from.Album
+		m.AlbumType = from.AlbumType
+		m.DiscNumber = from.DiscNumber
+		m.TrackNumber = from.TrackNumber
+	}
+}
+
+// Provider - источник метаданных песен. Песню, которой источник не знает,
+// он отмечает ErrNotFound; остальные ошибки считаются временными.
+type Provider interface {
+	Name() string
+	Lookup(ctx context.Context, group, song string) (*Metadata, error)
+}
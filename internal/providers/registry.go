@@ -0,0 +1,171 @@
+// registry.go
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Registry опрашивает источники по приоритету и собирает из их ответов одну
+// запись: каждое поле берётся у первого источника, который его знает. Для
+// отдельного поля порядок источников можно задать свой - например, дату выпуска
+// брать у одного источника, а текст у другого.
+type Registry struct {
+	providers  []Provider
+	fieldOrder map[Field][]Provider
+}
+
+// NewRegistry собирает реестр. providers перечислены по убыванию приоритета;
+// fieldOrder задаёт для поля свой порядок источников по именам, а источники,
+// которых в нём нет, для этого поля не опрашиваются. Реестр без источников
+// допустим: песни тогда не обогащаются, а Lookup отвечает ErrNotFound.
+func NewRegistry(providers []Provider, fieldOrder map[Field][]string) (*Registry, error) {
+	byName := make(map[string]Provider, len(providers))
+	for _, provider := range providers {
+		if _, ok := byName[provider.Name()]; ok {
+			return nil, fmt.Errorf("источник %s указан дважды", provider.Name())
+		}
+		byName[provider.Name()] = provider
+	}
+
+	registry := &Registry{
+		providers:  providers,
+		fieldOrder: make(map[Field][]Provider, len(Fields)),
+	}
+	for _, field := range Fields {
+		registry.fieldOrder[field] = providers
+	}
+	for field, names := range fieldOrder {
+		if !field.Valid() {
+			return nil, fmt.Errorf("неизвестное поле %s; доступны releaseDate, text, link, album", field)
+		}
+		order := make([]Provider, 0, len(names))
+		for _, name := range names {
+			provider, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("для поля %s указан неизвестный источник %s", field, name)
+			}
+			order = append(order, provider)
+		}
+		registry.fieldOrder[field] = order
+	}
+
+	return registry, nil
+}
+
+// Enabled сообщает, настроен ли хотя бы один источник.
+func (r *Registry) Enabled() bool {
+	return len(r.providers) > 0
+}
+
+// Lookup собирает данные песни из источников. Источник опрашивается, только когда
+// до него доходит очередь хотя бы по одному полю, и не больше одного раза.
+// ErrNotFound возвращается, если песни не знает ни один источник. Если кто-то из
+// источников не ответил, а поля без него заполнить не удалось, возвращается его
+// ошибка, чтобы запрос можно было повторить. Если же часть полей собрать удалось,
+// Lookup возвращает их вместе с *PartialError: остальные поля стоит запросить позже.
+func (r *Registry) Lookup(ctx context.Context, group, song string) (*Metadata, error) {
+	results := map[string]*Metadata{}
+	failed := map[string]error{}
+	var failures []error
+	fetch := func(provider Provider) *Metadata {
+		if metadata, ok := results[provider.Name()]; ok {
+			return metadata
+		}
+		metadata, err := provider.Lookup(ctx, group, song)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			failed[provider.Name()] = &LookupError{Provider: provider.Name(), Err: err}
+			failures = append(failures, failed[provider.Name()])
+		}
+		results[provider.Name()] = metadata
+		return metadata
+	}
+
+	merged := &Metadata{Sources: map[Field]Source{}}
+	var incomplete []Field
+	for _, field := range Fields {
+		skipped := false
+		for _, provider := range r.fieldOrder[field] {
+			metadata := fetch(provider)
+			if metadata != nil && metadata.has(field) {
+				merged.copyField(field, metadata)
+				merged.Sources[field] = Source{
+					Provider:  provider.Name(),
+					FetchedAt: metadata.FetchedAt,
+					Payload:   metadata.Payload,
+				}
+				break
+			}
+			if failed[provider.Name()] != nil {
+				skipped = true
+			}
+		}
+		// Поле взято у источника с меньшим приоритетом или не заполнено из-за сбоя
+		if skipped {
+			incomplete = append(incomplete, field)
+		}
+	}
+
+	if len(merged.Sources) > 0 {
+		if len(incomplete) > 0 {
+			return merged, &PartialError{Fields: incomplete, Failures: failures}
+		}
+		return merged, nil
+	}
+	if len(failures) == 0 {
+		return nil, ErrNotFound
+	}
+	// ErrUnavailable возвращается, только если ни один источник не пытался ответить
+	for _, err := range failures {
+		if !errors.Is(err, ErrUnavailable) {
+			return nil, err
+		}
+	}
+	return nil, failures[0]
+}
+
+// PartialError - Lookup собрал не все данные: поля Fields должны были дать
+// источники, которые не ответили. Failures - их ошибки (*LookupError).
+type PartialError struct {
+	Fields   []Field
+	Failures []error
+}
+
+func (e *PartialError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, err := range e.Failures {
+		messages[i] = err.Error()
+	}
+	return "собраны не все данные: " + strings.Join(messages, "; ")
+}
+
+func (e *PartialError) Unwrap() []error {
+	return e.Failures
+}
+
+// Unavailable сообщает, что все не ответившие источники отклонили запрос,
+// не обращаясь к сервису (ErrUnavailable).
+func (e *PartialError) Unavailable() bool {
+	for _, err := range e.Failures {
+		if !errors.Is(err, ErrUnavailable) {
+			return false
+		}
+	}
+	return true
+}
+
+// LookupError - источник не ответил.
+type LookupError struct {
+	Provider string
+	Err      error
+}
+
+func (e *LookupError) Error() string {
+	return e.Provider + ": " + e.Err.Error()
+}
+
+func (e *LookupError) Unwrap() error {
+	return e.Err
+}
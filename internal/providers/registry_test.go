@@ -0,0 +1,203 @@
+// registry_test.go
+package providers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakeProvider отвечает заранее заданными данными и считает обращения.
+type fakeProvider struct {
+	name     string
+	metadata *Metadata
+	err      error
+	calls    int
+}
+
+func (p *fakeProvider) Name() string {
+	return p.name
+}
+
+func (p *fakeProvider) Lookup(ctx context.Context, group, song string) (*Metadata, error) {
+	p.calls++
+	return p.metadata, p.err
+}
+
+func TestRegistryLookup(t *testing.T) {
+	errDown := errors.New("connection refused")
+
+	tests := []struct {
+		name       string
+		providers  func() []*fakeProvider
+		fieldOrder map[Field][]string
+		// want - какой источник заполнил каждое поле
+		want        map[Field]string
+		wantErr     error
+		wantPartial []Field
+	}{
+		{
+			name: "поля берутся у первого источника, который их знает",
+			providers: func() []*fakeProvider {
+				return []*fakeProvider{
+					{name: "a", metadata: &Metadata{Text: "text a"}},
+					{name: "b", metadata: &Metadata{Text: "text b", Link: "link b"}},
+				}
+			},
+			want: map[Field]string{FieldText: "a", FieldLink: "b"},
+		},
+		{
+			name: "порядок источников для поля",
+			providers: func() []*fakeProvider {
+				return []*fakeProvider{
+					{name: "a", metadata: &Metadata{Text: "text a", Link: "link a"}},
+					{name: "b", metadata: &Metadata{Text: "text b"}},
+				}
+			},
+			fieldOrder: map[Field][]string{FieldText: {"b", "a"}},
+			want:       map[Field]string{FieldText: "b", FieldLink: "a"},
+		},
+		{
+			name: "никто не знает песню",
+			providers: func() []*fakeProvider {
+				return []*fakeProvider{
+					{name: "a", err: ErrNotFound},
+					{name: "b", err: ErrNotFound},
+				}
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name:      "без источников",
+			providers: func() []*fakeProvider { return nil },
+			wantErr:   ErrNotFound,
+		},
+		{
+			name: "единственный ответ - сбой",
+			providers: func() []*fakeProvider {
+				return []*fakeProvider{
+					{name: "a", err: errDown},
+					{name: "b", err: ErrNotFound},
+				}
+			},
+			wantErr: errDown,
+		},
+		{
+			name: "все недоступны",
+			providers: func() []*fakeProvider {
+				return []*fakeProvider{
+					{name: "a", err: ErrUnavailable},
+				}
+			},
+			wantErr: ErrUnavailable,
+		},
+		{
+			name: "сбой источника с большим приоритетом - частичный результат",
+			providers: func() []*fakeProvider {
+				return []*fakeProvider{
+					{name: "a", err: errDown},
+					{name: "b", metadata: &Metadata{Text: "text b"}},
+				}
+			},
+			want:        map[Field]string{FieldText: "b"},
+			wantErr:     errDown,
+			wantPartial: []Field{FieldReleaseDate, FieldText, FieldLink, FieldAlbum},
+		},
+		{
+			name: "сбой источника, который не понадобился",
+			providers: func() []*fakeProvider {
+				return []*fakeProvider{
+					{name: "a", metadata: &Metadata{Text: "text a"}},
+					{name: "b", err: errDown},
+				}
+			},
+			fieldOrder: map[Field][]string{
+				FieldReleaseDate: {"a"}, FieldLink: {"a"}, FieldAlbum: {"a"},
+			},
+			want: map[Field]string{FieldText: "a"},
+		},
+		{
+			name: "сбой задевает только поля, в порядке которых стоит",
+			providers: func() []*fakeProvider {
+				return []*fakeProvider{
+					{name: "a", metadata: &Metadata{Text: "text a", Link: "link a"}},
+					{name: "b", err: errDown},
+				}
+			},
+			fieldOrder: map[Field][]string{
+				FieldReleaseDate: {"a"}, FieldText: {"a"}, FieldLink: {"b", "a"}, FieldAlbum: {"a"},
+			},
+			want:        map[Field]string{FieldText: "a", FieldLink: "a"},
+			wantErr:     errDown,
+			wantPartial: []Field{FieldLink},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakes := tt.providers()
+			providers := make([]Provider, len(fakes))
+			for i, fake := range fakes {
+				providers[i] = fake
+			}
+			registry, err := NewRegistry(providers, tt.fieldOrder)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			metadata, err := registry.Lookup(context.Background(), "group", "song")
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("ошибка %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ошибка %v, ожидалась %v", err, tt.wantErr)
+			}
+
+			var partial *PartialError
+			if errors.As(err, &partial) != (tt.wantPartial != nil) {
+				t.Fatalf("частичный результат: %v, ожидался: %v", partial != nil, tt.wantPartial != nil)
+			}
+			if partial != nil && !reflect.DeepEqual(partial.Fields, tt.wantPartial) {
+				t.Errorf("неполные поля %v, ожидались %v", partial.Fields, tt.wantPartial)
+			}
+
+			if tt.want == nil {
+				if metadata != nil {
+					t.Errorf("данные %+v, ожидалось nil", metadata)
+				}
+			} else {
+				got := map[Field]string{}
+				for field, source := range metadata.Sources {
+					got[field] = source.Provider
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("источники полей %v, ожидались %v", got, tt.want)
+				}
+			}
+
+			for _, fake := range fakes {
+				if fake.calls > 1 {
+					t.Errorf("источник %s опрошен %d раз", fake.name, fake.calls)
+				}
+			}
+		})
+	}
+}
+
+func TestPartialErrorUnavailable(t *testing.T) {
+	tests := []struct {
+		failures []error
+		want     bool
+	}{
+		{failures: []error{&LookupError{Provider: "a", Err: ErrUnavailable}}, want: true},
+		{failures: []error{&LookupError{Provider: "a", Err: ErrUnavailable}, &LookupError{Provider: "b", Err: errors.New("timeout")}}, want: false},
+	}
+
+	for _, tt := range tests {
+		partial := &PartialError{Failures: tt.failures}
+		if got := partial.Unavailable(); got != tt.want {
+			t.Errorf("Unavailable() для %v = %v, ожидалось %v", partial, got, tt.want)
+		}
+	}
+}
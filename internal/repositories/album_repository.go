@@ -0,0 +1,225 @@
+// album_repository.go
+package repositories
+
+import (
+	"errors"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AlbumRepository - реализация AlbumStore поверх GORM.
+type AlbumRepository struct {
+	db *gorm.DB
+}
+
+func NewAlbumRepository(db *gorm.DB) *AlbumRepository {
+	return &AlbumRepository{
+		db: db,
+	}
+}
+
+func (r *AlbumRepository) Create(album *models.Album) error {
+	return conflictError(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(album).Error; err != nil {
+			return err
+		}
+		return insertTracks(tx, album.ID, album.Tracks)
+	}))
+}
+
+func (r *AlbumRepository) GetByID(id uuid.UUID) (*models.Album, error) {
+	var album models.Album
+	query := r.db.
+		Preload("Artist").
+		Preload("Tracks", func(db *gorm.DB) *gorm.DB {
+			// Треки песен из корзины скрываются, но остаются в альбоме до окончательного удаления
+			return db.
+				Where("EXISTS (SELECT 1 FROM songs WHERE songs.id = album_tracks.song_id AND songs.deleted_at IS NULL)").
+				Order("disc_number, track_number")
+		})
+	result := preloadSong(query, "Tracks.Song.").First(&album, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, result.Error
+	}
+	return &album, nil
+}
+
+func (r *AlbumRepository) FindByTitle(artistID uuid.UUID, normalizedTitle string) (*models.Album, error) {
+	var album models.Album
+	result := r.db.
+		Preload("Artist").
+		Where("artist_id = ? AND normalized_title = ?", artistID, normalizedTitle).
+		Order("created_at").
+		First(&album)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, result.Error
+	}
+	return &album, nil
+}
+
+func (r *AlbumRepository) Update(album *models.Album) error {
+	return conflictError(r.db.Omit(clause.Associations).Save(album).Error)
+}
+
+func (r *AlbumRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.Album{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *AlbumRepository) GetAll(filter models.AlbumFilter, offset, limit int) ([]models.Album, int64, error) {
+	var albums []models.Album
+	var total int64
+
+	query := r.db.Model(&models.Album{})
+
+	if filter.Title != "" {
+		query = query.Where("albums.normalized_title LIKE ?", "%"+models.NormalizeAlbumTitle(filter.Title)+"%")
+	}
+	if filter.Artist != "" {
+		query = query.
+			Joins("JOIN artists ON artists.id = albums.artist_id").
+			Where("artists.normalized_name LIKE ?", "%"+models.NormalizeArtistName(filter.Artist)+"%")
+	}
+	if filter.Type != "" {
+		query = query.Where("albums.type = ?", filter.Type)
+	}
+	if filter.ArtistID != uuid.Nil {
+		query = query.Where("albums.artist_id = ?", filter.ArtistID)
+	}
+	if filter.SongID != uuid.Nil {
+		query = query.
+			Where("EXISTS (SELECT 1 FROM album_tracks WHERE album_tracks.album_id = albums.id AND album_tracks.song_id = ?)", filter.SongID).
+			Preload("Tracks", "song_id = ?", filter.SongID)
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.
+		Preload("Artist").
+		Order("albums.release_date").
+		Order("albums.title").
+		Order("albums.id").
+		Offset(offset).
+		Limit(limit).
+		Find(&albums).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return albums, total, nil
+}
+
+func (r *AlbumRepository) GetBySongs(songIDs []uuid.UUID) (map[uuid.UUID][]models.Album, error) {
+	var tracks []models.AlbumTrack
+	if err := r.db.Where("song_id IN ?", songIDs).Find(&tracks).Error; err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return map[uuid.UUID][]models.Album{}, nil
+	}
+
+	tracksByAlbum := make(map[uuid.UUID][]models.AlbumTrack)
+	albumIDs := make([]uuid.UUID, 0, len(tracks))
+	for _, track := range tracks {
+		if _, ok := tracksByAlbum[track.AlbumID]; !ok {
+			albumIDs = append(albumIDs, track.AlbumID)
+		}
+		tracksByAlbum[track.AlbumID] = append(tracksByAlbum[track.AlbumID], track)
+	}
+
+	var albums []models.Album
+	err := r.db.
+		Preload("Artist").
+		Where("albums.id IN ?", albumIDs).
+		Order("albums.release_date").
+		Order("albums.title").
+		Order("albums.id").
+		Find(&albums).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return albumsBySong(albums, tracksByAlbum), nil
+}
+
+// albumsBySong раскладывает упорядоченные альбомы по песням их треков.
+func albumsBySong(albums []models.Album, tracksByAlbum map[uuid.UUID][]models.AlbumTrack) map[uuid.UUID][]models.Album {
+	bySong := make(map[uuid.UUID][]models.Album)
+	for _, album := range albums {
+		for _, track := range tracksByAlbum[album.ID] {
+			songAlbum := album
+			songAlbum.Tracks = []models.AlbumTrack{track}
+			bySong[track.SongID] = append(bySong[track.SongID], songAlbum)
+		}
+	}
+	return bySong
+}
+
+func (r *AlbumRepository) SetTracks(albumID uuid.UUID, tracks []models.AlbumTrack) error {
+	return conflictError(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("album_id = ?", albumID).Delete(&models.AlbumTrack{}).Error; err != nil {
+			return err
+		}
+		return insertTracks(tx, albumID, tracks)
+	}))
+}
+
+func (r *AlbumRepository) AddTrack(track *models.AlbumTrack) error {
+	return conflictError(r.db.Transaction(func(tx *gorm.DB) error {
+		if track.DiscNumber == 0 {
+			track.DiscNumber = 1
+		}
+		if track.TrackNumber == 0 {
+			var last *int
+			err := tx.Model(&models.AlbumTrack{}).
+				Where("album_id = ? AND disc_number = ?", track.AlbumID, track.DiscNumber).
+				Select("MAX(track_number)").
+				Scan(&last).Error
+			if err != nil {
+				return err
+			}
+			track.TrackNumber = 1
+			if last != nil {
+				track.TrackNumber = *last + 1
+			}
+		}
+		return tx.Omit(clause.Associations).Create(track).Error
+	}))
+}
+
+func insertTracks(tx *gorm.DB, albumID uuid.UUID, tracks []models.AlbumTrack) error {
+	if len(tracks) == 0 {
+		return nil
+	}
+	for i := range tracks {
+		tracks[i].AlbumID = albumID
+	}
+	return tx.Omit(clause.Associations).Create(&tracks).Error
+}
+
+// conflictError заменяет нарушение уникальности в базе на ErrConflict.
+func conflictError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrConflict
+	}
+	return err
+}
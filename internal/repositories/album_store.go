@@ -0,0 +1,25 @@
+// album_store.go
+package repositories
+
+import (
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// AlbumStore описывает хранилище альбомов и их трек-листов.
+type AlbumStore interface {
+	Create(album *models.Album) error
+	GetByID(id uuid.UUID) (*models.Album, error)
+	FindByTitle(artistID uuid.UUID, normalizedTitle string) (*models.Album, error)
+	Update(album *models.Album) error
+	Delete(id uuid.UUID) error
+	GetAll(filter models.AlbumFilter, offset, limit int) ([]models.Album, int64, error)
+	// GetBySongs возвращает альбомы каждой из песен в порядке GetAll;
+	// в Tracks у альбома только трек этой песни.
+	GetBySongs(songIDs []uuid.UUID) (map[uuid.UUID][]models.Album, error)
+	// SetTracks полностью заменяет трек-лист альбома.
+	SetTracks(albumID uuid.UUID, tracks []models.AlbumTrack) error
+	// AddTrack добавляет песню в альбом; при нулевом номере трека песня ставится в конец диска.
+	AddTrack(track *models.AlbumTrack) error
+}
@@ -0,0 +1,89 @@
+// artist_repository.go
+package repositories
+
+import (
+	"errors"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ArtistRepository - реализация ArtistStore поверх GORM.
+type ArtistRepository struct {
+	db *gorm.DB
+}
+
+func NewArtistRepository(db *gorm.DB) *ArtistRepository {
+	return &ArtistRepository{
+		db: db,
+	}
+}
+
+// Create вставляет исполнителя в точке сохранения: в транзакции PostgreSQL нарушение
+// уникальности иначе прервало бы всю транзакцию, и исполнителя, созданного параллельно,
+// уже нельзя было бы прочитать.
+func (r *ArtistRepository) Create(artist *models.Artist) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return conflictError(tx.Create(artist).Error)
+	})
+}
+
+func (r *ArtistRepository) GetByID(id uuid.UUID) (*models.Artist, error) {
+	return r.first("id = ?", id)
+}
+
+func (r *ArtistRepository) GetByNormalizedName(normalizedName string) (*models.Artist, error) {
+	return r.first("normalized_name = ?", normalizedName)
+}
+
+func (r *ArtistRepository) Update(artist *models.Artist) error {
+	return r.db.Save(artist).Error
+}
+
+func (r *ArtistRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.Artist{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *ArtistRepository) GetAll(filter models.ArtistFilter, offset, limit int) ([]models.Artist, int64, error) {
+	var artists []models.Artist
+	var total int64
+
+	query := r.db.Model(&models.Artist{})
+
+	if filter.Name != "" {
+		query = query.Where("normalized_name LIKE ?", "%"+models.NormalizeArtistName(filter.Name)+"%")
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.Order("name").Order("id").Offset(offset).Limit(limit).Find(&artists).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return artists, total, nil
+}
+
+func (r *ArtistRepository) first(query string, args ...interface{}) (*models.Artist, error) {
+	var artist models.Artist
+	result := r.db.Where(query, args...).First(&artist)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, result.Error
+	}
+	return &artist, nil
+}
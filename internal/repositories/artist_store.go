@@ -0,0 +1,20 @@
+// artist_store.go
+package repositories
+
+import (
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ArtistStore описывает хранилище исполнителей.
+type ArtistStore interface {
+	// Create возвращает ErrConflict, если исполнитель с тем же нормализованным
+	// названием уже есть.
+	Create(artist *models.Artist) error
+	GetByID(id uuid.UUID) (*models.Artist, error)
+	GetByNormalizedName(normalizedName string) (*models.Artist, error)
+	Update(artist *models.Artist) error
+	Delete(id uuid.UUID) error
+	GetAll(filter models.ArtistFilter, offset, limit int) ([]models.Artist, int64, error)
+}
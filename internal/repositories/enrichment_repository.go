@@ -0,0 +1,84 @@
+// enrichment_repository.go
+package repositories
+
+import (
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EnrichmentRepository - реализация EnrichmentStore поверх GORM.
+type EnrichmentRepository struct {
+	db *gorm.DB
+}
+
+func NewEnrichmentRepository(db *gorm.DB) *EnrichmentRepository {
+	return &EnrichmentRepository{
+		db: db,
+	}
+}
+
+func (r *EnrichmentRepository) Enqueue(songID uuid.UUID, at time.Time) error {
+	job := &models.EnrichmentJob{
+		SongID:        songID,
+		NextAttemptAt: at,
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "song_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"attempts":        0,
+			"last_error":      "",
+			"next_attempt_at": at,
+			"locked_until":    nil,
+			"updated_at":      time.Now(),
+		}),
+	}).Create(job).Error
+}
+
+// Claim сначала выбирает кандидатов, а затем захватывает каждого условным UPDATE:
+// если задание успел захватить другой экземпляр, обновление не затронет строк.
+func (r *EnrichmentRepository) Claim(now time.Time, lease time.Duration, limit int) ([]models.EnrichmentJob, error) {
+	var candidates []models.EnrichmentJob
+	err := r.db.
+		Joins("JOIN songs ON songs.id = enrichment_jobs.song_id").
+		Where("songs.enrichment_status = ? AND songs.deleted_at IS NULL", models.EnrichmentPending).
+		Where("enrichment_jobs.next_attempt_at <= ?", now).
+		Where("(enrichment_jobs.locked_until IS NULL OR enrichment_jobs.locked_until < ?)", now).
+		Order("enrichment_jobs.next_attempt_at").
+		Limit(limit).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	lockedUntil := now.Add(lease)
+	claimed := candidates[:0]
+	for _, job := range candidates {
+		result := r.db.Model(&models.EnrichmentJob{}).
+			Where("song_id = ? AND (locked_until IS NULL OR locked_until < ?)", job.SongID, now).
+			Update("locked_until", lockedUntil)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			job.LockedUntil = &lockedUntil
+			claimed = append(claimed, job)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *EnrichmentRepository) Release(job *models.EnrichmentJob) error {
+	job.LockedUntil = nil
+	return r.db.Model(job).
+		Select("attempts", "last_error", "next_attempt_at", "locked_until").
+		Updates(job).Error
+}
+
+func (r *EnrichmentRepository) Delete(songID uuid.UUID) error {
+	return r.db.Delete(&models.EnrichmentJob{}, "song_id = ?", songID).Error
+}
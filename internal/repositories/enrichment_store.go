@@ -0,0 +1,25 @@
+// enrichment_store.go
+package repositories
+
+import (
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// EnrichmentStore - очередь заданий обогащения. Задания хранятся вместе с данными,
+// поэтому переживают перезапуск, а захват с ограниченным сроком позволяет
+// нескольким экземплярам сервиса разбирать одну очередь.
+type EnrichmentStore interface {
+	// Enqueue ставит песню в очередь на момент at; существующее задание
+	// начинается заново с нулём попыток.
+	Enqueue(songID uuid.UUID, at time.Time) error
+	// Claim захватывает до limit заданий песен в статусе pending, время попытки
+	// которых наступило, на срок lease.
+	Claim(now time.Time, lease time.Duration, limit int) ([]models.EnrichmentJob, error)
+	// Release сохраняет попытки, ошибку и время следующей попытки и снимает захват.
+	Release(job *models.EnrichmentJob) error
+	Delete(songID uuid.UUID) error
+}
@@ -0,0 +1,99 @@
+// genre_repository.go
+package repositories
+
+import (
+	"errors"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GenreRepository - реализация GenreStore поверх GORM.
+type GenreRepository struct {
+	db *gorm.DB
+}
+
+func NewGenreRepository(db *gorm.DB) *GenreRepository {
+	return &GenreRepository{
+		db: db,
+	}
+}
+
+func (r *GenreRepository) Create(genre *models.Genre) error {
+	return r.db.Create(genre).Error
+}
+
+func (r *GenreRepository) GetByID(id uuid.UUID) (*models.Genre, error) {
+	return r.first("id = ?", id)
+}
+
+func (r *GenreRepository) GetByNormalizedName(normalizedName string) (*models.Genre, error) {
+	return r.first("normalized_name = ?", normalizedName)
+}
+
+func (r *GenreRepository) Update(genre *models.Genre) error {
+	return r.db.Save(genre).Error
+}
+
+// Связи с песнями удаляются через ON DELETE CASCADE.
+func (r *GenreRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.Genre{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *GenreRepository) GetAll(offset, limit int) ([]models.Genre, int64, error) {
+	var genres []models.Genre
+	var total int64
+
+	query := r.db.Model(&models.Genre{})
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.Order("name").Order("id").Offset(offset).Limit(limit).Find(&genres).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return genres, total, nil
+}
+
+func (r *GenreRepository) AddToSong(songID, genreID uuid.UUID) error {
+	return r.db.Table("song_genres").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]interface{}{"song_id": songID, "genre_id": genreID}).Error
+}
+
+func (r *GenreRepository) RemoveFromSong(songID, genreID uuid.UUID) error {
+	result := r.db.Exec("DELETE FROM song_genres WHERE song_id = ? AND genre_id = ?", songID, genreID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
+
+func (r *GenreRepository) first(query string, args ...interface{}) (*models.Genre, error) {
+	var genre models.Genre
+	result := r.db.Where(query, args...).First(&genre)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, result.Error
+	}
+	return &genre, nil
+}
@@ -0,0 +1,24 @@
+// genre_store.go
+package repositories
+
+import (
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// GenreStore описывает хранилище справочника жанров и их связи с песнями.
+type GenreStore interface {
+	Create(genre *models.Genre) error
+	GetByID(id uuid.UUID) (*models.Genre, error)
+	GetByNormalizedName(normalizedName string) (*models.Genre, error)
+	Update(genre *models.Genre) error
+	// Delete удаляет жанр из справочника и снимает его со всех песен.
+	Delete(id uuid.UUID) error
+	GetAll(offset, limit int) ([]models.Genre, int64, error)
+
+	// AddToSong назначает жанр песне; повторное назначение не считается ошибкой.
+	AddToSong(songID, genreID uuid.UUID) error
+	// RemoveFromSong возвращает ErrEntryNotFound, если жанр не был назначен песне.
+	RemoveFromSong(songID, genreID uuid.UUID) error
+}
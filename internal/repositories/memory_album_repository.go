@@ -0,0 +1,292 @@
+// memory_album_repository.go
+package repositories
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryAlbumRepository - реализация AlbumStore в памяти процесса.
+type MemoryAlbumRepository struct {
+	storage *MemoryStorage
+}
+
+func NewMemoryAlbumRepository(storage *MemoryStorage) *MemoryAlbumRepository {
+	return &MemoryAlbumRepository{
+		storage: storage,
+	}
+}
+
+func (r *MemoryAlbumRepository) Create(album *models.Album) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	now := time.Now()
+	if album.CreatedAt.IsZero() {
+		album.CreatedAt = now
+	}
+	if album.UpdatedAt.IsZero() {
+		album.UpdatedAt = now
+	}
+	if r.titleTaken(*album) {
+		return ErrConflict
+	}
+	for i, track := range album.Tracks {
+		if trackConflicts(album.Tracks[:i], track) {
+			return ErrConflict
+		}
+	}
+
+	r.storage.albums[album.ID] = storedAlbum(*album)
+	r.storage.tracks[album.ID] = storedTracks(album.ID, album.Tracks)
+	return nil
+}
+
+func (r *MemoryAlbumRepository) GetByID(id uuid.UUID) (*models.Album, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	album, ok := r.storage.albums[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	album = r.loaded(album)
+	album.Tracks = make([]models.AlbumTrack, 0, len(r.storage.tracks[id]))
+	for _, track := range r.storage.tracks[id] {
+		song, ok := r.storage.liveSong(track.SongID)
+		if !ok {
+			continue
+		}
+		song = r.storage.loadedSong(song)
+		track.Song = &song
+		album.Tracks = append(album.Tracks, track)
+	}
+	sortTracks(album.Tracks)
+
+	return &album, nil
+}
+
+func (r *MemoryAlbumRepository) FindByTitle(artistID uuid.UUID, normalizedTitle string) (*models.Album, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	var found *models.Album
+	for _, album := range r.storage.albums {
+		if album.ArtistID != artistID || album.NormalizedTitle != normalizedTitle {
+			continue
+		}
+		if found == nil || album.CreatedAt.Before(found.CreatedAt) {
+			loaded := r.loaded(album)
+			found = &loaded
+		}
+	}
+	if found == nil {
+		return nil, ErrNotFound
+	}
+	return found, nil
+}
+
+func (r *MemoryAlbumRepository) Update(album *models.Album) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if r.titleTaken(*album) {
+		return ErrConflict
+	}
+	album.UpdatedAt = time.Now()
+	r.storage.albums[album.ID] = storedAlbum(*album)
+	return nil
+}
+
+func (r *MemoryAlbumRepository) Delete(id uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.albums[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.storage.albums, id)
+	delete(r.storage.tracks, id)
+	return nil
+}
+
+func (r *MemoryAlbumRepository) GetAll(filter models.AlbumFilter, offset, limit int) ([]models.Album, int64, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	title := models.NormalizeAlbumTitle(filter.Title)
+	artistName := models.NormalizeArtistName(filter.Artist)
+
+	matched := make([]models.Album, 0, len(r.storage.albums))
+	for _, album := range r.storage.albums {
+		album = r.loaded(album)
+		if title != "" && !strings.Contains(album.NormalizedTitle, title) {
+			continue
+		}
+		if artistName != "" && !strings.Contains(models.NormalizeArtistName(album.ArtistName), artistName) {
+			continue
+		}
+		if filter.Type != "" && album.Type != filter.Type {
+			continue
+		}
+		if filter.ArtistID != uuid.Nil && album.ArtistID != filter.ArtistID {
+			continue
+		}
+		if filter.SongID != uuid.Nil {
+			track, ok := r.storage.trackOf(album.ID, filter.SongID)
+			if !ok {
+				continue
+			}
+			album.Tracks = []models.AlbumTrack{track}
+		}
+		matched = append(matched, album)
+	}
+
+	sortAlbums(matched)
+	return paginate(matched, offset, limit), int64(len(matched)), nil
+}
+
+func (r *MemoryAlbumRepository) GetBySongs(songIDs []uuid.UUID) (map[uuid.UUID][]models.Album, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	wanted := make(map[uuid.UUID]bool, len(songIDs))
+	for _, songID := range songIDs {
+		wanted[songID] = true
+	}
+
+	var albums []models.Album
+	tracksByAlbum := make(map[uuid.UUID][]models.AlbumTrack)
+	for id, album := range r.storage.albums {
+		for _, track := range r.storage.tracks[id] {
+			if !wanted[track.SongID] {
+				continue
+			}
+			if _, ok := tracksByAlbum[id]; !ok {
+				albums = append(albums, r.loaded(album))
+			}
+			tracksByAlbum[id] = append(tracksByAlbum[id], track)
+		}
+	}
+
+	sortAlbums(albums)
+	return albumsBySong(albums, tracksByAlbum), nil
+}
+
+// sortAlbums упорядочивает альбомы как AlbumRepository.GetAll.
+func sortAlbums(albums []models.Album) {
+	sort.Slice(albums, func(i, j int) bool {
+		a, b := albums[i], albums[j]
+		if (a.ReleaseDate == nil) != (b.ReleaseDate == nil) {
+			// Как в PostgreSQL: NULL при сортировке по возрастанию идут последними
+			return a.ReleaseDate != nil
+		}
+		if a.ReleaseDate != nil && !a.ReleaseDate.Equal(*b.ReleaseDate) {
+			return a.ReleaseDate.Before(*b.ReleaseDate)
+		}
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.ID.String() < b.ID.String()
+	})
+}
+
+func (r *MemoryAlbumRepository) SetTracks(albumID uuid.UUID, tracks []models.AlbumTrack) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	for i, track := range tracks {
+		if trackConflicts(tracks[:i], track) {
+			return ErrConflict
+		}
+	}
+	r.storage.tracks[albumID] = storedTracks(albumID, tracks)
+	return nil
+}
+
+func (r *MemoryAlbumRepository) AddTrack(track *models.AlbumTrack) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if track.DiscNumber == 0 {
+		track.DiscNumber = 1
+	}
+	if track.TrackNumber == 0 {
+		track.TrackNumber = 1
+		for _, existing := range r.storage.tracks[track.AlbumID] {
+			if existing.DiscNumber == track.DiscNumber && existing.TrackNumber >= track.TrackNumber {
+				track.TrackNumber = existing.TrackNumber + 1
+			}
+		}
+	}
+
+	if trackConflicts(r.storage.tracks[track.AlbumID], *track) {
+		return ErrConflict
+	}
+
+	r.storage.tracks[track.AlbumID] = append(r.storage.tracks[track.AlbumID], storedTracks(track.AlbumID, []models.AlbumTrack{*track})...)
+	return nil
+}
+
+// titleTaken проверяет уникальность названия альбома у исполнителя, как индекс
+// idx_albums_artist_title в базе.
+func (r *MemoryAlbumRepository) titleTaken(album models.Album) bool {
+	for _, existing := range r.storage.albums {
+		if existing.ID != album.ID && existing.ArtistID == album.ArtistID && existing.NormalizedTitle == album.NormalizedTitle {
+			return true
+		}
+	}
+	return false
+}
+
+// trackConflicts проверяет, что песни ещё нет в трек-листе и её позиция свободна,
+// как первичный ключ и ограничение уникальности album_tracks в базе.
+func trackConflicts(tracks []models.AlbumTrack, track models.AlbumTrack) bool {
+	for _, existing := range tracks {
+		if existing.SongID == track.SongID ||
+			(existing.DiscNumber == track.DiscNumber && existing.TrackNumber == track.TrackNumber) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryAlbumRepository) loaded(album models.Album) models.Album {
+	if artist, ok := r.storage.artists[album.ArtistID]; ok {
+		album.Artist = &artist
+		album.ArtistName = artist.Name
+	}
+	return album
+}
+
+func storedAlbum(album models.Album) models.Album {
+	album.Artist = nil
+	album.ArtistName = ""
+	album.Tracks = nil
+	return album
+}
+
+func storedTracks(albumID uuid.UUID, tracks []models.AlbumTrack) []models.AlbumTrack {
+	stored := make([]models.AlbumTrack, 0, len(tracks))
+	for _, track := range tracks {
+		track.AlbumID = albumID
+		track.Song = nil
+		stored = append(stored, track)
+	}
+	return stored
+}
+
+func sortTracks(tracks []models.AlbumTrack) {
+	sort.Slice(tracks, func(i, j int) bool {
+		if tracks[i].DiscNumber != tracks[j].DiscNumber {
+			return tracks[i].DiscNumber < tracks[j].DiscNumber
+		}
+		return tracks[i].TrackNumber < tracks[j].TrackNumber
+	})
+}
@@ -0,0 +1,112 @@
+// memory_artist_repository.go
+package repositories
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryArtistRepository - реализация ArtistStore в памяти процесса.
+type MemoryArtistRepository struct {
+	storage *MemoryStorage
+}
+
+func NewMemoryArtistRepository(storage *MemoryStorage) *MemoryArtistRepository {
+	return &MemoryArtistRepository{
+		storage: storage,
+	}
+}
+
+func (r *MemoryArtistRepository) Create(artist *models.Artist) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	for _, existing := range r.storage.artists {
+		if existing.ID == artist.ID || existing.NormalizedName == artist.NormalizedName {
+			return ErrConflict
+		}
+	}
+
+	now := time.Now()
+	if artist.CreatedAt.IsZero() {
+		artist.CreatedAt = now
+	}
+	if artist.UpdatedAt.IsZero() {
+		artist.UpdatedAt = now
+	}
+
+	r.storage.artists[artist.ID] = *artist
+	return nil
+}
+
+func (r *MemoryArtistRepository) GetByID(id uuid.UUID) (*models.Artist, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	artist, ok := r.storage.artists[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &artist, nil
+}
+
+func (r *MemoryArtistRepository) GetByNormalizedName(normalizedName string) (*models.Artist, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	for _, artist := range r.storage.artists {
+		if artist.NormalizedName == normalizedName {
+			return &artist, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *MemoryArtistRepository) Update(artist *models.Artist) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	artist.UpdatedAt = time.Now()
+	r.storage.artists[artist.ID] = *artist
+	return nil
+}
+
+func (r *MemoryArtistRepository) Delete(id uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.artists[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.storage.artists, id)
+	return nil
+}
+
+func (r *MemoryArtistRepository) GetAll(filter models.ArtistFilter, offset, limit int) ([]models.Artist, int64, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	name := models.NormalizeArtistName(filter.Name)
+
+	matched := make([]models.Artist, 0, len(r.storage.artists))
+	for _, artist := range r.storage.artists {
+		if name != "" && !strings.Contains(artist.NormalizedName, name) {
+			continue
+		}
+		matched = append(matched, artist)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Name == matched[j].Name {
+			return matched[i].ID.String() < matched[j].ID.String()
+		}
+		return matched[i].Name < matched[j].Name
+	})
+
+	return paginate(matched, offset, limit), int64(len(matched)), nil
+}
@@ -0,0 +1,100 @@
+// memory_enrichment_repository.go
+package repositories
+
+import (
+	"sort"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryEnrichmentRepository - реализация EnrichmentStore в памяти процесса.
+type MemoryEnrichmentRepository struct {
+	storage *MemoryStorage
+}
+
+func NewMemoryEnrichmentRepository(storage *MemoryStorage) *MemoryEnrichmentRepository {
+	return &MemoryEnrichmentRepository{
+		storage: storage,
+	}
+}
+
+func (r *MemoryEnrichmentRepository) Enqueue(songID uuid.UUID, at time.Time) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.songs[songID]; !ok {
+		return ErrNotFound
+	}
+
+	now := time.Now()
+	job, ok := r.storage.enrichmentJobs[songID]
+	if !ok {
+		job = models.EnrichmentJob{SongID: songID, CreatedAt: now}
+	}
+	job.Attempts = 0
+	job.LastError = ""
+	job.NextAttemptAt = at
+	job.LockedUntil = nil
+	job.UpdatedAt = now
+	r.storage.enrichmentJobs[songID] = job
+	return nil
+}
+
+func (r *MemoryEnrichmentRepository) Claim(now time.Time, lease time.Duration, limit int) ([]models.EnrichmentJob, error) {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	var candidates []models.EnrichmentJob
+	for songID, job := range r.storage.enrichmentJobs {
+		song, ok := r.storage.liveSong(songID)
+		if !ok || song.EnrichmentStatus != models.EnrichmentPending {
+			continue
+		}
+		if job.NextAttemptAt.After(now) || (job.LockedUntil != nil && !job.LockedUntil.Before(now)) {
+			continue
+		}
+		candidates = append(candidates, job)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].NextAttemptAt.Before(candidates[j].NextAttemptAt)
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	lockedUntil := now.Add(lease)
+	for i := range candidates {
+		candidates[i].LockedUntil = &lockedUntil
+		r.storage.enrichmentJobs[candidates[i].SongID] = candidates[i]
+	}
+	return candidates, nil
+}
+
+func (r *MemoryEnrichmentRepository) Release(job *models.EnrichmentJob) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	stored, ok := r.storage.enrichmentJobs[job.SongID]
+	if !ok {
+		return ErrNotFound
+	}
+	job.LockedUntil = nil
+	stored.Attempts = job.Attempts
+	stored.LastError = job.LastError
+	stored.NextAttemptAt = job.NextAttemptAt
+	stored.LockedUntil = nil
+	stored.UpdatedAt = time.Now()
+	r.storage.enrichmentJobs[job.SongID] = stored
+	return nil
+}
+
+func (r *MemoryEnrichmentRepository) Delete(songID uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	delete(r.storage.enrichmentJobs, songID)
+	return nil
+}
@@ -0,0 +1,133 @@
+// memory_genre_repository.go
+package repositories
+
+import (
+	"sort"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryGenreRepository - реализация GenreStore в памяти процесса.
+type MemoryGenreRepository struct {
+	storage *MemoryStorage
+}
+
+func NewMemoryGenreRepository(storage *MemoryStorage) *MemoryGenreRepository {
+	return &MemoryGenreRepository{
+		storage: storage,
+	}
+}
+
+func (r *MemoryGenreRepository) Create(genre *models.Genre) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	now := time.Now()
+	if genre.CreatedAt.IsZero() {
+		genre.CreatedAt = now
+	}
+	if genre.UpdatedAt.IsZero() {
+		genre.UpdatedAt = now
+	}
+
+	r.storage.genres[genre.ID] = *genre
+	return nil
+}
+
+func (r *MemoryGenreRepository) GetByID(id uuid.UUID) (*models.Genre, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	genre, ok := r.storage.genres[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &genre, nil
+}
+
+func (r *MemoryGenreRepository) GetByNormalizedName(normalizedName string) (*models.Genre, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	for _, genre := range r.storage.genres {
+		if genre.NormalizedName == normalizedName {
+			return &genre, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *MemoryGenreRepository) Update(genre *models.Genre) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	genre.UpdatedAt = time.Now()
+	r.storage.genres[genre.ID] = *genre
+	return nil
+}
+
+func (r *MemoryGenreRepository) Delete(id uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.genres[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.storage.genres, id)
+
+	for _, genres := range r.storage.songGenres {
+		delete(genres, id)
+	}
+	return nil
+}
+
+func (r *MemoryGenreRepository) GetAll(offset, limit int) ([]models.Genre, int64, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	genres := make([]models.Genre, 0, len(r.storage.genres))
+	for _, genre := range r.storage.genres {
+		genres = append(genres, genre)
+	}
+
+	sort.Slice(genres, func(i, j int) bool {
+		if genres[i].Name == genres[j].Name {
+			return genres[i].ID.String() < genres[j].ID.String()
+		}
+		return genres[i].Name < genres[j].Name
+	})
+
+	return paginate(genres, offset, limit), int64(len(genres)), nil
+}
+
+func (r *MemoryGenreRepository) AddToSong(songID, genreID uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.songs[songID]; !ok {
+		return ErrNotFound
+	}
+	if _, ok := r.storage.genres[genreID]; !ok {
+		return ErrNotFound
+	}
+
+	if r.storage.songGenres[songID] == nil {
+		r.storage.songGenres[songID] = make(map[uuid.UUID]struct{})
+	}
+	r.storage.songGenres[songID][genreID] = struct{}{}
+	return nil
+}
+
+func (r *MemoryGenreRepository) RemoveFromSong(songID, genreID uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.songGenres[songID][genreID]; !ok {
+		return ErrEntryNotFound
+	}
+	delete(r.storage.songGenres[songID], genreID)
+	return nil
+}
@@ -0,0 +1,239 @@
+// memory_playlist_repository.go
+package repositories
+
+import (
+	"sort"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryPlaylistRepository - реализация PlaylistStore в памяти процесса.
+type MemoryPlaylistRepository struct {
+	storage *MemoryStorage
+}
+
+func NewMemoryPlaylistRepository(storage *MemoryStorage) *MemoryPlaylistRepository {
+	return &MemoryPlaylistRepository{
+		storage: storage,
+	}
+}
+
+func (r *MemoryPlaylistRepository) Create(playlist *models.Playlist) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	now := time.Now()
+	if playlist.CreatedAt.IsZero() {
+		playlist.CreatedAt = now
+	}
+	if playlist.UpdatedAt.IsZero() {
+		playlist.UpdatedAt = now
+	}
+
+	r.storage.playlists[playlist.ID] = *playlist
+	return nil
+}
+
+func (r *MemoryPlaylistRepository) GetByID(id uuid.UUID) (*models.Playlist, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	playlist, ok := r.storage.playlists[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	playlist.EntryCount = int64(len(r.storage.entries[id]))
+	return &playlist, nil
+}
+
+func (r *MemoryPlaylistRepository) Update(playlist *models.Playlist) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	playlist.UpdatedAt = time.Now()
+	r.storage.playlists[playlist.ID] = *playlist
+	return nil
+}
+
+func (r *MemoryPlaylistRepository) Delete(id uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.playlists[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.storage.playlists, id)
+	delete(r.storage.entries, id)
+	return nil
+}
+
+func (r *MemoryPlaylistRepository) GetAll(offset, limit int) ([]models.Playlist, int64, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	playlists := make([]models.Playlist, 0, len(r.storage.playlists))
+	for id, playlist := range r.storage.playlists {
+		playlist.EntryCount = int64(len(r.storage.entries[id]))
+		playlists = append(playlists, playlist)
+	}
+
+	sort.Slice(playlists, func(i, j int) bool {
+		if playlists[i].CreatedAt.Equal(playlists[j].CreatedAt) {
+			return playlists[i].ID.String() < playlists[j].ID.String()
+		}
+		return playlists[i].CreatedAt.Before(playlists[j].CreatedAt)
+	})
+
+	return paginate(playlists, offset, limit), int64(len(playlists)), nil
+}
+
+func (r *MemoryPlaylistRepository) GetEntries(playlistID uuid.UUID, offset, limit int) ([]models.PlaylistEntry, int64, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	entries := r.loadedEntries(playlistID)
+	return paginate(entries, offset, limit), int64(len(entries)), nil
+}
+
+func (r *MemoryPlaylistRepository) GetAllEntries(playlistID uuid.UUID) ([]models.PlaylistEntry, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	return r.loadedEntries(playlistID), nil
+}
+
+func (r *MemoryPlaylistRepository) InsertEntry(entry *models.PlaylistEntry) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.playlists[entry.PlaylistID]; !ok {
+		return ErrNotFound
+	}
+
+	entries := r.storage.entries[entry.PlaylistID]
+	if entry.Position < 1 || entry.Position > len(entries)+1 {
+		entry.Position = len(entries) + 1
+	}
+
+	stored := *entry
+	stored.Song = nil
+
+	index := entry.Position - 1
+	entries = append(entries, models.PlaylistEntry{})
+	copy(entries[index+1:], entries[index:])
+	entries[index] = stored
+
+	r.storage.entries[entry.PlaylistID] = renumber(entries)
+	return nil
+}
+
+func (r *MemoryPlaylistRepository) MoveEntry(playlistID, entryID uuid.UUID, position int) (*models.PlaylistEntry, error) {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.playlists[playlistID]; !ok {
+		return nil, ErrNotFound
+	}
+
+	entries := r.storage.entries[playlistID]
+	from := indexOfEntry(entries, entryID)
+	if from < 0 {
+		return nil, ErrEntryNotFound
+	}
+
+	if position > len(entries) {
+		position = len(entries)
+	}
+	to := position - 1
+
+	entry := entries[from]
+	entries = append(entries[:from], entries[from+1:]...)
+	entries = append(entries, models.PlaylistEntry{})
+	copy(entries[to+1:], entries[to:])
+	entries[to] = entry
+
+	r.storage.entries[playlistID] = renumber(entries)
+
+	moved := entries[to]
+	return &moved, nil
+}
+
+func (r *MemoryPlaylistRepository) RemoveEntry(playlistID, entryID uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.playlists[playlistID]; !ok {
+		return ErrNotFound
+	}
+
+	entries := r.storage.entries[playlistID]
+	index := indexOfEntry(entries, entryID)
+	if index < 0 {
+		return ErrEntryNotFound
+	}
+
+	entries = append(entries[:index], entries[index+1:]...)
+	r.storage.entries[playlistID] = renumber(entries)
+	return nil
+}
+
+func (r *MemoryPlaylistRepository) MarkSongRemoved(songID uuid.UUID, removedAt time.Time) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	for _, entries := range r.storage.entries {
+		for i := range entries {
+			if entries[i].SongID == songID && entries[i].SongRemovedAt == nil {
+				at := removedAt
+				entries[i].SongRemovedAt = &at
+			}
+		}
+	}
+	return nil
+}
+
+func (r *MemoryPlaylistRepository) ClearSongRemoved(songID uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	for _, entries := range r.storage.entries {
+		for i := range entries {
+			if entries[i].SongID == songID {
+				entries[i].SongRemovedAt = nil
+			}
+		}
+	}
+	return nil
+}
+
+// loadedEntries возвращает копию записей плейлиста с подставленными песнями.
+func (r *MemoryPlaylistRepository) loadedEntries(playlistID uuid.UUID) []models.PlaylistEntry {
+	entries := make([]models.PlaylistEntry, 0, len(r.storage.entries[playlistID]))
+	for _, entry := range r.storage.entries[playlistID] {
+		if song, ok := r.storage.liveSong(entry.SongID); ok {
+			song = r.storage.loadedSong(song)
+			entry.Song = &song
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func indexOfEntry(entries []models.PlaylistEntry, entryID uuid.UUID) int {
+	for i, entry := range entries {
+		if entry.ID == entryID {
+			return i
+		}
+	}
+	return -1
+}
+
+func renumber(entries []models.PlaylistEntry) []models.PlaylistEntry {
+	for i := range entries {
+		entries[i].Position = i + 1
+	}
+	return entries
+}
@@ -0,0 +1,74 @@
+// memory_revision_repository.go
+package repositories
+
+import (
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryRevisionRepository - реализация RevisionStore в памяти процесса.
+type MemoryRevisionRepository struct {
+	storage *MemoryStorage
+}
+
+func NewMemoryRevisionRepository(storage *MemoryStorage) *MemoryRevisionRepository {
+	return &MemoryRevisionRepository{
+		storage: storage,
+	}
+}
+
+func (r *MemoryRevisionRepository) Create(revision *models.SongRevision) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.songs[revision.SongID]; !ok {
+		return ErrNotFound
+	}
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now()
+	}
+
+	revisions := r.storage.revisions[revision.SongID]
+	revision.Revision = len(revisions) + 1
+	r.storage.revisions[revision.SongID] = append(revisions, *revision)
+	return nil
+}
+
+func (r *MemoryRevisionRepository) Get(songID uuid.UUID, revision int) (*models.SongRevision, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	revisions := r.storage.revisions[songID]
+	if revision < 1 || revision > len(revisions) {
+		return nil, ErrNotFound
+	}
+	found := revisions[revision-1]
+	return &found, nil
+}
+
+func (r *MemoryRevisionRepository) Latest(songID uuid.UUID) (*models.SongRevision, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	revisions := r.storage.revisions[songID]
+	if len(revisions) == 0 {
+		return nil, ErrNotFound
+	}
+	latest := revisions[len(revisions)-1]
+	return &latest, nil
+}
+
+func (r *MemoryRevisionRepository) GetBySong(songID uuid.UUID, offset, limit int) ([]models.SongRevision, int64, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	revisions := r.storage.revisions[songID]
+	newestFirst := make([]models.SongRevision, len(revisions))
+	for i, revision := range revisions {
+		newestFirst[len(revisions)-1-i] = revision
+	}
+	return paginate(newestFirst, offset, limit), int64(len(revisions)), nil
+}
@@ -0,0 +1,422 @@
+// memory_song_repository.go
+package repositories
+
+import (
+	"sort"
+	"time"
+
+	"song_library/internal/lyrics"
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MemorySongRepository - реализация SongStore в памяти процесса.
+// Подходит для тестов и локального запуска без базы данных.
+type MemorySongRepository struct {
+	storage *MemoryStorage
+}
+
+func NewMemorySongRepository(storage *MemoryStorage) *MemorySongRepository {
+	return &MemorySongRepository{
+		storage: storage,
+	}
+}
+
+func (r *MemorySongRepository) Create(song *models.Song) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	now := time.Now()
+	if song.CreatedAt.IsZero() {
+		song.CreatedAt = now
+	}
+	if song.UpdatedAt.IsZero() {
+		song.UpdatedAt = now
+	}
+
+	r.storage.songs[song.ID] = storedSong(*song)
+	r.storage.sections[song.ID] = songSections(song)
+	return nil
+}
+
+func (r *MemorySongRepository) GetByID(id uuid.UUID) (*models.Song, error) {
+	return r.GetProjected(id, models.SongProjection{})
+}
+
+func (r *MemorySongRepository) GetProjected(id uuid.UUID, projection models.SongProjection) (*models.Song, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	song, ok := r.storage.liveSong(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	song = projectedSong(r.storage.loadedSong(song), projection)
+	return &song, nil
+}
+
+func (r *MemorySongRepository) Update(song *models.Song) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	stored, ok := r.storage.liveSong(song.ID)
+	if !ok {
+		return ErrNotFound
+	}
+	song.UpdatedAt = time.Now()
+	song.EnrichmentStatus = stored.EnrichmentStatus
+	song.CreatedAt = stored.CreatedAt
+	song.DeletedAt = stored.DeletedAt
+	if stored.Text != song.Text {
+		r.storage.replaceText(song)
+	}
+	r.storage.songs[song.ID] = storedSong(*song)
+	r.storage.sections[song.ID] = songSections(song)
+	return nil
+}
+
+func (r *MemorySongRepository) UpdateFields(song *models.Song, fields []string) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	stored, ok := r.storage.liveSong(song.ID)
+	if !ok {
+		return ErrNotFound
+	}
+	for _, field := range fields {
+		switch field {
+		case models.SongFieldReleaseDate:
+			stored.ReleaseDate = song.ReleaseDate
+		case models.SongFieldText:
+			if stored.Text != song.Text {
+				r.storage.replaceText(song)
+				stored.Text = song.Text
+				r.storage.sections[song.ID] = songSections(song)
+			}
+		case models.SongFieldLink:
+			stored.Link = song.Link
+		}
+	}
+	stored.UpdatedAt = time.Now()
+	song.UpdatedAt = stored.UpdatedAt
+	r.storage.songs[song.ID] = stored
+	return nil
+}
+
+// GetForUpdate совпадает с GetByID: транзакции в памяти и так выполняются по одной.
+func (r *MemorySongRepository) GetForUpdate(id uuid.UUID) (*models.Song, error) {
+	return r.GetByID(id)
+}
+
+func (r *MemorySongRepository) SetEnrichmentStatus(id uuid.UUID, status models.EnrichmentStatus) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	song, ok := r.storage.songs[id]
+	if !ok {
+		return ErrNotFound
+	}
+	song.EnrichmentStatus = status
+	r.storage.songs[id] = song
+	return nil
+}
+
+func (r *MemorySongRepository) Delete(id uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	song, ok := r.storage.liveSong(id)
+	if !ok {
+		return ErrNotFound
+	}
+	song.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.storage.songs[id] = song
+	return nil
+}
+
+func (r *MemorySongRepository) GetTrash(offset, limit int) ([]models.Song, int64, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	trashed := make([]models.Song, 0)
+	for _, song := range r.storage.songs {
+		if song.DeletedAt.Valid {
+			trashed = append(trashed, r.storage.loadedSong(song))
+		}
+	}
+	sort.Slice(trashed, func(i, j int) bool {
+		if trashed[i].DeletedAt.Time.Equal(trashed[j].DeletedAt.Time) {
+			return trashed[i].ID.String() < trashed[j].ID.String()
+		}
+		return trashed[i].DeletedAt.Time.After(trashed[j].DeletedAt.Time)
+	})
+
+	return paginate(trashed, offset, limit), int64(len(trashed)), nil
+}
+
+func (r *MemorySongRepository) Restore(id uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	song, ok := r.storage.songs[id]
+	if !ok || !song.DeletedAt.Valid {
+		return ErrNotFound
+	}
+	song.DeletedAt = gorm.DeletedAt{}
+	r.storage.songs[id] = song
+	return nil
+}
+
+func (r *MemorySongRepository) Purge(before time.Time) (int64, error) {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	var purged int64
+	for id, song := range r.storage.songs {
+		if song.DeletedAt.Valid && song.DeletedAt.Time.Before(before) {
+			r.storage.deleteSong(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (r *MemorySongRepository) GetAll(filter models.SongFilter, projection models.SongProjection, offset, limit int) ([]models.Song, int64, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	matched := make([]models.Song, 0, len(r.storage.songs))
+	for _, song := range r.storage.songs {
+		song = r.storage.loadedSong(song)
+		if r.storage.matchesFilter(song, filter) {
+			matched = append(matched, song)
+		}
+	}
+
+	// Порядок обхода map случаен, поэтому сортируем для стабильной пагинации
+	sort.Slice(matched, func(i, j int) bool {
+		if len(filter.Order) > 0 {
+			return compareSongs(&matched[i], &matched[j], filter.Order) < 0
+		}
+		if filter.AlbumID != uuid.Nil {
+			a, _ := r.storage.trackOf(filter.AlbumID, matched[i].ID)
+			b, _ := r.storage.trackOf(filter.AlbumID, matched[j].ID)
+			if a.DiscNumber != b.DiscNumber {
+				return a.DiscNumber < b.DiscNumber
+			}
+			if a.TrackNumber != b.TrackNumber {
+				return a.TrackNumber < b.TrackNumber
+			}
+			return matched[i].ID.String() < matched[j].ID.String()
+		}
+		return models.KeyOf(&matched[i]).Less(models.KeyOf(&matched[j]))
+	})
+
+	total := int64(len(matched))
+	var page []models.Song
+	if len(filter.Order) == 0 && filter.AlbumID == uuid.Nil && (filter.After != nil || filter.Before != nil) {
+		// Как в SongRepository: страницы по ключу не пересчитываются
+		page, total = keysetPage(matched, filter.After, filter.Before, offset, limit), -1
+	} else {
+		page = paginate(matched, offset, limit)
+	}
+	for i := range page {
+		page[i] = projectedSong(page[i], projection)
+	}
+	return page, total, nil
+}
+
+// keysetPage выбирает из песен, упорядоченных по models.SongKey, limit песен после
+// ключа after, а если задан before - ближайшие песни до него, как при чтении
+// в обратном порядке в SQL.
+func keysetPage(songs []models.Song, after, before *models.SongKey, offset, limit int) []models.Song {
+	if before != nil {
+		end := sort.Search(len(songs), func(i int) bool {
+			return !models.KeyOf(&songs[i]).Less(*before)
+		})
+		end = max(end-offset, 0)
+		return songs[max(end-limit, 0):end]
+	}
+	start := sort.Search(len(songs), func(i int) bool {
+		return after.Less(models.KeyOf(&songs[i]))
+	})
+	return paginate(songs[start:], offset, limit)
+}
+
+// projectedSong убирает связи, которые проекция не подгружает. Колонки не отбрасываются:
+// в памяти песня и так хранится целиком.
+func projectedSong(song models.Song, projection models.SongProjection) models.Song {
+	if !projection.Loads(models.SongRelationArtist) {
+		song.Artist = nil
+	}
+	if !projection.Loads(models.SongRelationGenres) {
+		song.Genres = nil
+	}
+	if !projection.Loads(models.SongRelationTags) {
+		song.Tags = nil
+	}
+	if !projection.Loads(models.SongRelationEnrichment) {
+		song.Enrichment = nil
+	}
+	return song
+}
+
+// Export копирует подходящие песни под блокировкой и передаёт их fn уже без неё,
+// чтобы медленный получатель не задерживал запись: в памяти песни и так хранятся целиком.
+func (r *MemorySongRepository) Export(filter models.SongFilter, withText bool, fn func(song *models.Song) error) error {
+	r.storage.mu.RLock()
+	matched := make([]models.Song, 0, len(r.storage.songs))
+	for _, song := range r.storage.songs {
+		song = r.storage.loadedSong(song)
+		if !r.storage.matchesFilter(song, filter) {
+			continue
+		}
+		if !withText {
+			song.Text = ""
+		}
+		song.Artist, song.Genres, song.Tags = nil, nil, nil
+		matched = append(matched, song)
+	}
+	r.storage.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		return compareSongs(&matched[i], &matched[j], filter.Order) < 0
+	})
+
+	for i := range matched {
+		if err := fn(&matched[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemorySongRepository) Search(query string, offset, limit int) ([]models.SongSearchResult, int64, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []models.SongSearchResult{}, 0, nil
+	}
+
+	results := make([]models.SongSearchResult, 0)
+	for _, song := range r.storage.songs {
+		if song.DeletedAt.Valid {
+			continue
+		}
+		if result, ok := matchSong(r.storage.loadedSong(song), terms); ok {
+			results = append(results, result)
+		}
+	}
+	rankResults(results)
+
+	return paginate(results, offset, limit), int64(len(results)), nil
+}
+
+func (r *MemorySongRepository) Facets(filter models.SongFilter) (*models.SongFacetsResponse, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	facets := &models.SongFacetsResponse{}
+	tagCounts := make(map[uuid.UUID]int64)
+	genreCounts := make(map[uuid.UUID]int64)
+
+	for _, song := range r.storage.songs {
+		song = r.storage.loadedSong(song)
+		if !r.storage.matchesFilter(song, filter) {
+			continue
+		}
+		facets.Total++
+		for _, tag := range song.Tags {
+			tagCounts[tag.ID]++
+		}
+		for _, genre := range song.Genres {
+			genreCounts[genre.ID]++
+		}
+	}
+
+	facets.Tags = make([]models.FacetCount, 0, len(tagCounts))
+	for id, count := range tagCounts {
+		facets.Tags = append(facets.Tags, models.FacetCount{ID: id, Name: r.storage.tags[id].Name, Count: count})
+	}
+	facets.Genres = make([]models.FacetCount, 0, len(genreCounts))
+	for id, count := range genreCounts {
+		facets.Genres = append(facets.Genres, models.FacetCount{ID: id, Name: r.storage.genres[id].Name, Count: count})
+	}
+	sortFacets(facets.Tags)
+	sortFacets(facets.Genres)
+
+	return facets, nil
+}
+
+// sortFacets упорядочивает счётчики так же, как SQL-реализация: по убыванию числа, затем по названию.
+func sortFacets(counts []models.FacetCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+}
+
+func (r *MemorySongRepository) GetSections(songID uuid.UUID) ([]models.LyricsSection, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	return append([]models.LyricsSection(nil), r.storage.sections[songID]...), nil
+}
+
+// BackfillSections ничего не делает: в памяти части размечаются при каждой записи песни.
+func (r *MemorySongRepository) BackfillSections() (int, error) {
+	return 0, nil
+}
+
+func (r *MemorySongRepository) SetSyncedLines(songID uuid.UUID, lines []models.SyncedLine) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if len(lines) == 0 {
+		delete(r.storage.syncedLines, songID)
+		return nil
+	}
+
+	stored := make([]models.SyncedLine, len(lines))
+	for i, line := range lines {
+		line.SongID = songID
+		stored[i] = line
+	}
+	r.storage.syncedLines[songID] = stored
+	return nil
+}
+
+func (r *MemorySongRepository) GetSyncedLines(songID uuid.UUID) ([]models.SyncedLine, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	return append([]models.SyncedLine(nil), r.storage.syncedLines[songID]...), nil
+}
+
+func (r *MemorySongRepository) GetSyncedLinesBetween(songID uuid.UUID, fromMs, toMs int64) ([]models.SyncedLine, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	lines := make([]models.SyncedLine, 0)
+	for _, line := range r.storage.syncedLines[songID] {
+		if line.Text == "" || line.StartMs >= toMs || (line.EndMs != nil && *line.EndMs <= fromMs) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+func songSections(song *models.Song) []models.LyricsSection {
+	sections := lyrics.Parse(song.Text)
+	for i := range sections {
+		sections[i].SongID = song.ID
+	}
+	return sections
+}
@@ -0,0 +1,62 @@
+// memory_song_repository_test.go
+package repositories
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestKeysetPage(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Песни 1 и 2 добавлены одновременно и различаются только ID
+	songs := []models.Song{
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), CreatedAt: base},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), CreatedAt: base.Add(time.Second)},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000003"), CreatedAt: base.Add(time.Second)},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000004"), CreatedAt: base.Add(2 * time.Second)},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000005"), CreatedAt: base.Add(3 * time.Second)},
+	}
+	key := func(i int) *models.SongKey {
+		k := models.KeyOf(&songs[i])
+		return &k
+	}
+
+	tests := []struct {
+		name   string
+		after  *models.SongKey
+		before *models.SongKey
+		offset int
+		limit  int
+		// want - номера песен страницы (индекс в songs + 1)
+		want []int
+	}{
+		{name: "после первой", after: key(0), limit: 2, want: []int{2, 3}},
+		{name: "после песни с тем же временем", after: key(1), limit: 2, want: []int{3, 4}},
+		{name: "после последней", after: key(4), limit: 2, want: nil},
+		{name: "после со смещением", after: key(0), offset: 1, limit: 2, want: []int{3, 4}},
+		{name: "до последней", before: key(4), limit: 2, want: []int{3, 4}},
+		{name: "до песни с тем же временем", before: key(2), limit: 2, want: []int{1, 2}},
+		{name: "до первой", before: key(0), limit: 2, want: nil},
+		{name: "до - страница у начала короче", before: key(1), limit: 3, want: []int{1}},
+		{name: "до со смещением", before: key(4), offset: 1, limit: 2, want: []int{2, 3}},
+		{name: "ключ удалённой песни", after: &models.SongKey{CreatedAt: base.Add(1500 * time.Millisecond)}, limit: 5, want: []int{4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := keysetPage(songs, tt.after, tt.before, tt.offset, tt.limit)
+			var got []int
+			for _, song := range page {
+				got = append(got, slices.IndexFunc(songs, func(s models.Song) bool { return s.ID == song.ID })+1)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("страница %v, ожидалась %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// memory_song_source_repository.go
+package repositories
+
+import (
+	"sort"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemorySongSourceRepository - реализация SongSourceStore в памяти процесса.
+type MemorySongSourceRepository struct {
+	storage *MemoryStorage
+}
+
+func NewMemorySongSourceRepository(storage *MemoryStorage) *MemorySongSourceRepository {
+	return &MemorySongSourceRepository{
+		storage: storage,
+	}
+}
+
+func (r *MemorySongSourceRepository) GetBySong(songID uuid.UUID) ([]models.SongFieldSource, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	sources := make([]models.SongFieldSource, 0, len(r.storage.songSources[songID]))
+	for _, source := range r.storage.songSources[songID] {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Field < sources[j].Field
+	})
+	return sources, nil
+}
+
+func (r *MemorySongSourceRepository) GetBySongs(songIDs []uuid.UUID) (map[uuid.UUID][]models.SongFieldSource, error) {
+	bySong := make(map[uuid.UUID][]models.SongFieldSource, len(songIDs))
+	for _, songID := range songIDs {
+		sources, err := r.GetBySong(songID)
+		if err != nil {
+			return nil, err
+		}
+		if len(sources) > 0 {
+			bySong[songID] = sources
+		}
+	}
+	return bySong, nil
+}
+
+func (r *MemorySongSourceRepository) Save(sources []models.SongFieldSource) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	now := time.Now()
+	for _, source := range sources {
+		fields, err := r.songFields(source.SongID)
+		if err != nil {
+			return err
+		}
+		stored := fields[source.Field]
+		stored.SongID = source.SongID
+		stored.Field = source.Field
+		stored.Provider = source.Provider
+		stored.FetchedAt = source.FetchedAt
+		stored.Payload = source.Payload
+		stored.UpdatedAt = now
+		fields[source.Field] = stored
+	}
+	return nil
+}
+
+func (r *MemorySongSourceRepository) SetLocked(songID uuid.UUID, fields []string, locked bool) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if len(fields) == 0 {
+		return nil
+	}
+	stored, err := r.songFields(songID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, field := range fields {
+		source := stored[field]
+		source.SongID = songID
+		source.Field = field
+		source.Locked = locked
+		source.UpdatedAt = now
+		stored[field] = source
+	}
+	return nil
+}
+
+// songFields возвращает записи полей песни, создавая их набор при первой записи.
+// Вызывается под блокировкой на запись.
+func (r *MemorySongSourceRepository) songFields(songID uuid.UUID) (map[string]models.SongFieldSource, error) {
+	if _, ok := r.storage.songs[songID]; !ok {
+		return nil, ErrNotFound
+	}
+	fields, ok := r.storage.songSources[songID]
+	if !ok {
+		fields = map[string]models.SongFieldSource{}
+		r.storage.songSources[songID] = fields
+	}
+	return fields, nil
+}
@@ -0,0 +1,339 @@
+// memory_storage.go
+package repositories
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"song_library/internal/lyrics"
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStorage - общее хранилище для in-memory репозиториев.
+// Все репозитории работают под одной блокировкой и видят данные друг друга,
+// поэтому связи между сущностями ведут себя так же, как внешние ключи в базе.
+type MemoryStorage struct {
+	mu      sync.RWMutex
+	songs   map[uuid.UUID]models.Song
+	artists map[uuid.UUID]models.Artist
+	albums  map[uuid.UUID]models.Album
+	tracks  map[uuid.UUID][]models.AlbumTrack
+
+	playlists map[uuid.UUID]models.Playlist
+	// entries хранятся в порядке позиций: позиция записи равна её индексу плюс один
+	entries map[uuid.UUID][]models.PlaylistEntry
+
+	genres map[uuid.UUID]models.Genre
+	tags   map[uuid.UUID]models.Tag
+	// songGenres и songTags - связи песни с жанрами и тегами по ID песни
+	songGenres map[uuid.UUID]map[uuid.UUID]struct{}
+	songTags   map[uuid.UUID]map[uuid.UUID]struct{}
+
+	sections    map[uuid.UUID][]models.LyricsSection
+	syncedLines map[uuid.UUID][]models.SyncedLine
+	// translations - переводы песни по языку
+	translations map[uuid.UUID]map[string]models.Translation
+	// revisions хранятся по порядку: номер ревизии равен индексу плюс один
+	revisions map[uuid.UUID][]models.SongRevision
+	// enrichmentJobs - задания очереди обогащения по ID песни
+	enrichmentJobs map[uuid.UUID]models.EnrichmentJob
+	// songSources - происхождение и блокировки полей по ID песни и названию поля
+	songSources map[uuid.UUID]map[string]models.SongFieldSource
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		songs:   make(map[uuid.UUID]models.Song),
+		artists: make(map[uuid.UUID]models.Artist),
+		albums:  make(map[uuid.UUID]models.Album),
+		tracks:  make(map[uuid.UUID][]models.AlbumTrack),
+
+		playlists: make(map[uuid.UUID]models.Playlist),
+		entries:   make(map[uuid.UUID][]models.PlaylistEntry),
+
+		genres:     make(map[uuid.UUID]models.Genre),
+		tags:       make(map[uuid.UUID]models.Tag),
+		songGenres: make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		songTags:   make(map[uuid.UUID]map[uuid.UUID]struct{}),
+
+		sections:     make(map[uuid.UUID][]models.LyricsSection),
+		syncedLines:  make(map[uuid.UUID][]models.SyncedLine),
+		translations: make(map[uuid.UUID]map[string]models.Translation),
+		revisions:    make(map[uuid.UUID][]models.SongRevision),
+
+		enrichmentJobs: make(map[uuid.UUID]models.EnrichmentJob),
+		songSources:    make(map[uuid.UUID]map[string]models.SongFieldSource),
+	}
+}
+
+// loadedSong заполняет связанные данные песни так же, как это делает Preload в GORM.
+func (s *MemoryStorage) loadedSong(song models.Song) models.Song {
+	if artist, ok := s.artists[song.ArtistID]; ok {
+		song.Artist = &artist
+		song.GroupName = artist.Name
+	}
+
+	song.Genres = nil
+	for genreID := range s.songGenres[song.ID] {
+		song.Genres = append(song.Genres, s.genres[genreID])
+	}
+	sort.Slice(song.Genres, func(i, j int) bool {
+		return song.Genres[i].Name < song.Genres[j].Name
+	})
+
+	song.Tags = nil
+	for tagID := range s.songTags[song.ID] {
+		song.Tags = append(song.Tags, s.tags[tagID])
+	}
+	sort.Slice(song.Tags, func(i, j int) bool {
+		return song.Tags[i].Name < song.Tags[j].Name
+	})
+
+	song.Enrichment = nil
+	if job, ok := s.enrichmentJobs[song.ID]; ok {
+		song.Enrichment = &job
+	}
+
+	return song
+}
+
+// storedSong убирает из песни данные, которые хранятся отдельно.
+func storedSong(song models.Song) models.Song {
+	song.Artist = nil
+	song.GroupName = ""
+	song.Genres = nil
+	song.Tags = nil
+	song.Enrichment = nil
+	return song
+}
+
+// replaceText удаляет синхронизацию LRC песни, текст которой меняется на song.Text,
+// и переносит части её переводов на части нового текста. Вызывается под s.mu
+// до того, как в sections попадут части нового текста.
+func (s *MemoryStorage) replaceText(song *models.Song) {
+	delete(s.syncedLines, song.ID)
+	before := s.sections[song.ID]
+	after := songSections(song)
+	for language, translation := range s.translations[song.ID] {
+		translation.Verses = lyrics.RealignVerses(before, after, translation.Verses)
+		s.translations[song.ID][language] = translation
+	}
+}
+
+// liveSong возвращает песню, если она есть и не находится в корзине.
+func (s *MemoryStorage) liveSong(id uuid.UUID) (models.Song, bool) {
+	song, ok := s.songs[id]
+	if !ok || song.DeletedAt.Valid {
+		return models.Song{}, false
+	}
+	return song, true
+}
+
+// trackOf возвращает позицию песни в альбоме.
+func (s *MemoryStorage) trackOf(albumID, songID uuid.UUID) (models.AlbumTrack, bool) {
+	for _, track := range s.tracks[albumID] {
+		if track.SongID == songID {
+			return track, true
+		}
+	}
+	return models.AlbumTrack{}, false
+}
+
+// deleteSong удаляет песню вместе с зависимыми записями, как ON DELETE CASCADE.
+func (s *MemoryStorage) deleteSong(id uuid.UUID) {
+	delete(s.songs, id)
+	delete(s.songGenres, id)
+	delete(s.songTags, id)
+	delete(s.sections, id)
+	delete(s.syncedLines, id)
+	delete(s.translations, id)
+	delete(s.revisions, id)
+	delete(s.enrichmentJobs, id)
+	delete(s.songSources, id)
+
+	for albumID, tracks := range s.tracks {
+		kept := tracks[:0]
+		for _, track := range tracks {
+			if track.SongID != id {
+				kept = append(kept, track)
+			}
+		}
+		s.tracks[albumID] = kept
+	}
+}
+
+func (s *MemoryStorage) tagByName(normalizedName string) (models.Tag, bool) {
+	for _, tag := range s.tags {
+		if tag.NormalizedName == normalizedName {
+			return tag, true
+		}
+	}
+	return models.Tag{}, false
+}
+
+// matchesFilter проверяет песню, уже заполненную через loadedSong, на соответствие фильтру.
+func (s *MemoryStorage) matchesFilter(song models.Song, filter models.SongFilter) bool {
+	if song.DeletedAt.Valid && !filter.WithTrashed {
+		return false
+	}
+	if filter.GroupName != "" &&
+		!matchText(models.NormalizeArtistName(song.GroupName), models.NormalizeArtistName(filter.GroupName), filter.Match) {
+		return false
+	}
+	if filter.SongTitle != "" && !matchText(strings.ToLower(song.SongTitle), strings.ToLower(filter.SongTitle), filter.Match) {
+		return false
+	}
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, song.ID) {
+		return false
+	}
+	if !filter.Released.IsZero() && (song.ReleaseDate.IsZero() || !filter.Released.Contains(song.ReleaseDate.Time)) {
+		return false
+	}
+	if !filter.Created.Contains(song.CreatedAt) || !filter.Updated.Contains(song.UpdatedAt) {
+		return false
+	}
+	if filter.HasLyrics != nil && (song.Text != "") != *filter.HasLyrics {
+		return false
+	}
+	if filter.HasLink != nil && (song.Link != "") != *filter.HasLink {
+		return false
+	}
+	if filter.Enrichment != "" && song.EnrichmentStatus != filter.Enrichment {
+		return false
+	}
+	if filter.ArtistID != uuid.Nil && song.ArtistID != filter.ArtistID {
+		return false
+	}
+	if filter.AlbumID != uuid.Nil {
+		if _, ok := s.trackOf(filter.AlbumID, song.ID); !ok {
+			return false
+		}
+	}
+	if len(filter.Tags) > 0 {
+		matched := 0
+		for _, tag := range song.Tags {
+			if containsString(filter.Tags, tag.NormalizedName) {
+				matched++
+			}
+		}
+		if matched == 0 || (filter.TagMatch == models.TagMatchAll && matched < len(filter.Tags)) {
+			return false
+		}
+	}
+	if len(filter.Genres) > 0 {
+		matched := false
+		for _, genre := range song.Genres {
+			if containsString(filter.Genres, genre.NormalizedName) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if filter.Incomplete && !s.incomplete(song) {
+		return false
+	}
+	if !filter.StaleBefore.IsZero() && !s.stale(song.ID, filter.StaleBefore) {
+		return false
+	}
+	return true
+}
+
+// incomplete сообщает, что у песни не заполнено незаблокированное поле.
+func (s *MemoryStorage) incomplete(song models.Song) bool {
+	sources := s.songSources[song.ID]
+	missing := func(field string, empty bool) bool {
+		return empty && !sources[field].Locked
+	}
+	return missing(models.SongFieldReleaseDate, song.ReleaseDate.IsZero()) ||
+		missing(models.SongFieldText, song.Text == "") ||
+		missing(models.SongFieldLink, song.Link == "")
+}
+
+// stale сообщает, что данные источника в незаблокированном поле песни получены раньше before.
+func (s *MemoryStorage) stale(songID uuid.UUID, before time.Time) bool {
+	for _, source := range s.songSources[songID] {
+		if !source.Locked && source.FetchedAt != nil && source.FetchedAt.Before(before) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchText сравнивает уже приведённые к нижнему регистру название и образец.
+func matchText(value, pattern string, match models.TextMatch) bool {
+	switch match {
+	case models.TextMatchExact:
+		return value == pattern
+	case models.TextMatchPrefix:
+		return strings.HasPrefix(value, pattern)
+	}
+	return strings.Contains(value, pattern)
+}
+
+// compareSongs сравнивает песни по полям order так же, как orderSongs в SQL;
+// при равенстве - по порядку добавления.
+func compareSongs(a, b *models.Song, order []models.SongOrder) int {
+	for _, o := range order {
+		var result int
+		switch o.Field {
+		case models.SortSong:
+			result = strings.Compare(strings.ToLower(a.SongTitle), strings.ToLower(b.SongTitle))
+		case models.SortGroup:
+			result = strings.Compare(models.NormalizeArtistName(a.GroupName), models.NormalizeArtistName(b.GroupName))
+		case models.SortReleaseDate:
+			// Неизвестная дата идёт последней в любом направлении
+			if a.ReleaseDate.IsZero() != b.ReleaseDate.IsZero() {
+				if a.ReleaseDate.IsZero() {
+					return 1
+				}
+				return -1
+			}
+			result = a.ReleaseDate.Time.Compare(b.ReleaseDate.Time)
+		case models.SortCreatedAt:
+			result = a.CreatedAt.Compare(b.CreatedAt)
+		case models.SortUpdatedAt:
+			result = a.UpdatedAt.Compare(b.UpdatedAt)
+		}
+		if o.Desc {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	if models.KeyOf(a).Less(models.KeyOf(b)) {
+		return -1
+	}
+	if models.KeyOf(b).Less(models.KeyOf(a)) {
+		return 1
+	}
+	return 0
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func paginate[T any](items []T, offset, limit int) []T {
+	if offset > len(items) {
+		offset = len(items)
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
@@ -0,0 +1,86 @@
+// memory_tag_repository.go
+package repositories
+
+import (
+	"sort"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryTagRepository - реализация TagStore в памяти процесса.
+type MemoryTagRepository struct {
+	storage *MemoryStorage
+}
+
+func NewMemoryTagRepository(storage *MemoryStorage) *MemoryTagRepository {
+	return &MemoryTagRepository{
+		storage: storage,
+	}
+}
+
+func (r *MemoryTagRepository) GetByNormalizedName(normalizedName string) (*models.Tag, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	if tag, ok := r.storage.tagByName(normalizedName); ok {
+		return &tag, nil
+	}
+	return nil, ErrNotFound
+}
+
+func (r *MemoryTagRepository) FindOrCreate(names []string) ([]models.Tag, error) {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		normalizedName := models.NormalizeTagName(name)
+		tag, ok := r.storage.tagByName(normalizedName)
+		if !ok {
+			tag = models.Tag{
+				ID:             uuid.New(),
+				Name:           name,
+				NormalizedName: normalizedName,
+				CreatedAt:      time.Now(),
+			}
+			r.storage.tags[tag.ID] = tag
+		}
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
+
+func (r *MemoryTagRepository) AddToSong(songID uuid.UUID, tagIDs []uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.songs[songID]; !ok {
+		return ErrNotFound
+	}
+
+	if r.storage.songTags[songID] == nil {
+		r.storage.songTags[songID] = make(map[uuid.UUID]struct{})
+	}
+	for _, tagID := range tagIDs {
+		r.storage.songTags[songID][tagID] = struct{}{}
+	}
+	return nil
+}
+
+func (r *MemoryTagRepository) RemoveFromSong(songID, tagID uuid.UUID) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.songTags[songID][tagID]; !ok {
+		return ErrEntryNotFound
+	}
+	delete(r.storage.songTags[songID], tagID)
+	return nil
+}
@@ -0,0 +1,111 @@
+// memory_transactor.go
+package repositories
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// NewMemoryStores возвращает хранилища в памяти процесса над общим storage.
+func NewMemoryStores(storage *MemoryStorage) *Stores {
+	return &Stores{
+		Songs:        NewMemorySongRepository(storage),
+		Artists:      NewMemoryArtistRepository(storage),
+		Albums:       NewMemoryAlbumRepository(storage),
+		Playlists:    NewMemoryPlaylistRepository(storage),
+		Genres:       NewMemoryGenreRepository(storage),
+		Tags:         NewMemoryTagRepository(storage),
+		Translations: NewMemoryTranslationRepository(storage),
+		Revisions:    NewMemoryRevisionRepository(storage),
+		Enrichment:   NewMemoryEnrichmentRepository(storage),
+		Sources:      NewMemorySongSourceRepository(storage),
+	}
+}
+
+// MemoryTransactor - реализация Transactor в памяти процесса. Транзакции выполняются
+// по одной; перед каждой запоминается копия storage, и если fn вернула ошибку,
+// storage возвращается к ней. Изменения, сделанные в это время вне транзакций,
+// при откате тоже теряются.
+type MemoryTransactor struct {
+	mu      sync.Mutex
+	storage *MemoryStorage
+	stores  *Stores
+}
+
+func NewMemoryTransactor(storage *MemoryStorage) *MemoryTransactor {
+	return &MemoryTransactor{
+		storage: storage,
+		stores:  NewMemoryStores(storage),
+	}
+}
+
+func (t *MemoryTransactor) Transaction(fn func(stores *Stores) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshot := t.storage.snapshot()
+	if err := fn(t.stores); err != nil {
+		t.storage.restore(snapshot)
+		return err
+	}
+	return nil
+}
+
+// snapshot копирует данные storage так, чтобы изменения после копии её не затронули.
+func (s *MemoryStorage) snapshot() *MemoryStorage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &MemoryStorage{
+		songs:   maps.Clone(s.songs),
+		artists: maps.Clone(s.artists),
+		albums:  maps.Clone(s.albums),
+		tracks:  cloneSlices(s.tracks),
+
+		playlists: maps.Clone(s.playlists),
+		entries:   cloneSlices(s.entries),
+
+		genres:     maps.Clone(s.genres),
+		tags:       maps.Clone(s.tags),
+		songGenres: cloneMaps(s.songGenres),
+		songTags:   cloneMaps(s.songTags),
+
+		sections:     cloneSlices(s.sections),
+		syncedLines:  cloneSlices(s.syncedLines),
+		translations: cloneMaps(s.translations),
+		revisions:    cloneSlices(s.revisions),
+
+		enrichmentJobs: maps.Clone(s.enrichmentJobs),
+		songSources:    cloneMaps(s.songSources),
+	}
+}
+
+// restore возвращает storage к данным snapshot.
+func (s *MemoryStorage) restore(snapshot *MemoryStorage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.songs, s.artists, s.albums, s.tracks = snapshot.songs, snapshot.artists, snapshot.albums, snapshot.tracks
+	s.playlists, s.entries = snapshot.playlists, snapshot.entries
+	s.genres, s.tags, s.songGenres, s.songTags = snapshot.genres, snapshot.tags, snapshot.songGenres, snapshot.songTags
+	s.sections, s.syncedLines = snapshot.sections, snapshot.syncedLines
+	s.translations, s.revisions = snapshot.translations, snapshot.revisions
+	s.enrichmentJobs, s.songSources = snapshot.enrichmentJobs, snapshot.songSources
+}
+
+func cloneSlices[K comparable, V any](m map[K][]V) map[K][]V {
+	cloned := make(map[K][]V, len(m))
+	for key, values := range m {
+		cloned[key] = slices.Clone(values)
+	}
+	return cloned
+}
+
+func cloneMaps[K, K2 comparable, V any](m map[K]map[K2]V) map[K]map[K2]V {
+	cloned := make(map[K]map[K2]V, len(m))
+	for key, values := range m {
+		cloned[key] = maps.Clone(values)
+	}
+	return cloned
+}
@@ -0,0 +1,100 @@
+// memory_translation_repository.go
+package repositories
+
+import (
+	"sort"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MemoryTranslationRepository - реализация TranslationStore в памяти процесса.
+type MemoryTranslationRepository struct {
+	storage *MemoryStorage
+}
+
+func NewMemoryTranslationRepository(storage *MemoryStorage) *MemoryTranslationRepository {
+	return &MemoryTranslationRepository{
+		storage: storage,
+	}
+}
+
+func (r *MemoryTranslationRepository) GetBySong(songID uuid.UUID) ([]models.Translation, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	translations := make([]models.Translation, 0, len(r.storage.translations[songID]))
+	for _, translation := range r.storage.translations[songID] {
+		translations = append(translations, translation)
+	}
+	sort.Slice(translations, func(i, j int) bool {
+		return translations[i].Language < translations[j].Language
+	})
+	return translations, nil
+}
+
+func (r *MemoryTranslationRepository) GetBySongs(songIDs []uuid.UUID) (map[uuid.UUID][]models.Translation, error) {
+	bySong := make(map[uuid.UUID][]models.Translation, len(songIDs))
+	for _, songID := range songIDs {
+		translations, err := r.GetBySong(songID)
+		if err != nil {
+			return nil, err
+		}
+		if len(translations) > 0 {
+			bySong[songID] = translations
+		}
+	}
+	return bySong, nil
+}
+
+func (r *MemoryTranslationRepository) Get(songID uuid.UUID, language string) (*models.Translation, error) {
+	r.storage.mu.RLock()
+	defer r.storage.mu.RUnlock()
+
+	translation, ok := r.storage.translations[songID][language]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &translation, nil
+}
+
+func (r *MemoryTranslationRepository) Save(translation *models.Translation) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.songs[translation.SongID]; !ok {
+		return ErrNotFound
+	}
+
+	byLanguage, ok := r.storage.translations[translation.SongID]
+	if !ok {
+		byLanguage = make(map[string]models.Translation)
+		r.storage.translations[translation.SongID] = byLanguage
+	}
+
+	now := time.Now()
+	if existing, ok := byLanguage[translation.Language]; ok {
+		translation.CreatedAt = existing.CreatedAt
+	} else if translation.CreatedAt.IsZero() {
+		translation.CreatedAt = now
+	}
+	translation.UpdatedAt = now
+
+	stored := *translation
+	stored.Verses = append([]models.TranslatedVerse(nil), translation.Verses...)
+	byLanguage[translation.Language] = stored
+	return nil
+}
+
+func (r *MemoryTranslationRepository) Delete(songID uuid.UUID, language string) error {
+	r.storage.mu.Lock()
+	defer r.storage.mu.Unlock()
+
+	if _, ok := r.storage.translations[songID][language]; !ok {
+		return ErrNotFound
+	}
+	delete(r.storage.translations[songID], language)
+	return nil
+}
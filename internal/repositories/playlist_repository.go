@@ -0,0 +1,222 @@
+// playlist_repository.go
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const playlistEntryCount = "(SELECT count(*) FROM playlist_entries e WHERE e.playlist_id = playlists.id) AS entry_count"
+
+// PlaylistRepository - реализация PlaylistStore поверх GORM.
+type PlaylistRepository struct {
+	db *gorm.DB
+}
+
+func NewPlaylistRepository(db *gorm.DB) *PlaylistRepository {
+	return &PlaylistRepository{
+		db: db,
+	}
+}
+
+func (r *PlaylistRepository) Create(playlist *models.Playlist) error {
+	return r.db.Create(playlist).Error
+}
+
+func (r *PlaylistRepository) GetByID(id uuid.UUID) (*models.Playlist, error) {
+	var playlist models.Playlist
+	result := r.db.Select("playlists.*", playlistEntryCount).First(&playlist, "playlists.id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, result.Error
+	}
+	return &playlist, nil
+}
+
+func (r *PlaylistRepository) Update(playlist *models.Playlist) error {
+	return r.db.Save(playlist).Error
+}
+
+func (r *PlaylistRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.Playlist{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PlaylistRepository) GetAll(offset, limit int) ([]models.Playlist, int64, error) {
+	var playlists []models.Playlist
+	var total int64
+
+	err := r.db.Model(&models.Playlist{}).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.
+		Select("playlists.*", playlistEntryCount).
+		Order("playlists.created_at").
+		Order("playlists.id").
+		Offset(offset).
+		Limit(limit).
+		Find(&playlists).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return playlists, total, nil
+}
+
+func (r *PlaylistRepository) GetEntries(playlistID uuid.UUID, offset, limit int) ([]models.PlaylistEntry, int64, error) {
+	var entries []models.PlaylistEntry
+	var total int64
+
+	query := r.db.Model(&models.PlaylistEntry{}).Where("playlist_id = ?", playlistID)
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = preloadSong(query, "Song.").Order("position").Offset(offset).Limit(limit).Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+func (r *PlaylistRepository) GetAllEntries(playlistID uuid.UUID) ([]models.PlaylistEntry, error) {
+	var entries []models.PlaylistEntry
+	err := preloadSong(r.db, "Song.").Where("playlist_id = ?", playlistID).Order("position").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *PlaylistRepository) InsertEntry(entry *models.PlaylistEntry) error {
+	return r.withPlaylistLock(entry.PlaylistID, func(tx *gorm.DB, count int) error {
+		if entry.Position < 1 || entry.Position > count+1 {
+			entry.Position = count + 1
+		}
+
+		err := tx.Model(&models.PlaylistEntry{}).
+			Where("playlist_id = ? AND position >= ?", entry.PlaylistID, entry.Position).
+			Update("position", gorm.Expr("position + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Omit(clause.Associations).Create(entry).Error
+	})
+}
+
+func (r *PlaylistRepository) MoveEntry(playlistID, entryID uuid.UUID, position int) (*models.PlaylistEntry, error) {
+	var entry models.PlaylistEntry
+
+	err := r.withPlaylistLock(playlistID, func(tx *gorm.DB, count int) error {
+		if err := r.firstEntry(tx, playlistID, entryID, &entry); err != nil {
+			return err
+		}
+
+		if position > count {
+			position = count
+		}
+		from := entry.Position
+
+		shift := tx.Model(&models.PlaylistEntry{}).Where("playlist_id = ?", playlistID)
+		switch {
+		case position < from:
+			shift = shift.Where("position >= ? AND position < ?", position, from).
+				Update("position", gorm.Expr("position + 1"))
+		case position > from:
+			shift = shift.Where("position > ? AND position <= ?", from, position).
+				Update("position", gorm.Expr("position - 1"))
+		default:
+			return nil
+		}
+		if shift.Error != nil {
+			return shift.Error
+		}
+
+		entry.Position = position
+		return tx.Model(&entry).Update("position", position).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (r *PlaylistRepository) RemoveEntry(playlistID, entryID uuid.UUID) error {
+	return r.withPlaylistLock(playlistID, func(tx *gorm.DB, count int) error {
+		var entry models.PlaylistEntry
+		if err := r.firstEntry(tx, playlistID, entryID, &entry); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&entry).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.PlaylistEntry{}).
+			Where("playlist_id = ? AND position > ?", playlistID, entry.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+}
+
+func (r *PlaylistRepository) MarkSongRemoved(songID uuid.UUID, removedAt time.Time) error {
+	return r.db.Model(&models.PlaylistEntry{}).
+		Where("song_id = ? AND song_removed_at IS NULL", songID).
+		Update("song_removed_at", removedAt).Error
+}
+
+func (r *PlaylistRepository) ClearSongRemoved(songID uuid.UUID) error {
+	return r.db.Model(&models.PlaylistEntry{}).
+		Where("song_id = ? AND song_removed_at IS NOT NULL", songID).
+		Update("song_removed_at", nil).Error
+}
+
+// withPlaylistLock блокирует строку плейлиста на время транзакции, чтобы параллельные
+// изменения не перепутали позиции, и передаёт в fn текущее число записей.
+func (r *PlaylistRepository) withPlaylistLock(playlistID uuid.UUID, fn func(tx *gorm.DB, count int) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var playlist models.Playlist
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&playlist, "id = ?", playlistID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.PlaylistEntry{}).Where("playlist_id = ?", playlistID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		return fn(tx, int(count))
+	})
+}
+
+func (r *PlaylistRepository) firstEntry(tx *gorm.DB, playlistID, entryID uuid.UUID, entry *models.PlaylistEntry) error {
+	err := tx.First(entry, "id = ? AND playlist_id = ?", entryID, playlistID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrEntryNotFound
+	}
+	return err
+}
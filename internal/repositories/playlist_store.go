@@ -0,0 +1,32 @@
+// playlist_store.go
+package repositories
+
+import (
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// PlaylistStore описывает хранилище плейлистов.
+// Позиции записей нумеруются с единицы; вставка, перемещение и удаление
+// сдвигают соседние записи, чтобы в нумерации не было пропусков.
+type PlaylistStore interface {
+	Create(playlist *models.Playlist) error
+	GetByID(id uuid.UUID) (*models.Playlist, error)
+	Update(playlist *models.Playlist) error
+	Delete(id uuid.UUID) error
+	GetAll(offset, limit int) ([]models.Playlist, int64, error)
+
+	GetEntries(playlistID uuid.UUID, offset, limit int) ([]models.PlaylistEntry, int64, error)
+	GetAllEntries(playlistID uuid.UUID) ([]models.PlaylistEntry, error)
+	// InsertEntry вставляет запись на entry.Position; нулевая или слишком большая позиция означает конец списка.
+	InsertEntry(entry *models.PlaylistEntry) error
+	MoveEntry(playlistID, entryID uuid.UUID, position int) (*models.PlaylistEntry, error)
+	RemoveEntry(playlistID, entryID uuid.UUID) error
+	// MarkSongRemoved помечает все записи с песней как указывающие на удалённую песню.
+	MarkSongRemoved(songID uuid.UUID, removedAt time.Time) error
+	// ClearSongRemoved снимает пометку с записей песни, восстановленной из корзины.
+	ClearSongRemoved(songID uuid.UUID) error
+}
@@ -0,0 +1,84 @@
+// revision_repository.go
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RevisionRepository - реализация RevisionStore поверх GORM.
+type RevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewRevisionRepository(db *gorm.DB) *RevisionRepository {
+	return &RevisionRepository{
+		db: db,
+	}
+}
+
+// Create выбирает номер внутри транзакции; если две записи одновременно получат
+// один номер, вторая упадёт на первичном ключе с ErrConflict, а не перезапишет первую.
+func (r *RevisionRepository) Create(revision *models.SongRevision) error {
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now()
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		err := tx.Model(&models.SongRevision{}).
+			Where("song_id = ?", revision.SongID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		revision.Revision = last + 1
+		return conflictError(tx.Create(revision).Error)
+	})
+}
+
+func (r *RevisionRepository) Get(songID uuid.UUID, revision int) (*models.SongRevision, error) {
+	return r.first(r.db.Where("song_id = ? AND revision = ?", songID, revision))
+}
+
+func (r *RevisionRepository) Latest(songID uuid.UUID) (*models.SongRevision, error) {
+	return r.first(r.db.Where("song_id = ?", songID).Order("revision DESC"))
+}
+
+func (r *RevisionRepository) GetBySong(songID uuid.UUID, offset, limit int) ([]models.SongRevision, int64, error) {
+	var revisions []models.SongRevision
+	var total int64
+
+	query := r.db.Model(&models.SongRevision{}).Where("song_id = ?", songID)
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = query.Order("revision DESC").Offset(offset).Limit(limit).Find(&revisions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, total, nil
+}
+
+func (r *RevisionRepository) first(query *gorm.DB) (*models.SongRevision, error) {
+	var revision models.SongRevision
+	err := query.First(&revision).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
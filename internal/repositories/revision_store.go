@@ -0,0 +1,20 @@
+// revision_store.go
+package repositories
+
+import (
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// RevisionStore описывает хранилище истории изменений песен.
+type RevisionStore interface {
+	// Create сохраняет ревизию, присваивая ей следующий номер для песни. Если этот номер
+	// одновременно занял другой запрос, возвращается ErrConflict.
+	Create(revision *models.SongRevision) error
+	Get(songID uuid.UUID, revision int) (*models.SongRevision, error)
+	// Latest возвращает последнюю ревизию или ErrNotFound, если истории ещё нет.
+	Latest(songID uuid.UUID) (*models.SongRevision, error)
+	// GetBySong возвращает ревизии песни, начиная с последней.
+	GetBySong(songID uuid.UUID, offset, limit int) ([]models.SongRevision, int64, error)
+}
@@ -2,12 +2,20 @@
 package repositories
 
 import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"song_library/internal/lyrics"
 	"song_library/internal/models"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// SongRepository - реализация SongStore поверх GORM (PostgreSQL или SQLite).
 type SongRepository struct {
 	db *gorm.DB
 }
@@ -18,53 +26,802 @@ func NewSongRepository(db *gorm.DB) *SongRepository {
 	}
 }
 
-func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(&models.Song{})
-}
-
+// Исполнитель сохраняется через ArtistStore, поэтому ассоциации здесь не записываются.
 func (r *SongRepository) Create(song *models.Song) error {
-	return r.db.Create(song).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(song).Error; err != nil {
+			return err
+		}
+		return replaceSections(tx, song.ID, song.Text)
+	})
 }
 
 func (r *SongRepository) GetByID(id uuid.UUID) (*models.Song, error) {
+	return r.GetProjected(id, models.SongProjection{})
+}
+
+func (r *SongRepository) GetProjected(id uuid.UUID, projection models.SongProjection) (*models.Song, error) {
 	var song models.Song
-	result := r.db.First(&song, "id = ?", id)
+	result := projectSong(r.db, projection).First(&song, "songs.id = ?", id)
 	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
 		return nil, result.Error
 	}
 	return &song, nil
 }
 
+// songColumns - колонки, которые сохраняет Update: статус обогащения пишет только
+// очередь, а время удаления - корзина.
+var songColumns = []string{
+	"artist_id", "song_title", "release_date", "release_date_precision", "text", "link", "updated_at",
+}
+
+// Update не меняет статус обогащения: его пишет только очередь через SetEnrichmentStatus,
+// чтобы правка песни не затёрла результат обогащения, завершившегося в это время.
 func (r *SongRepository) Update(song *models.Song) error {
-	return r.db.Save(song).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		previous, err := lockedText(tx, song.ID)
+		if err != nil {
+			return err
+		}
+
+		song.UpdatedAt = time.Now()
+		if err := updateSong(tx, song, songColumns); err != nil {
+			return err
+		}
+		if previous == song.Text {
+			return replaceSections(tx, song.ID, song.Text)
+		}
+		return replaceText(tx, song.ID, song.Text)
+	})
+}
+
+// songFieldColumns - колонки таблицы songs для блокируемых полей песни.
+var songFieldColumns = map[string][]string{
+	models.SongFieldReleaseDate: {"release_date", "release_date_precision"},
+	models.SongFieldText:        {"text"},
+	models.SongFieldLink:        {"link"},
+}
+
+func (r *SongRepository) UpdateFields(song *models.Song, fields []string) error {
+	columns := []string{"updated_at"}
+	for _, field := range fields {
+		columns = append(columns, songFieldColumns[field]...)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		previous, err := lockedText(tx, song.ID)
+		if err != nil {
+			return err
+		}
+
+		song.UpdatedAt = time.Now()
+		if err := updateSong(tx, song, columns); err != nil {
+			return err
+		}
+		if !slices.Contains(fields, models.SongFieldText) || previous == song.Text {
+			return nil
+		}
+		return replaceText(tx, song.ID, song.Text)
+	})
+}
+
+// GetForUpdate блокирует строку песни до конца транзакции и читает песню, как GetByID.
+func (r *SongRepository) GetForUpdate(id uuid.UUID) (*models.Song, error) {
+	if _, err := lockedText(r.db, id); err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+// updateSong сохраняет колонки песни; ErrNotFound, если песня в корзине.
+func updateSong(tx *gorm.DB, song *models.Song, columns []string) error {
+	result := tx.Model(song).Omit(clause.Associations).Select(columns).Updates(song)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// lockedText блокирует строку песни, в том числе из корзины, до конца транзакции
+// и возвращает её текст.
+func lockedText(tx *gorm.DB, id uuid.UUID) (string, error) {
+	var song models.Song
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "text").First(&song, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", ErrNotFound
+	}
+	return song.Text, err
+}
+
+// replaceText обновляет то, что зависит от изменившегося текста песни: части текста
+// разбираются заново, синхронизация LRC удаляется, потому что её строки ссылались
+// на старые куплеты, а части переводов переносятся на части нового текста.
+func replaceText(tx *gorm.DB, songID uuid.UUID, text string) error {
+	var before []models.LyricsSection
+	if err := tx.Where("song_id = ?", songID).Order("position").Find(&before).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("song_id = ?", songID).Delete(&models.SyncedLine{}).Error; err != nil {
+		return err
+	}
+	if err := replaceSections(tx, songID, text); err != nil {
+		return err
+	}
+	return realignTranslations(tx, songID, before, lyrics.Parse(text))
+}
+
+// realignTranslations переносит части переводов песни на части изменённого текста.
+func realignTranslations(tx *gorm.DB, songID uuid.UUID, before, after []models.LyricsSection) error {
+	var translations []models.Translation
+	if err := tx.Where("song_id = ?", songID).Find(&translations).Error; err != nil {
+		return err
+	}
+	for i := range translations {
+		verses := lyrics.RealignVerses(before, after, translations[i].Verses)
+		err := tx.Model(&translations[i]).Select("verses").Updates(models.Translation{Verses: verses}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SongRepository) SetEnrichmentStatus(id uuid.UUID, status models.EnrichmentStatus) error {
+	result := r.db.Unscoped().Model(&models.Song{}).Where("id = ?", id).Update("enrichment_status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
+// Delete перемещает песню в корзину. Песня, которой нет или которая уже
+// в корзине, даёт ErrNotFound: мягкое удаление обновляет только живые строки.
 func (r *SongRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Song{}, "id = ?", id).Error
+	result := r.db.Delete(&models.Song{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
-func (r *SongRepository) GetAll(filter models.SongFilter, offset, limit int) ([]models.Song, int64, error) {
+func (r *SongRepository) GetTrash(offset, limit int) ([]models.Song, int64, error) {
 	var songs []models.Song
 	var total int64
 
+	query := r.db.Unscoped().Model(&models.Song{}).Where("deleted_at IS NOT NULL")
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = preloadSong(query, "").Order("deleted_at DESC").Order("id").Offset(offset).Limit(limit).Find(&songs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return songs, total, nil
+}
+
+func (r *SongRepository) Restore(id uuid.UUID) error {
+	result := r.db.Unscoped().Model(&models.Song{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Purge удаляет окончательно песни, попавшие в корзину раньше before.
+// Зависимые записи удаляются через ON DELETE CASCADE.
+func (r *SongRepository) Purge(before time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&models.Song{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *SongRepository) GetAll(filter models.SongFilter, projection models.SongProjection, offset, limit int) ([]models.Song, int64, error) {
+	var songs []models.Song
+	var total int64
+
+	query := r.filtered(filter)
+
+	// Страницу по ключу читает индекс idx_songs_created_at_id, а полный подсчёт
+	// на каждой такой странице обходил бы все песни под фильтром
+	total = -1
+	if filter.After == nil && filter.Before == nil {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	reversed := false
+	switch {
+	case len(filter.Order) > 0:
+		query = orderSongs(query, filter.Order)
+	case filter.AlbumID != uuid.Nil:
+		query = query.
+			Joins("JOIN album_tracks ON album_tracks.song_id = songs.id AND album_tracks.album_id = ?", filter.AlbumID).
+			Order("album_tracks.disc_number").
+			Order("album_tracks.track_number").
+			Order("songs.id")
+	case filter.Before != nil:
+		// Ближайшие к ключу песни читаются в обратном порядке и затем разворачиваются
+		query = query.
+			Where("(songs.created_at < ? OR (songs.created_at = ? AND songs.id < ?))",
+				filter.Before.CreatedAt, filter.Before.CreatedAt, filter.Before.ID).
+			Order("songs.created_at DESC").
+			Order("songs.id DESC")
+		reversed = true
+	default:
+		if filter.After != nil {
+			query = query.Where("(songs.created_at > ? OR (songs.created_at = ? AND songs.id > ?))",
+				filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID)
+		}
+		query = query.
+			Order("songs.created_at").
+			Order("songs.id")
+	}
+
+	err := projectSong(query, projection).Offset(offset).Limit(limit).Find(&songs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if reversed {
+		slices.Reverse(songs)
+	}
+
+	return songs, total, nil
+}
+
+// exportedSong - песня вместе с названием исполнителя, прочитанным тем же запросом.
+type exportedSong struct {
+	models.Song
+	ArtistName string
+}
+
+func (r *SongRepository) Export(filter models.SongFilter, withText bool, fn func(song *models.Song) error) error {
+	columns := []string{
+		"songs.id", "songs.artist_id", "songs.song_title", "songs.release_date",
+		"songs.release_date_precision", "songs.link", "songs.created_at", "songs.updated_at",
+		"(SELECT name FROM artists WHERE artists.id = songs.artist_id) AS artist_name",
+	}
+	if withText {
+		columns = append(columns, "songs.text")
+	}
+
+	rows, err := orderSongs(r.filtered(filter).Select(columns), filter.Order).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row exportedSong
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		row.Song.GroupName = row.ArtistName
+		if err := fn(&row.Song); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *SongRepository) Facets(filter models.SongFilter) (*models.SongFacetsResponse, error) {
+	facets := &models.SongFacetsResponse{
+		Tags:   []models.FacetCount{},
+		Genres: []models.FacetCount{},
+	}
+
+	if err := r.filtered(filter).Count(&facets.Total).Error; err != nil {
+		return nil, err
+	}
+
+	err := r.db.Table("song_tags").
+		Select("tags.id, tags.name, COUNT(*) AS count").
+		Joins("JOIN tags ON tags.id = song_tags.tag_id").
+		Where("song_tags.song_id IN (?)", r.filtered(filter).Select("songs.id")).
+		Group("tags.id, tags.name").
+		Order("count DESC, tags.name").
+		Scan(&facets.Tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Table("song_genres").
+		Select("genres.id, genres.name, COUNT(*) AS count").
+		Joins("JOIN genres ON genres.id = song_genres.genre_id").
+		Where("song_genres.song_id IN (?)", r.filtered(filter).Select("songs.id")).
+		Group("genres.id, genres.name").
+		Order("count DESC, genres.name").
+		Scan(&facets.Genres).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// filtered строит запрос песен, подходящих под фильтр, без сортировки и пагинации.
+func (r *SongRepository) filtered(filter models.SongFilter) *gorm.DB {
 	query := r.db.Model(&models.Song{})
+	if filter.WithTrashed {
+		query = query.Unscoped()
+	}
+	like := likeOperator(r.db)
 
 	if filter.GroupName != "" {
-		query = query.Where("group_name ILIKE ?", "%"+filter.GroupName+"%")
+		name := models.NormalizeArtistName(filter.GroupName)
+		query = query.Joins("JOIN artists ON artists.id = songs.artist_id")
+		switch filter.Match {
+		case models.TextMatchExact:
+			query = query.Where("artists.normalized_name = ?", name)
+		case models.TextMatchPrefix:
+			query = query.Where(`artists.normalized_name LIKE ? ESCAPE '\'`, escapeLike(name)+"%")
+		default:
+			query = query.Where(`artists.normalized_name LIKE ? ESCAPE '\'`, "%"+escapeLike(name)+"%")
+		}
 	}
 	if filter.SongTitle != "" {
-		query = query.Where("song_title ILIKE ?", "%"+filter.SongTitle+"%")
+		switch filter.Match {
+		case models.TextMatchExact:
+			query = query.Where("LOWER(songs.song_title) = LOWER(?)", filter.SongTitle)
+		case models.TextMatchPrefix:
+			query = query.Where("songs.song_title "+like+` ? ESCAPE '\'`, escapeLike(filter.SongTitle)+"%")
+		default:
+			query = query.Where("songs.song_title "+like+` ? ESCAPE '\'`, "%"+escapeLike(filter.SongTitle)+"%")
+		}
+	}
+	if len(filter.IDs) > 0 {
+		query = query.Where("songs.id IN ?", filter.IDs)
+	}
+	if !filter.Released.IsZero() {
+		query = query.Where("songs.release_date_precision <> ''")
+	}
+	ranges := []struct {
+		column    string
+		timeRange models.TimeRange
+	}{
+		{"songs.release_date", filter.Released},
+		{"songs.created_at", filter.Created},
+		{"songs.updated_at", filter.Updated},
+	}
+	for _, r := range ranges {
+		if !r.timeRange.From.IsZero() {
+			query = query.Where(r.column+" >= ?", r.timeRange.From)
+		}
+		if !r.timeRange.Before.IsZero() {
+			query = query.Where(r.column+" < ?", r.timeRange.Before)
+		}
+	}
+	if filter.HasLyrics != nil {
+		query = query.Where(emptiness("songs.text", *filter.HasLyrics))
+	}
+	if filter.HasLink != nil {
+		query = query.Where(emptiness("songs.link", *filter.HasLink))
+	}
+	if filter.Enrichment != "" {
+		query = query.Where("songs.enrichment_status = ?", filter.Enrichment)
+	}
+	if filter.ArtistID != uuid.Nil {
+		query = query.Where("songs.artist_id = ?", filter.ArtistID)
+	}
+	if filter.AlbumID != uuid.Nil {
+		query = query.Where("songs.id IN (?)",
+			r.db.Table("album_tracks").Select("song_id").Where("album_id = ?", filter.AlbumID))
+	}
+	if len(filter.Tags) > 0 {
+		tagged := r.db.Table("song_tags").
+			Select("song_tags.song_id").
+			Joins("JOIN tags ON tags.id = song_tags.tag_id").
+			Where("tags.normalized_name IN ?", filter.Tags)
+		if filter.TagMatch == models.TagMatchAll {
+			// Названия в фильтре уникальны, поэтому совпадение по всем означает равенство числа строк
+			tagged = tagged.Group("song_tags.song_id").Having("COUNT(*) = ?", len(filter.Tags))
+		}
+		query = query.Where("songs.id IN (?)", tagged)
+	}
+	if len(filter.Genres) > 0 {
+		query = query.Where("songs.id IN (?)", r.db.Table("song_genres").
+			Select("song_genres.song_id").
+			Joins("JOIN genres ON genres.id = song_genres.genre_id").
+			Where("genres.normalized_name IN ?", filter.Genres))
+	}
+	if filter.Incomplete {
+		unlocked := func(field string) *gorm.DB {
+			return r.db.Table("song_field_sources").
+				Select("1").
+				Where("song_field_sources.song_id = songs.id AND song_field_sources.field = ? AND song_field_sources.locked", field)
+		}
+		query = query.Where(r.db.
+			Where("(songs.release_date IS NULL OR songs.release_date < ?) AND NOT EXISTS (?)", models.MinKnownReleaseDate, unlocked(models.SongFieldReleaseDate)).
+			Or("songs.text = '' AND NOT EXISTS (?)", unlocked(models.SongFieldText)).
+			Or("songs.link = '' AND NOT EXISTS (?)", unlocked(models.SongFieldLink)))
+	}
+	if !filter.StaleBefore.IsZero() {
+		query = query.Where("EXISTS (?)", r.db.Table("song_field_sources").
+			Select("1").
+			Where("song_field_sources.song_id = songs.id AND NOT song_field_sources.locked").
+			Where("song_field_sources.fetched_at < ?", filter.StaleBefore))
 	}
 
-	err := query.Count(&total).Error
+	return query
+}
+
+// preloadSong подгружает связи песни. prefix - путь до песни во вложенной
+// загрузке, например "Song." для записей плейлиста.
+func preloadSong(db *gorm.DB, prefix string) *gorm.DB {
+	byName := func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}
+	return db.
+		Preload(prefix+"Artist").
+		Preload(prefix+"Genres", byName).
+		Preload(prefix+"Tags", byName).
+		Preload(prefix + "Enrichment")
+}
+
+// projectSong читает только колонки проекции и подгружает только её связи.
+func projectSong(db *gorm.DB, projection models.SongProjection) *gorm.DB {
+	if len(projection.Columns) > 0 {
+		columns := []string{"songs.id"}
+		for _, column := range projection.Columns {
+			if column != "id" {
+				columns = append(columns, "songs."+column)
+			}
+		}
+		db = db.Select(columns)
+	}
+
+	byName := func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}
+	for _, relation := range models.SongRelations {
+		if !projection.Loads(relation) {
+			continue
+		}
+		switch relation {
+		case models.SongRelationGenres, models.SongRelationTags:
+			db = db.Preload(relation, byName)
+		default:
+			db = db.Preload(relation)
+		}
+	}
+	return db
+}
+
+func (r *SongRepository) GetSections(songID uuid.UUID) ([]models.LyricsSection, error) {
+	var sections []models.LyricsSection
+	err := r.db.Where("song_id = ?", songID).Order("position").Find(&sections).Error
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	return sections, nil
+}
+
+// backfillBatchSize - сколько песен размечается за один проход BackfillSections.
+const backfillBatchSize = 100
+
+func (r *SongRepository) BackfillSections() (int, error) {
+	var processed int
+	lastID := uuid.Nil
+
+	for {
+		var songs []models.Song
+		// Песни из корзины тоже размечаются, чтобы после восстановления у них были части
+		err := r.db.Unscoped().Select("id", "text").
+			Where("id > ? AND text <> ''", lastID).
+			Where("NOT EXISTS (SELECT 1 FROM lyrics_sections WHERE lyrics_sections.song_id = songs.id)").
+			Order("id").
+			Limit(backfillBatchSize).
+			Find(&songs).Error
+		if err != nil {
+			return processed, err
+		}
+		if len(songs) == 0 {
+			return processed, nil
+		}
+
+		for _, song := range songs {
+			err := r.db.Transaction(func(tx *gorm.DB) error {
+				// Песню могли изменить после выборки - размечаем текущий текст под блокировкой строки
+				var current models.Song
+				err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+					Select("id", "text").
+					First(&current, "id = ?", song.ID).Error
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				return replaceSections(tx, current.ID, current.Text)
+			})
+			if err != nil {
+				return processed, err
+			}
+			processed++
+		}
+		lastID = songs[len(songs)-1].ID
+	}
+}
+
+func (r *SongRepository) SetSyncedLines(songID uuid.UUID, lines []models.SyncedLine) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("song_id = ?", songID).Delete(&models.SyncedLine{}).Error; err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return nil
+		}
+		for i := range lines {
+			lines[i].SongID = songID
+		}
+		return tx.CreateInBatches(&lines, 500).Error
+	})
+}
+
+func (r *SongRepository) GetSyncedLines(songID uuid.UUID) ([]models.SyncedLine, error) {
+	var lines []models.SyncedLine
+	err := r.db.Where("song_id = ?", songID).Order("line_no").Find(&lines).Error
+	if err != nil {
+		return nil, err
 	}
+	return lines, nil
+}
 
-	err = query.Offset(offset).Limit(limit).Find(&songs).Error
+func (r *SongRepository) GetSyncedLinesBetween(songID uuid.UUID, fromMs, toMs int64) ([]models.SyncedLine, error) {
+	var lines []models.SyncedLine
+	err := r.db.
+		Where("song_id = ? AND text <> ''", songID).
+		Where("start_ms < ? AND (end_ms IS NULL OR end_ms > ?)", toMs, fromMs).
+		Order("line_no").
+		Find(&lines).Error
 	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// replaceSections заменяет части текста песни на результат разбора text.
+func replaceSections(tx *gorm.DB, songID uuid.UUID, text string) error {
+	if err := tx.Where("song_id = ?", songID).Delete(&models.LyricsSection{}).Error; err != nil {
+		return err
+	}
+
+	sections := lyrics.Parse(text)
+	if len(sections) == 0 {
+		return nil
+	}
+	for i := range sections {
+		sections[i].SongID = songID
+	}
+	return tx.Create(&sections).Error
+}
+
+// likeOperator возвращает оператор регистронезависимого сравнения для диалекта БД.
+// В SQLite LIKE и так не учитывает регистр, а ILIKE не поддерживается.
+func likeOperator(db *gorm.DB) string {
+	if db.Dialector.Name() == "postgres" {
+		return "ILIKE"
+	}
+	return "LIKE"
+}
+
+// escapeLike экранирует в образце LIKE символы \, % и _, чтобы они совпадали
+// буквально; условие должно заканчиваться на ESCAPE '\'.
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// emptiness возвращает условие на непустую колонку, если filled, и на пустую иначе.
+func emptiness(column string, filled bool) string {
+	if filled {
+		return column + " <> ''"
+	}
+	return column + " = ''"
+}
+
+// orderSongs сортирует песни по полям order, а при равенстве - в порядке добавления.
+func orderSongs(query *gorm.DB, order []models.SongOrder) *gorm.DB {
+	for _, o := range order {
+		direction := ""
+		if o.Desc {
+			direction = " DESC"
+		}
+		switch o.Field {
+		case models.SortSong:
+			query = query.Order("LOWER(songs.song_title)" + direction)
+		case models.SortGroup:
+			query = query.Order("(SELECT artists.normalized_name FROM artists WHERE artists.id = songs.artist_id)" + direction)
+		case models.SortReleaseDate:
+			// Неизвестная дата хранится без точности; такие песни идут последними
+			query = query.
+				Order("songs.release_date_precision = ''").
+				Order("songs.release_date" + direction)
+		case models.SortCreatedAt:
+			query = query.Order("songs.created_at" + direction)
+		case models.SortUpdatedAt:
+			query = query.Order("songs.updated_at" + direction)
+		}
+	}
+	return query.
+		Order("songs.created_at").
+		Order("songs.id")
+}
+
+// searchQuery ранжирует песни по tsvector и для каждой песни на странице
+// находит части текста с совпадениями. Номера частей те же, что в /lyrics;
+// повторы припева пропускаются, чтобы совпадение не дублировалось.
+const searchQuery = `
+WITH q AS (
+    SELECT websearch_to_tsquery('russian', @query) || websearch_to_tsquery('english', @query) AS query
+), ranked AS (
+    SELECT s.*, ts_rank_cd(s.search_vector, q.query) AS rank
+    FROM songs s, q
+    WHERE s.search_vector @@ q.query
+        AND s.deleted_at IS NULL
+    ORDER BY rank DESC, s.id
+    LIMIT @limit OFFSET @offset
+)
+SELECT r.*,
+    ts_headline('russian', r.song_title, q.query, @titleHeadline) AS title_snippet,
+    v.verse_index,
+    v.snippet
+FROM ranked r
+CROSS JOIN q
+LEFT JOIN LATERAL (
+    SELECT ls.position AS verse_index,
+        ts_headline('russian', ls.text, q.query, @verseHeadline) AS snippet
+    FROM lyrics_sections ls
+    WHERE ls.song_id = r.id
+        AND ls.repeat_of IS NULL
+        AND (to_tsvector('russian', ls.text) || to_tsvector('english', ls.text)) @@ q.query
+) v ON true
+ORDER BY r.rank DESC, r.id, v.verse_index`
+
+const searchCountQuery = `
+SELECT count(*)
+FROM songs
+WHERE search_vector @@ (websearch_to_tsquery('russian', @query) || websearch_to_tsquery('english', @query))
+    AND deleted_at IS NULL`
+
+type searchRow struct {
+	models.Song
+	Rank         float64
+	TitleSnippet string
+	VerseIndex   *int
+	Snippet      *string
+}
+
+func (r *SongRepository) Search(query string, offset, limit int) ([]models.SongSearchResult, int64, error) {
+	if r.db.Dialector.Name() != "postgres" {
+		return r.searchFallback(query, offset, limit)
+	}
+
+	// Метки совпадений заменяются на <mark> после экранирования, см. headline
+	args := map[string]interface{}{
+		"query":         query,
+		"limit":         limit,
+		"offset":        offset,
+		"titleHeadline": "HighlightAll=true, StartSel=" + headlineStart + ", StopSel=" + headlineStop,
+		"verseHeadline": "StartSel=" + headlineStart + ", StopSel=" + headlineStop + ", MaxFragments=2",
+	}
+
+	var total int64
+	if err := r.db.Raw(searchCountQuery, args).Scan(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	return songs, total, nil
+	var rows []searchRow
+	if err := r.db.Raw(searchQuery, args).Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.attachRelations(rows); err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]models.SongSearchResult, 0, limit)
+	for _, row := range rows {
+		if len(results) == 0 || results[len(results)-1].Song.ID != row.ID {
+			results = append(results, models.SongSearchResult{
+				Song:         row.Song,
+				Rank:         row.Rank,
+				TitleSnippet: headline(row.TitleSnippet),
+				Matches:      []models.VerseMatch{},
+			})
+		}
+		if row.VerseIndex != nil && row.Snippet != nil {
+			last := &results[len(results)-1]
+			last.Matches = append(last.Matches, models.VerseMatch{
+				Verse:   *row.VerseIndex,
+				Snippet: headline(*row.Snippet),
+			})
+		}
+	}
+
+	return results, total, nil
+}
+
+// attachRelations подгружает исполнителей, жанры и теги для строк, полученных сырым SQL.
+func (r *SongRepository) attachRelations(rows []searchRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	var songs []models.Song
+	if err := preloadSong(r.db.Select("id", "artist_id"), "").Where("id IN ?", ids).Find(&songs).Error; err != nil {
+		return err
+	}
+
+	byID := make(map[uuid.UUID]*models.Song, len(songs))
+	for i := range songs {
+		byID[songs[i].ID] = &songs[i]
+	}
+	for i := range rows {
+		if song, ok := byID[rows[i].ID]; ok {
+			rows[i].Artist = song.Artist
+			rows[i].GroupName = song.GroupName
+			rows[i].Genres = song.Genres
+			rows[i].Tags = song.Tags
+		}
+	}
+	return nil
+}
+
+// searchFallback ищет по подстрокам для баз без tsvector (SQLite).
+// Кандидаты отбираются через LIKE, ранжирование выполняется в Go.
+func (r *SongRepository) searchFallback(query string, offset, limit int) ([]models.SongSearchResult, int64, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []models.SongSearchResult{}, 0, nil
+	}
+
+	candidates := r.db.Model(&models.Song{})
+	like := likeOperator(r.db)
+	for _, term := range terms {
+		pattern := "%" + term + "%"
+		candidates = candidates.Where("(song_title "+like+" ? OR text "+like+" ?)", pattern, pattern)
+	}
+
+	var songs []models.Song
+	if err := preloadSong(candidates, "").Find(&songs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]models.SongSearchResult, 0, len(songs))
+	for _, song := range songs {
+		if result, ok := matchSong(song, terms); ok {
+			results = append(results, result)
+		}
+	}
+	rankResults(results)
+
+	return paginate(results, offset, limit), int64(len(results)), nil
 }
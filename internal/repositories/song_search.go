@@ -0,0 +1,131 @@
+// song_search.go
+package repositories
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"song_library/internal/lyrics"
+	"song_library/internal/models"
+)
+
+const (
+	highlightStart = "<mark>"
+	highlightStop  = "</mark>"
+
+	// headlineStart и headlineStop отмечают совпадения в выводе ts_headline:
+	// текст песни экранируется уже после PostgreSQL, и только потом они заменяются на <mark>
+	headlineStart = "\x02"
+	headlineStop  = "\x03"
+)
+
+// searchTerms разбивает поисковый запрос на слова в нижнем регистре.
+// Используется там, где нет полнотекстового поиска PostgreSQL (SQLite и память).
+func searchTerms(query string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	terms := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field != "" {
+			terms = append(terms, field)
+		}
+	}
+	return terms
+}
+
+// matchSong проверяет песню на вхождение всех слов запроса и строит результат поиска.
+// Слова в названии весят больше, чем слова в тексте, как и в tsvector.
+func matchSong(song models.Song, terms []string) (models.SongSearchResult, bool) {
+	title := strings.ToLower(song.SongTitle)
+	text := strings.ToLower(song.Text)
+
+	var rank float64
+	for _, term := range terms {
+		inTitle := strings.Count(title, term)
+		inText := strings.Count(text, term)
+		if inTitle == 0 && inText == 0 {
+			return models.SongSearchResult{}, false
+		}
+		rank += float64(inTitle) + 0.4*float64(inText)
+	}
+
+	titleSnippet, _ := highlight(song.SongTitle, terms)
+	result := models.SongSearchResult{
+		Song:         song,
+		Rank:         rank,
+		TitleSnippet: titleSnippet,
+		Matches:      []models.VerseMatch{},
+	}
+
+	for _, section := range lyrics.Parse(song.Text) {
+		if section.RepeatOf != nil {
+			continue
+		}
+		if snippet, found := highlight(section.Text, terms); found {
+			result.Matches = append(result.Matches, models.VerseMatch{
+				Verse:   section.Position,
+				Snippet: snippet,
+			})
+		}
+	}
+
+	return result, true
+}
+
+// rankResults сортирует результаты по убыванию релевантности.
+func rankResults(results []models.SongSearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank == results[j].Rank {
+			return results[i].Song.ID.String() < results[j].Song.ID.String()
+		}
+		return results[i].Rank > results[j].Rank
+	})
+}
+
+// highlight экранирует s для HTML и оборачивает вхождения слов запроса в <mark>;
+// found сообщает, нашлось ли хоть одно вхождение.
+// Сравнение идёт по рунам, чтобы не сбиться на многобайтовых символах.
+func highlight(s string, terms []string) (snippet string, found bool) {
+	runes := []rune(s)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(runes))
+	for _, term := range terms {
+		termRunes := []rune(term)
+		for i := 0; i+len(termRunes) <= len(lower); i++ {
+			if string(lower[i:i+len(termRunes)]) == term {
+				found = true
+				for j := i; j < i+len(termRunes); j++ {
+					marked[j] = true
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, r := range runes {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			b.WriteString(highlightStart)
+		}
+		b.WriteString(html.EscapeString(string(r)))
+		if marked[i] && (i == len(runes)-1 || !marked[i+1]) {
+			b.WriteString(highlightStop)
+		}
+	}
+	return b.String(), found
+}
+
+// headline экранирует для HTML фрагмент, выделенный ts_headline, и заменяет
+// метки headlineStart и headlineStop на <mark>.
+func headline(s string) string {
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, headlineStart, highlightStart)
+	return strings.ReplaceAll(s, headlineStop, highlightStop)
+}
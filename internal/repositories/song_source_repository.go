@@ -0,0 +1,67 @@
+// song_source_repository.go
+package repositories
+
+import (
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SongSourceRepository - реализация SongSourceStore поверх GORM.
+type SongSourceRepository struct {
+	db *gorm.DB
+}
+
+func NewSongSourceRepository(db *gorm.DB) *SongSourceRepository {
+	return &SongSourceRepository{
+		db: db,
+	}
+}
+
+func (r *SongSourceRepository) GetBySong(songID uuid.UUID) ([]models.SongFieldSource, error) {
+	var sources []models.SongFieldSource
+	err := r.db.Where("song_id = ?", songID).Order("field").Find(&sources).Error
+	return sources, err
+}
+
+func (r *SongSourceRepository) GetBySongs(songIDs []uuid.UUID) (map[uuid.UUID][]models.SongFieldSource, error) {
+	var sources []models.SongFieldSource
+	if err := r.db.Where("song_id IN ?", songIDs).Order("field").Find(&sources).Error; err != nil {
+		return nil, err
+	}
+	bySong := make(map[uuid.UUID][]models.SongFieldSource, len(songIDs))
+	for _, source := range sources {
+		bySong[source.SongID] = append(bySong[source.SongID], source)
+	}
+	return bySong, nil
+}
+
+func (r *SongSourceRepository) Save(sources []models.SongFieldSource) error {
+	if len(sources) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "song_id"}, {Name: "field"}},
+		DoUpdates: clause.AssignmentColumns([]string{"provider", "fetched_at", "payload", "updated_at"}),
+	}).Omit("locked").Create(&sources).Error
+}
+
+func (r *SongSourceRepository) SetLocked(songID uuid.UUID, fields []string, locked bool) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	sources := make([]models.SongFieldSource, len(fields))
+	for i, field := range fields {
+		sources[i] = models.SongFieldSource{SongID: songID, Field: field, Locked: locked, UpdatedAt: now}
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "song_id"}, {Name: "field"}},
+		DoUpdates: clause.AssignmentColumns([]string{"locked", "updated_at"}),
+	}).Create(&sources).Error
+}
@@ -0,0 +1,20 @@
+// song_source_store.go
+package repositories
+
+import (
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SongSourceStore хранит происхождение полей песен и их блокировки.
+type SongSourceStore interface {
+	// GetBySong возвращает записи полей песни по названию поля.
+	GetBySong(songID uuid.UUID) ([]models.SongFieldSource, error)
+	// GetBySongs возвращает записи полей каждой из песен по названию поля.
+	GetBySongs(songIDs []uuid.UUID) (map[uuid.UUID][]models.SongFieldSource, error)
+	// Save сохраняет источник, время и ответ полей; блокировки не меняются.
+	Save(sources []models.SongFieldSource) error
+	// SetLocked включает или снимает блокировку полей песни.
+	SetLocked(songID uuid.UUID, fields []string, locked bool) error
+}
@@ -0,0 +1,74 @@
+// song_store.go
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound = errors.New("запись не найдена")
+	// ErrEntryNotFound - не найдена вложенная запись: позиция плейлиста, жанр или тег песни
+	ErrEntryNotFound = errors.New("связанная запись не найдена")
+	// ErrConflict - запись нарушает уникальность: например, альбом с тем же названием
+	// у исполнителя или уже занятая позиция в трек-листе
+	ErrConflict = errors.New("запись уже существует")
+)
+
+// SongStore описывает хранилище песен независимо от конкретной базы данных.
+// При создании и обновлении песни хранилище само разбирает текст на части
+// (lyrics.Parse), поэтому части всегда соответствуют тексту.
+type SongStore interface {
+	Create(song *models.Song) error
+	GetByID(id uuid.UUID) (*models.Song, error)
+	// Update сохраняет песню, кроме статуса обогащения; ErrNotFound, если песни нет
+	// или она в корзине. Изменение текста удаляет синхронизацию LRC песни
+	// и переносит части переводов на части нового текста.
+	Update(song *models.Song) error
+	// UpdateFields сохраняет только перечисленные поля из models.LockableSongFields,
+	// не затирая остальные колонки, изменённые с момента чтения песни.
+	UpdateFields(song *models.Song, fields []string) error
+	// GetForUpdate читает песню, как GetByID, и в транзакции блокирует её
+	// от изменения другими транзакциями до своего завершения.
+	GetForUpdate(id uuid.UUID) (*models.Song, error)
+	// SetEnrichmentStatus меняет статус обогащения песни, в том числе песни в корзине.
+	SetEnrichmentStatus(id uuid.UUID, status models.EnrichmentStatus) error
+	// Delete перемещает песню в корзину; песни из корзины не возвращаются
+	// остальными методами, пока их не восстановят.
+	Delete(id uuid.UUID) error
+	// GetTrash возвращает песни из корзины, начиная с удалённых последними.
+	GetTrash(offset, limit int) ([]models.Song, int64, error)
+	// Restore возвращает песню из корзины; ErrNotFound, если её там нет.
+	Restore(id uuid.UUID) error
+	// Purge окончательно удаляет песни, попавшие в корзину раньше before, и возвращает их число.
+	Purge(before time.Time) (int64, error)
+	// GetProjected возвращает песню только с колонками и связями проекции.
+	GetProjected(id uuid.UUID, projection models.SongProjection) (*models.Song, error)
+	// GetAll возвращает страницу песен под фильтром с колонками и связями проекции
+	// и общее число таких песен. Песни идут в порядке добавления (models.SongKey),
+	// а с фильтром по альбому - в порядке трек-листа. На страницах по ключу
+	// (filter.After или filter.Before) песни не пересчитываются, и число равно -1.
+	GetAll(filter models.SongFilter, projection models.SongProjection, offset, limit int) ([]models.Song, int64, error)
+	// Export передаёт fn песни под фильтром по одной в порядке добавления, читая их
+	// курсором базы данных, чтобы выгрузка всей библиотеки не держала её в памяти.
+	// Жанры и теги не загружаются; текст читается, только если withText.
+	Export(filter models.SongFilter, withText bool, fn func(song *models.Song) error) error
+	Search(query string, offset, limit int) ([]models.SongSearchResult, int64, error)
+	// Facets считает, сколько песен под фильтром отмечено каждым тегом и жанром.
+	Facets(filter models.SongFilter) (*models.SongFacetsResponse, error)
+
+	// GetSections возвращает части текста песни по порядку.
+	GetSections(songID uuid.UUID) ([]models.LyricsSection, error)
+	// BackfillSections размечает песни, сохранённые до появления частей, и возвращает их число.
+	BackfillSections() (int, error)
+
+	// SetSyncedLines заменяет строки LRC песни; пустой список удаляет синхронизацию.
+	SetSyncedLines(songID uuid.UUID, lines []models.SyncedLine) error
+	GetSyncedLines(songID uuid.UUID) ([]models.SyncedLine, error)
+	// GetSyncedLinesBetween возвращает непустые строки, звучащие в окне [fromMs, toMs).
+	GetSyncedLinesBetween(songID uuid.UUID, fromMs, toMs int64) ([]models.SyncedLine, error)
+}
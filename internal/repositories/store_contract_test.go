@@ -0,0 +1,355 @@
+// store_contract_test.go
+package repositories
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"song_library/internal/migrations"
+	"song_library/internal/models"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// backend - хранилища одного бэкенда и транзакции над ними.
+type backend struct {
+	stores *Stores
+	tx     Transactor
+}
+
+// backends открывают пустые хранилища, которые должны вести себя одинаково.
+var backends = map[string]func(t *testing.T) backend{
+	"memory": func(t *testing.T) backend {
+		storage := NewMemoryStorage()
+		return backend{stores: NewMemoryStores(storage), tx: NewMemoryTransactor(storage)}
+	},
+	"sqlite": func(t *testing.T) backend {
+		dsn := filepath.Join(t.TempDir(), "songs.db") + "?_pragma=foreign_keys(1)"
+		db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+			TranslateError: true,
+			Logger:         logger.Default.LogMode(logger.Silent),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			if sqlDB, err := db.DB(); err == nil {
+				sqlDB.Close()
+			}
+		})
+
+		migrator, err := migrations.NewMigrator(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := migrator.Up(); err != nil {
+			t.Fatalf("миграции: %v", err)
+		}
+		return backend{stores: NewStores(db), tx: NewDBTransactor(db)}
+	},
+}
+
+var errRollback = errors.New("откат")
+
+func TestStoreContract(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, b backend)
+	}{
+		{
+			name: "песня читается такой, какой сохранена",
+			run: func(t *testing.T, b backend) {
+				date, _ := models.ParseReleaseDate("2006-07")
+				song := addSong(t, b, "Muse", "Uprising", "one\n\ntwo", func(s *models.Song) {
+					s.ReleaseDate = date
+					s.Link = "https://example.com/uprising"
+				})
+
+				got := getSong(t, b, song.ID)
+				if got.GroupName != "Muse" || got.SongTitle != "Uprising" || got.Link != song.Link ||
+					!got.ReleaseDate.Equal(date) || got.EnrichmentStatus != models.EnrichmentPending {
+					t.Errorf("GetByID = %+v, ожидалась сохранённая песня", got)
+				}
+				if sections := getSections(t, b, song.ID); len(sections) != 2 {
+					t.Errorf("частей текста %d, ожидалось 2", len(sections))
+				}
+			},
+		},
+		{
+			name: "Update не меняет статус обогащения и сбрасывает LRC при смене текста",
+			run: func(t *testing.T, b backend) {
+				song := addSong(t, b, "Muse", "Uprising", "one", nil)
+				if err := b.stores.Songs.SetSyncedLines(song.ID, []models.SyncedLine{{LineNo: 1, StartMs: 1000, Text: "one", Verse: 1}}); err != nil {
+					t.Fatal(err)
+				}
+				if err := b.stores.Songs.SetEnrichmentStatus(song.ID, models.EnrichmentEnriched); err != nil {
+					t.Fatal(err)
+				}
+
+				// song прочитана до смены статуса и несёт устаревший pending
+				song.Text = "one\n\nthree"
+				if err := b.stores.Songs.Update(song); err != nil {
+					t.Fatalf("Update: %v", err)
+				}
+
+				got := getSong(t, b, song.ID)
+				if got.EnrichmentStatus != models.EnrichmentEnriched || got.Text != song.Text {
+					t.Errorf("после Update статус %q и текст %q", got.EnrichmentStatus, got.Text)
+				}
+				if lines, err := b.stores.Songs.GetSyncedLines(song.ID); err != nil || len(lines) != 0 {
+					t.Errorf("строки LRC после смены текста: %v, %v", lines, err)
+				}
+				if sections := getSections(t, b, song.ID); len(sections) != 2 {
+					t.Errorf("частей текста %d, ожидалось 2", len(sections))
+				}
+			},
+		},
+		{
+			name: "Update не создаёт и не восстанавливает песни",
+			run: func(t *testing.T, b backend) {
+				missing := &models.Song{ID: uuid.New(), ArtistID: addArtist(t, b, "Muse").ID, SongTitle: "Missing"}
+				if err := b.stores.Songs.Update(missing); !errors.Is(err, ErrNotFound) {
+					t.Errorf("Update несуществующей песни = %v, ожидалась ErrNotFound", err)
+				}
+				if _, err := b.stores.Songs.GetByID(missing.ID); !errors.Is(err, ErrNotFound) {
+					t.Errorf("GetByID после Update несуществующей песни = %v, ожидалась ErrNotFound", err)
+				}
+
+				song := addSong(t, b, "Muse", "Uprising", "one", nil)
+				if err := b.stores.Songs.Delete(song.ID); err != nil {
+					t.Fatal(err)
+				}
+				song.Text = "changed"
+				if err := b.stores.Songs.Update(song); !errors.Is(err, ErrNotFound) {
+					t.Errorf("Update песни в корзине = %v, ожидалась ErrNotFound", err)
+				}
+				if _, total, err := b.stores.Songs.GetTrash(0, 10); err != nil || total != 1 {
+					t.Errorf("в корзине %d песен (%v), ожидалась 1", total, err)
+				}
+			},
+		},
+		{
+			name: "UpdateFields сохраняет только перечисленные поля",
+			run: func(t *testing.T, b backend) {
+				song := addSong(t, b, "Muse", "Uprising", "one", nil)
+				song.SongTitle = "Stale title"
+				song.Link = "https://example.com/new"
+				if err := b.stores.Songs.UpdateFields(song, []string{models.SongFieldLink}); err != nil {
+					t.Fatalf("UpdateFields: %v", err)
+				}
+
+				got := getSong(t, b, song.ID)
+				if got.SongTitle != "Uprising" || got.Link != song.Link {
+					t.Errorf("после UpdateFields название %q и ссылка %q", got.SongTitle, got.Link)
+				}
+			},
+		},
+		{
+			name: "корзина",
+			run: func(t *testing.T, b backend) {
+				song := addSong(t, b, "Muse", "Uprising", "one", nil)
+				if err := b.stores.Songs.Delete(song.ID); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := b.stores.Songs.GetByID(song.ID); !errors.Is(err, ErrNotFound) {
+					t.Errorf("GetByID песни в корзине = %v, ожидалась ErrNotFound", err)
+				}
+				if _, err := b.stores.Songs.GetForUpdate(song.ID); !errors.Is(err, ErrNotFound) {
+					t.Errorf("GetForUpdate песни в корзине = %v, ожидалась ErrNotFound", err)
+				}
+				if err := b.stores.Songs.Delete(song.ID); !errors.Is(err, ErrNotFound) {
+					t.Errorf("повторный Delete = %v, ожидалась ErrNotFound", err)
+				}
+				if err := b.stores.Songs.SetEnrichmentStatus(song.ID, models.EnrichmentFailed); err != nil {
+					t.Errorf("SetEnrichmentStatus песни в корзине: %v", err)
+				}
+
+				if err := b.stores.Songs.Restore(song.ID); err != nil {
+					t.Fatalf("Restore: %v", err)
+				}
+				if err := b.stores.Songs.Restore(song.ID); !errors.Is(err, ErrNotFound) {
+					t.Errorf("Restore песни не из корзины = %v, ожидалась ErrNotFound", err)
+				}
+				if got := getSong(t, b, song.ID); got.EnrichmentStatus != models.EnrichmentFailed {
+					t.Errorf("статус после восстановления %q, ожидался failed", got.EnrichmentStatus)
+				}
+
+				if err := b.stores.Songs.Delete(song.ID); err != nil {
+					t.Fatal(err)
+				}
+				if purged, err := b.stores.Songs.Purge(time.Now().Add(time.Minute)); err != nil || purged != 1 {
+					t.Errorf("Purge = %d, %v, ожидалась 1 песня", purged, err)
+				}
+				if _, total, _ := b.stores.Songs.GetTrash(0, 10); total != 0 {
+					t.Errorf("после Purge в корзине %d песен", total)
+				}
+			},
+		},
+		{
+			name: "транзакция с ошибкой откатывается",
+			run: func(t *testing.T, b backend) {
+				song := addSong(t, b, "Muse", "Uprising", "one", nil)
+
+				var created uuid.UUID
+				err := b.tx.Transaction(func(stores *Stores) error {
+					artist := &models.Artist{ID: uuid.New(), Name: "Kino", NormalizedName: models.NormalizeArtistName("Kino")}
+					if err := stores.Artists.Create(artist); err != nil {
+						return err
+					}
+					created = uuid.New()
+					newSong := &models.Song{ID: created, ArtistID: artist.ID, SongTitle: "Gruppa krovi", Text: "a"}
+					if err := stores.Songs.Create(newSong); err != nil {
+						return err
+					}
+
+					current, err := stores.Songs.GetForUpdate(song.ID)
+					if err != nil {
+						return err
+					}
+					current.Text = "changed"
+					if err := stores.Songs.Update(current); err != nil {
+						return err
+					}
+					return errRollback
+				})
+				if !errors.Is(err, errRollback) {
+					t.Fatalf("Transaction = %v, ожидалась ошибка fn", err)
+				}
+
+				if _, err := b.stores.Songs.GetByID(created); !errors.Is(err, ErrNotFound) {
+					t.Errorf("песня из отменённой транзакции: %v", err)
+				}
+				if _, err := b.stores.Artists.GetByNormalizedName("kino"); !errors.Is(err, ErrNotFound) {
+					t.Errorf("исполнитель из отменённой транзакции: %v", err)
+				}
+				if got := getSong(t, b, song.ID); got.Text != "one" {
+					t.Errorf("текст после отката %q, ожидался one", got.Text)
+				}
+				if sections := getSections(t, b, song.ID); len(sections) != 1 || sections[0].Text != "one" {
+					t.Errorf("части текста после отката: %+v", sections)
+				}
+			},
+		},
+		{
+			name: "исполнитель с тем же нормализованным названием",
+			run: func(t *testing.T, b backend) {
+				addArtist(t, b, "Кино")
+				duplicate := &models.Artist{ID: uuid.New(), Name: "КИНО ", NormalizedName: models.NormalizeArtistName("КИНО ")}
+				if err := b.stores.Artists.Create(duplicate); !errors.Is(err, ErrConflict) {
+					t.Errorf("Create дубликата = %v, ожидалась ErrConflict", err)
+				}
+			},
+		},
+		{
+			name: "фильтр по названию не считает % и _ шаблоном",
+			run: func(t *testing.T, b backend) {
+				base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				for i, title := range []string{"100% Love", "1000 Love", "a_b", "axb"} {
+					addSong(t, b, "Muse", title, "", func(s *models.Song) {
+						s.CreatedAt = base.Add(time.Duration(i) * time.Second)
+					})
+				}
+
+				tests := []struct {
+					filter models.SongFilter
+					want   []string
+				}{
+					{filter: models.SongFilter{SongTitle: "100%", Match: models.TextMatchContains}, want: []string{"100% Love"}},
+					{filter: models.SongFilter{SongTitle: "A_", Match: models.TextMatchPrefix}, want: []string{"a_b"}},
+					{filter: models.SongFilter{SongTitle: "AXB", Match: models.TextMatchExact}, want: []string{"axb"}},
+					{filter: models.SongFilter{GroupName: "mu", Match: models.TextMatchPrefix}, want: []string{"100% Love", "1000 Love", "a_b", "axb"}},
+				}
+				for _, tt := range tests {
+					songs, total, err := b.stores.Songs.GetAll(tt.filter, models.SongProjection{}, 0, 10)
+					if err != nil {
+						t.Fatal(err)
+					}
+					titles := make([]string, 0, len(songs))
+					for _, song := range songs {
+						titles = append(titles, song.SongTitle)
+					}
+					if total != int64(len(tt.want)) || !equalStrings(titles, tt.want) {
+						t.Errorf("GetAll(group %q, song %q, %s) = %v (всего %d), ожидалось %v",
+							tt.filter.GroupName, tt.filter.SongTitle, tt.filter.Match, titles, total, tt.want)
+					}
+				}
+			},
+		},
+	}
+
+	for name, open := range backends {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				tt.run(t, open(t))
+			})
+		}
+	}
+}
+
+func addArtist(t *testing.T, b backend, name string) *models.Artist {
+	t.Helper()
+	artist, err := b.stores.Artists.GetByNormalizedName(models.NormalizeArtistName(name))
+	if err == nil {
+		return artist
+	}
+	artist = &models.Artist{ID: uuid.New(), Name: name, NormalizedName: models.NormalizeArtistName(name)}
+	if err := b.stores.Artists.Create(artist); err != nil {
+		t.Fatalf("Create исполнителя: %v", err)
+	}
+	return artist
+}
+
+// addSong сохраняет песню исполнителя group; edit может поменять поля перед сохранением.
+func addSong(t *testing.T, b backend, group, title, text string, edit func(song *models.Song)) *models.Song {
+	t.Helper()
+	artist := addArtist(t, b, group)
+	song := &models.Song{
+		ID:               uuid.New(),
+		ArtistID:         artist.ID,
+		SongTitle:        title,
+		Text:             text,
+		EnrichmentStatus: models.EnrichmentPending,
+	}
+	if edit != nil {
+		edit(song)
+	}
+	if err := b.stores.Songs.Create(song); err != nil {
+		t.Fatalf("Create песни: %v", err)
+	}
+	return song
+}
+
+func getSong(t *testing.T, b backend, id uuid.UUID) *models.Song {
+	t.Helper()
+	song, err := b.stores.Songs.GetByID(id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	return song
+}
+
+func getSections(t *testing.T, b backend, id uuid.UUID) []models.LyricsSection {
+	t.Helper()
+	sections, err := b.stores.Songs.GetSections(id)
+	if err != nil {
+		t.Fatalf("GetSections: %v", err)
+	}
+	return sections
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
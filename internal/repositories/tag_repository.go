@@ -0,0 +1,98 @@
+// tag_repository.go
+package repositories
+
+import (
+	"errors"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository - реализация TagStore поверх GORM.
+type TagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{
+		db: db,
+	}
+}
+
+func (r *TagRepository) GetByNormalizedName(normalizedName string) (*models.Tag, error) {
+	var tag models.Tag
+	result := r.db.Where("normalized_name = ?", normalizedName).First(&tag)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, result.Error
+	}
+	return &tag, nil
+}
+
+// FindOrCreate вставляет недостающие теги с ON CONFLICT DO NOTHING, поэтому
+// параллельные запросы с одним и тем же новым тегом не падают на уникальном индексе.
+func (r *TagRepository) FindOrCreate(names []string) ([]models.Tag, error) {
+	if len(names) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	normalized := make([]string, 0, len(names))
+	candidates := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		normalizedName := models.NormalizeTagName(name)
+		normalized = append(normalized, normalizedName)
+		candidates = append(candidates, models.Tag{
+			ID:             uuid.New(),
+			Name:           name,
+			NormalizedName: normalizedName,
+		})
+	}
+
+	var tags []models.Tag
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "normalized_name"}},
+			DoNothing: true,
+		}).Create(&candidates).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("normalized_name IN ?", normalized).Order("name").Find(&tags).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepository) AddToSong(songID uuid.UUID, tagIDs []uuid.UUID) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]map[string]interface{}, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		rows = append(rows, map[string]interface{}{"song_id": songID, "tag_id": tagID})
+	}
+
+	return r.db.Table("song_tags").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(rows).Error
+}
+
+func (r *TagRepository) RemoveFromSong(songID, tagID uuid.UUID) error {
+	result := r.db.Exec("DELETE FROM song_tags WHERE song_id = ? AND tag_id = ?", songID, tagID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+// tag_store.go
+package repositories
+
+import (
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// TagStore описывает хранилище произвольных тегов песен.
+type TagStore interface {
+	GetByNormalizedName(normalizedName string) (*models.Tag, error)
+	// FindOrCreate возвращает теги с указанными названиями, создавая недостающие.
+	// Названия уже должны быть очищены и не повторяться после нормализации.
+	FindOrCreate(names []string) ([]models.Tag, error)
+
+	// AddToSong назначает теги песне; уже назначенные теги пропускаются.
+	AddToSong(songID uuid.UUID, tagIDs []uuid.UUID) error
+	// RemoveFromSong возвращает ErrEntryNotFound, если тег не был назначен песне.
+	RemoveFromSong(songID, tagID uuid.UUID) error
+}
@@ -0,0 +1,60 @@
+// transactor.go
+package repositories
+
+import (
+	"gorm.io/gorm"
+)
+
+// Stores - набор хранилищ одного бэкенда.
+type Stores struct {
+	Songs        SongStore
+	Artists      ArtistStore
+	Albums       AlbumStore
+	Playlists    PlaylistStore
+	Genres       GenreStore
+	Tags         TagStore
+	Translations TranslationStore
+	Revisions    RevisionStore
+	Enrichment   EnrichmentStore
+	Sources      SongSourceStore
+}
+
+// Transactor выполняет несколько изменений атомарно: fn получает хранилища,
+// работающие в одной транзакции, и если fn вернула ошибку, все изменения,
+// сделанные через них, откатываются.
+type Transactor interface {
+	Transaction(fn func(stores *Stores) error) error
+}
+
+// NewStores возвращает хранилища поверх GORM.
+func NewStores(db *gorm.DB) *Stores {
+	return &Stores{
+		Songs:        NewSongRepository(db),
+		Artists:      NewArtistRepository(db),
+		Albums:       NewAlbumRepository(db),
+		Playlists:    NewPlaylistRepository(db),
+		Genres:       NewGenreRepository(db),
+		Tags:         NewTagRepository(db),
+		Translations: NewTranslationRepository(db),
+		Revisions:    NewRevisionRepository(db),
+		Enrichment:   NewEnrichmentRepository(db),
+		Sources:      NewSongSourceRepository(db),
+	}
+}
+
+// DBTransactor - реализация Transactor поверх транзакций базы данных.
+type DBTransactor struct {
+	db *gorm.DB
+}
+
+func NewDBTransactor(db *gorm.DB) *DBTransactor {
+	return &DBTransactor{
+		db: db,
+	}
+}
+
+func (t *DBTransactor) Transaction(fn func(stores *Stores) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewStores(tx))
+	})
+}
@@ -0,0 +1,79 @@
+// translation_repository.go
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TranslationRepository - реализация TranslationStore поверх GORM.
+type TranslationRepository struct {
+	db *gorm.DB
+}
+
+func NewTranslationRepository(db *gorm.DB) *TranslationRepository {
+	return &TranslationRepository{
+		db: db,
+	}
+}
+
+func (r *TranslationRepository) GetBySong(songID uuid.UUID) ([]models.Translation, error) {
+	var translations []models.Translation
+	err := r.db.Where("song_id = ?", songID).Order("language").Find(&translations).Error
+	return translations, err
+}
+
+func (r *TranslationRepository) GetBySongs(songIDs []uuid.UUID) (map[uuid.UUID][]models.Translation, error) {
+	var translations []models.Translation
+	if err := r.db.Where("song_id IN ?", songIDs).Order("language").Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	bySong := make(map[uuid.UUID][]models.Translation, len(songIDs))
+	for _, translation := range translations {
+		bySong[translation.SongID] = append(bySong[translation.SongID], translation)
+	}
+	return bySong, nil
+}
+
+func (r *TranslationRepository) Get(songID uuid.UUID, language string) (*models.Translation, error) {
+	var translation models.Translation
+	err := r.db.First(&translation, "song_id = ? AND language = ?", songID, language).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// Save при замене перевода сохраняет исходное время создания.
+func (r *TranslationRepository) Save(translation *models.Translation) error {
+	now := time.Now()
+	if translation.CreatedAt.IsZero() {
+		translation.CreatedAt = now
+	}
+	translation.UpdatedAt = now
+
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "song_id"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"text", "author", "source", "verses", "updated_at"}),
+	}).Create(translation).Error
+}
+
+func (r *TranslationRepository) Delete(songID uuid.UUID, language string) error {
+	result := r.db.Delete(&models.Translation{}, "song_id = ? AND language = ?", songID, language)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+// translation_store.go
+package repositories
+
+import (
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// TranslationStore описывает хранилище переводов текстов песен.
+type TranslationStore interface {
+	// GetBySong возвращает переводы песни, упорядоченные по языку.
+	GetBySong(songID uuid.UUID) ([]models.Translation, error)
+	// GetBySongs возвращает переводы каждой из песен, упорядоченные по языку.
+	GetBySongs(songIDs []uuid.UUID) (map[uuid.UUID][]models.Translation, error)
+	Get(songID uuid.UUID, language string) (*models.Translation, error)
+	// Save создаёт перевод или заменяет существующий перевод на тот же язык.
+	Save(translation *models.Translation) error
+	Delete(songID uuid.UUID, language string) error
+}
@@ -0,0 +1,232 @@
+// album_service.go
+package services
+
+import (
+	"errors"
+	"time"
+
+	"song_library/internal/models"
+	"song_library/internal/repositories"
+	"song_library/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAlbumNotFound      = errors.New("альбом не найден")
+	ErrEmptyAlbumTitle    = errors.New("название альбома не может быть пустым")
+	ErrInvalidAlbumType   = errors.New("тип альбома должен быть одним из: lp, ep, single, compilation")
+	ErrInvalidReleaseDate = errors.New("дата выпуска должна быть в формате ГГГГ-ММ-ДД")
+	ErrInvalidTracks      = errors.New("в трек-листе повторяются песни или позиции")
+	ErrTrackSongNotFound  = errors.New("песня из трек-листа не найдена")
+	ErrAlbumExists        = errors.New("у исполнителя уже есть альбом с таким названием")
+)
+
+type AlbumService struct {
+	AlbumRepo  repositories.AlbumStore
+	ArtistRepo repositories.ArtistStore
+	SongRepo   repositories.SongStore
+}
+
+func NewAlbumService(albumRepo repositories.AlbumStore, artistRepo repositories.ArtistStore, songRepo repositories.SongStore) *AlbumService {
+	return &AlbumService{
+		AlbumRepo:  albumRepo,
+		ArtistRepo: artistRepo,
+		SongRepo:   songRepo,
+	}
+}
+
+func (s *AlbumService) GetAlbums(filter models.AlbumFilter, pagination *utils.Pagination) ([]models.Album, error) {
+	albums, _, err := s.AlbumRepo.GetAll(filter, pagination.GetOffset(), pagination.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	return albums, nil
+}
+
+func (s *AlbumService) GetAlbum(id uuid.UUID) (*models.Album, error) {
+	album, err := s.AlbumRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrAlbumNotFound
+		}
+		return nil, err
+	}
+	return album, nil
+}
+
+func (s *AlbumService) AddAlbum(req models.CreateAlbumRequest) (*models.Album, error) {
+	title := models.CleanAlbumTitle(req.Title)
+	if title == "" {
+		return nil, ErrEmptyAlbumTitle
+	}
+	if !req.Type.Valid() {
+		return nil, ErrInvalidAlbumType
+	}
+
+	releaseDate, err := parseAlbumReleaseDate(req.ReleaseDate)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks, err := s.buildTracks(req.Tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	artist, err := resolveArtist(s.ArtistRepo, req.Artist)
+	if err != nil {
+		return nil, err
+	}
+
+	album := &models.Album{
+		ID:              uuid.New(),
+		ArtistID:        artist.ID,
+		Title:           title,
+		NormalizedTitle: models.NormalizeAlbumTitle(title),
+		ReleaseDate:     releaseDate,
+		Type:            req.Type,
+		Tracks:          tracks,
+	}
+
+	err = s.AlbumRepo.Create(album)
+	if errors.Is(err, repositories.ErrConflict) {
+		return nil, ErrAlbumExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetAlbum(album.ID)
+}
+
+func (s *AlbumService) UpdateAlbum(id uuid.UUID, req models.UpdateAlbumRequest) (*models.Album, error) {
+	album, err := s.GetAlbum(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != "" {
+		title := models.CleanAlbumTitle(req.Title)
+		if title == "" {
+			return nil, ErrEmptyAlbumTitle
+		}
+		album.Title = title
+		album.NormalizedTitle = models.NormalizeAlbumTitle(title)
+	}
+	if req.Type != "" {
+		if !req.Type.Valid() {
+			return nil, ErrInvalidAlbumType
+		}
+		album.Type = req.Type
+	}
+	if req.ReleaseDate != "" {
+		releaseDate, err := parseAlbumReleaseDate(req.ReleaseDate)
+		if err != nil {
+			return nil, err
+		}
+		album.ReleaseDate = releaseDate
+	}
+	if req.Artist != "" {
+		artist, err := resolveArtist(s.ArtistRepo, req.Artist)
+		if err != nil {
+			return nil, err
+		}
+		album.ArtistID = artist.ID
+		album.Artist = artist
+		album.ArtistName = artist.Name
+	}
+
+	err = s.AlbumRepo.Update(album)
+	if errors.Is(err, repositories.ErrConflict) {
+		return nil, ErrAlbumExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetAlbum(id)
+}
+
+func (s *AlbumService) DeleteAlbum(id uuid.UUID) error {
+	err := s.AlbumRepo.Delete(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrAlbumNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *AlbumService) SetAlbumTracks(id uuid.UUID, req models.SetAlbumTracksRequest) (*models.Album, error) {
+	if _, err := s.GetAlbum(id); err != nil {
+		return nil, err
+	}
+
+	tracks, err := s.buildTracks(req.Tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.AlbumRepo.SetTracks(id, tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetAlbum(id)
+}
+
+// buildTracks проверяет трек-лист: песни существуют, не повторяются
+// и не занимают одну и ту же позицию на диске.
+func (s *AlbumService) buildTracks(reqs []models.AlbumTrackRequest) ([]models.AlbumTrack, error) {
+	type position struct{ disc, track int }
+
+	tracks := make([]models.AlbumTrack, 0, len(reqs))
+	songs := make(map[uuid.UUID]bool, len(reqs))
+	positions := make(map[position]bool, len(reqs))
+
+	for _, req := range reqs {
+		songID, err := uuid.Parse(req.SongID)
+		if err != nil {
+			return nil, ErrTrackSongNotFound
+		}
+
+		disc := req.DiscNumber
+		if disc == 0 {
+			disc = 1
+		}
+		pos := position{disc, req.TrackNumber}
+		if songs[songID] || positions[pos] {
+			return nil, ErrInvalidTracks
+		}
+		songs[songID] = true
+		positions[pos] = true
+
+		if _, err := s.SongRepo.GetByID(songID); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return nil, ErrTrackSongNotFound
+			}
+			return nil, err
+		}
+
+		tracks = append(tracks, models.AlbumTrack{
+			SongID:      songID,
+			DiscNumber:  disc,
+			TrackNumber: req.TrackNumber,
+		})
+	}
+
+	return tracks, nil
+}
+
+func parseAlbumReleaseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	releaseDate, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, ErrInvalidReleaseDate
+	}
+	return &releaseDate, nil
+}
@@ -0,0 +1,195 @@
+// artist_service.go
+package services
+
+import (
+	"errors"
+
+	"song_library/internal/models"
+	"song_library/internal/repositories"
+	"song_library/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrArtistNotFound  = errors.New("исполнитель не найден")
+	ErrArtistExists    = errors.New("исполнитель с таким названием уже существует")
+	ErrArtistHasSongs  = errors.New("у исполнителя есть песни")
+	ErrArtistHasAlbums = errors.New("у исполнителя есть альбомы")
+	ErrEmptyArtistName = errors.New("название исполнителя не может быть пустым")
+)
+
+type ArtistService struct {
+	ArtistRepo repositories.ArtistStore
+	SongRepo   repositories.SongStore
+	AlbumRepo  repositories.AlbumStore
+}
+
+func NewArtistService(artistRepo repositories.ArtistStore, songRepo repositories.SongStore, albumRepo repositories.AlbumStore) *ArtistService {
+	return &ArtistService{
+		ArtistRepo: artistRepo,
+		SongRepo:   songRepo,
+		AlbumRepo:  albumRepo,
+	}
+}
+
+func (s *ArtistService) GetArtists(filter models.ArtistFilter, pagination *utils.Pagination) ([]models.Artist, error) {
+	artists, _, err := s.ArtistRepo.GetAll(filter, pagination.GetOffset(), pagination.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	return artists, nil
+}
+
+func (s *ArtistService) GetArtist(id uuid.UUID) (*models.Artist, error) {
+	artist, err := s.ArtistRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrArtistNotFound
+		}
+		return nil, err
+	}
+	return artist, nil
+}
+
+func (s *ArtistService) AddArtist(name string) (*models.Artist, error) {
+	name = models.CleanArtistName(name)
+	if name == "" {
+		return nil, ErrEmptyArtistName
+	}
+
+	_, err := s.ArtistRepo.GetByNormalizedName(models.NormalizeArtistName(name))
+	if err == nil {
+		return nil, ErrArtistExists
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+
+	artist := &models.Artist{
+		ID:             uuid.New(),
+		Name:           name,
+		NormalizedName: models.NormalizeArtistName(name),
+	}
+
+	err = s.ArtistRepo.Create(artist)
+	if errors.Is(err, repositories.ErrConflict) {
+		// Исполнителя мог одновременно создать параллельный запрос
+		return nil, ErrArtistExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return artist, nil
+}
+
+// UpdateArtist переименовывает исполнителя; все его песни получают новое название группы.
+func (s *ArtistService) UpdateArtist(id uuid.UUID, req models.UpdateArtistRequest) (*models.Artist, error) {
+	artist, err := s.GetArtist(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := models.CleanArtistName(req.Name)
+	if name == "" {
+		return nil, ErrEmptyArtistName
+	}
+
+	normalizedName := models.NormalizeArtistName(name)
+	existing, err := s.ArtistRepo.GetByNormalizedName(normalizedName)
+	if err == nil && existing.ID != artist.ID {
+		return nil, ErrArtistExists
+	}
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+
+	artist.Name = name
+	artist.NormalizedName = normalizedName
+
+	err = s.ArtistRepo.Update(artist)
+	if err != nil {
+		return nil, err
+	}
+
+	return artist, nil
+}
+
+func (s *ArtistService) DeleteArtist(id uuid.UUID) error {
+	if _, err := s.GetArtist(id); err != nil {
+		return err
+	}
+
+	// Песни в корзине ещё ссылаются на исполнителя и могут быть восстановлены
+	_, total, err := s.SongRepo.GetAll(models.SongFilter{ArtistID: id, WithTrashed: true},
+		models.SongProjection{Columns: []string{"id"}, Without: models.SongRelations}, 0, 1)
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return ErrArtistHasSongs
+	}
+
+	_, total, err = s.AlbumRepo.GetAll(models.AlbumFilter{ArtistID: id}, 0, 1)
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return ErrArtistHasAlbums
+	}
+
+	err = s.ArtistRepo.Delete(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrArtistNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *ArtistService) GetArtistSongs(id uuid.UUID, pagination *utils.Pagination) ([]models.Song, error) {
+	if _, err := s.GetArtist(id); err != nil {
+		return nil, err
+	}
+
+	songs, _, err := s.SongRepo.GetAll(models.SongFilter{ArtistID: id}, models.SongProjection{}, pagination.GetOffset(), pagination.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	return songs, nil
+}
+
+// resolveArtist находит исполнителя по названию без учёта регистра и пробелов
+// или создаёт нового, если такого ещё нет.
+func resolveArtist(store repositories.ArtistStore, name string) (*models.Artist, error) {
+	name = models.CleanArtistName(name)
+	if name == "" {
+		return nil, ErrEmptyArtistName
+	}
+	normalizedName := models.NormalizeArtistName(name)
+
+	artist, err := store.GetByNormalizedName(normalizedName)
+	if err == nil {
+		return artist, nil
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+
+	artist = &models.Artist{
+		ID:             uuid.New(),
+		Name:           name,
+		NormalizedName: normalizedName,
+	}
+	if err := store.Create(artist); err != nil {
+		// Исполнителя мог одновременно создать параллельный запрос
+		if existing, getErr := store.GetByNormalizedName(normalizedName); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	return artist, nil
+}
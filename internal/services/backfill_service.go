@@ -0,0 +1,260 @@
+// backfill_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"song_library/internal/models"
+	"song_library/internal/providers"
+	"song_library/internal/repositories"
+	"song_library/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBackfillRunning      = errors.New("дозаполнение уже выполняется")
+	ErrBackfillNotStarted   = errors.New("дозаполнение ещё не запускалось")
+	ErrBackfillNotRunning   = errors.New("дозаполнение не выполняется")
+	ErrInvalidStaleAfter    = errors.New("некорректный срок устаревания данных, ожидается длительность вроде 720h")
+	ErrInvalidBackfillLimit = errors.New("лимит песен не может быть отрицательным")
+)
+
+// BackfillService дозаполняет песни, которым источники не дали даты выпуска, текста
+// или ссылки, и обновляет устаревшие данные источников. Песни обрабатываются по одной
+// не быстрее Rate в секунду, чтобы прогон по всей библиотеке не упёрся в лимиты
+// источников. Одновременно выполняется один прогон; о последнем помнит только
+// текущий процесс.
+type BackfillService struct {
+	SongRepo   repositories.SongStore
+	Enrichment *EnrichmentService
+	// Rate - сколько песен в секунду отправляется источникам
+	Rate float64
+
+	mu     sync.Mutex
+	run    *models.BackfillRun
+	cancel context.CancelFunc
+}
+
+func NewBackfillService(songRepo repositories.SongStore, enrichment *EnrichmentService, rate float64) *BackfillService {
+	return &BackfillService{
+		SongRepo:   songRepo,
+		Enrichment: enrichment,
+		Rate:       rate,
+	}
+}
+
+// Start запускает в фоне прогон по неполным песням, а если задан StaleAfter - и по
+// песням с устаревшими данными источников. Песни отбираются уже в фоне, поэтому
+// Total известен не сразу. Песни в очереди обогащения пропускаются.
+func (s *BackfillService) Start(req models.BackfillRequest, trigger models.BackfillTrigger) (*models.BackfillRun, error) {
+	var staleAfter time.Duration
+	if req.StaleAfter != "" {
+		var err error
+		staleAfter, err = time.ParseDuration(req.StaleAfter)
+		if err != nil || staleAfter <= 0 {
+			return nil, ErrInvalidStaleAfter
+		}
+	}
+	if req.Limit < 0 {
+		return nil, ErrInvalidBackfillLimit
+	}
+	if !s.Enrichment.Enabled() {
+		return nil, ErrEnrichmentDisabled
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.run != nil && s.run.Status == models.BackfillRunning {
+		return nil, ErrBackfillRunning
+	}
+
+	run := &models.BackfillRun{
+		ID:        uuid.New(),
+		Status:    models.BackfillRunning,
+		Trigger:   trigger,
+		StartedAt: time.Now(),
+	}
+	if staleAfter > 0 {
+		run.StaleAfter = staleAfter.String()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.run = run
+	s.cancel = cancel
+	go s.process(ctx, run, staleAfter, req.Limit)
+
+	return s.snapshot(), nil
+}
+
+// Current возвращает выполняющийся или последний завершённый прогон.
+func (s *BackfillService) Current() (*models.BackfillRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.run == nil {
+		return nil, ErrBackfillNotStarted
+	}
+	return s.snapshot(), nil
+}
+
+// Cancel останавливает выполняющийся прогон. Песня, которую источники уже
+// обрабатывают, дожидается ответа.
+func (s *BackfillService) Cancel() (*models.BackfillRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.run == nil || s.run.Status != models.BackfillRunning {
+		return nil, ErrBackfillNotRunning
+	}
+	s.cancel()
+	s.run.ResumeAt = nil
+	s.finish(models.BackfillCancelled)
+	return s.snapshot(), nil
+}
+
+// candidates возвращает ID неполных песен и затем песен с данными старше staleAfter, без повторов.
+func (s *BackfillService) candidates(staleAfter time.Duration, limit int) ([]uuid.UUID, error) {
+	filters := []models.SongFilter{{Incomplete: true}}
+	if staleAfter > 0 {
+		filters = append(filters, models.SongFilter{StaleBefore: time.Now().Add(-staleAfter)})
+	}
+
+	var ids []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	for _, filter := range filters {
+		found, err := songIDs(s.SongRepo, filter, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range found {
+			if limit > 0 && len(ids) >= limit {
+				return ids, nil
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// process отбирает песни прогона и обновляет их по одной с паузой 1/Rate секунды
+// между ними. Пока источники недоступны, прогон ждёт Enrichment.Backoff и повторяет
+// ту же песню, не считая её ошибкой.
+func (s *BackfillService) process(ctx context.Context, run *models.BackfillRun, staleAfter time.Duration, limit int) {
+	logger := utils.GetLogger()
+
+	ids, err := s.candidates(staleAfter, limit)
+	if err != nil {
+		logger.Errorf("Не удалось отобрать песни для дозаполнения: %v", err)
+	}
+
+	s.mu.Lock()
+	if run.Status != models.BackfillRunning {
+		// Прогон отменили, пока песни отбирались
+		s.mu.Unlock()
+		return
+	}
+	if err != nil {
+		run.LastError = err.Error()
+		s.finish(models.BackfillFailed)
+		s.mu.Unlock()
+		return
+	}
+	run.Total = len(ids)
+	s.mu.Unlock()
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / s.Rate))
+	defer ticker.Stop()
+
+	for i := 0; i < len(ids); {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+
+		id := ids[i]
+		status, err := s.Enrichment.Refresh(ctx, id)
+		if ctx.Err() != nil {
+			// Прогон отменён, пока источники отвечали; Cancel уже подвёл итог
+			return
+		}
+
+		if errors.Is(err, providers.ErrUnavailable) {
+			if !s.pause(ctx, run, err) {
+				return
+			}
+			continue
+		}
+
+		s.mu.Lock()
+		run.Processed++
+		run.ResumeAt = nil
+		switch {
+		case errors.Is(err, ErrSongNotFound), status == models.EnrichmentPending:
+			run.Skipped++
+		case err != nil:
+			run.Failed++
+			run.LastError = err.Error()
+			logger.Warnf("Не удалось дозаполнить песню %s: %v", id, err)
+		case status == models.EnrichmentNotFound:
+			run.NotFound++
+		default:
+			run.Enriched++
+		}
+		s.mu.Unlock()
+		i++
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if run.Status == models.BackfillRunning {
+		s.finish(models.BackfillCompleted)
+		logger.Infof("Дозаполнение завершено: обработано %d, обогащено %d, не найдено %d, ошибок %d",
+			run.Processed, run.Enriched, run.NotFound, run.Failed)
+	}
+}
+
+// pause приостанавливает прогон на Enrichment.Backoff, пока источники недоступны.
+// Возвращает false, если прогон отменили во время паузы.
+func (s *BackfillService) pause(ctx context.Context, run *models.BackfillRun, err error) bool {
+	resumeAt := time.Now().Add(s.Enrichment.Backoff)
+
+	s.mu.Lock()
+	run.LastError = err.Error()
+	run.ResumeAt = &resumeAt
+	s.mu.Unlock()
+	utils.GetLogger().Warnf("Дозаполнение приостановлено до %s: %v", resumeAt.Format(time.RFC3339), err)
+
+	timer := time.NewTimer(time.Until(resumeAt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// finish завершает текущий прогон; вызывается под s.mu.
+func (s *BackfillService) finish(status models.BackfillStatus) {
+	now := time.Now()
+	s.run.Status = status
+	s.run.FinishedAt = &now
+}
+
+// snapshot копирует текущий прогон, чтобы его можно было отдать, не держа s.mu;
+// вызывается под s.mu.
+func (s *BackfillService) snapshot() *models.BackfillRun {
+	run := *s.run
+	return &run
+}
@@ -0,0 +1,603 @@
+// enrichment_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"song_library/internal/models"
+	"song_library/internal/providers"
+	"song_library/internal/repositories"
+	"song_library/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEnrichmentNotRetryable = errors.New("песня уже обогащена или ждёт обогащения")
+	ErrEnrichmentDisabled     = errors.New("источники метаданных не настроены: задайте EXTERNAL_API или ENRICH_PROVIDERS")
+)
+
+const (
+	// enrichmentLease - на сколько обработчик захватывает задание; если он упадёт,
+	// задание по истечении срока возьмёт другой
+	enrichmentLease = 2 * time.Minute
+	// enrichmentPollInterval - как часто очередь проверяется без новых заданий
+	enrichmentPollInterval = 2 * time.Second
+	// maxEnrichmentBackoff ограничивает паузу между попытками
+	maxEnrichmentBackoff = time.Hour
+	// enrichmentNote - комментарий к ревизии с данными источников
+	enrichmentNote = "Данные источников метаданных"
+)
+
+// EnrichmentService заполняет песни данными источников метаданных в фоне. Задания хранятся
+// в EnrichmentStore; при временной ошибке попытка повторяется с растущей паузой,
+// пока не кончится MaxAttempts, а песню, которой не знает ни один источник, сразу
+// помечает not_found.
+type EnrichmentService struct {
+	SongRepo     repositories.SongStore
+	AlbumRepo    repositories.AlbumStore
+	RevisionRepo repositories.RevisionStore
+	JobRepo      repositories.EnrichmentStore
+	SourceRepo   repositories.SongSourceStore
+	Tx           repositories.Transactor
+	Providers    *providers.Registry
+	// Workers - число одновременно обогащаемых песен
+	Workers     int
+	MaxAttempts int
+	// Backoff - пауза перед второй попыткой; каждая следующая вдвое дольше
+	Backoff time.Duration
+
+	wake chan struct{}
+}
+
+func NewEnrichmentService(
+	songRepo repositories.SongStore,
+	albumRepo repositories.AlbumStore,
+	revisionRepo repositories.RevisionStore,
+	jobRepo repositories.EnrichmentStore,
+	sourceRepo repositories.SongSourceStore,
+	tx repositories.Transactor,
+	registry *providers.Registry,
+	workers, maxAttempts int,
+	backoff time.Duration,
+) *EnrichmentService {
+	return &EnrichmentService{
+		SongRepo:     songRepo,
+		AlbumRepo:    albumRepo,
+		RevisionRepo: revisionRepo,
+		JobRepo:      jobRepo,
+		SourceRepo:   sourceRepo,
+		Tx:           tx,
+		Providers:    registry,
+		Workers:      workers,
+		MaxAttempts:  maxAttempts,
+		Backoff:      backoff,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Enabled сообщает, настроен ли хотя бы один источник метаданных.
+// Без источников песни не обогащаются, а очередь не разбирается.
+func (s *EnrichmentService) Enabled() bool {
+	return s.Providers.Enabled()
+}
+
+// notify будит обработчики после того, как в очередь добавлено задание,
+// не дожидаясь очередной проверки.
+func (s *EnrichmentService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// RetrySong возвращает в очередь песню, обогащение которой не удалось
+// или ещё не запускалось.
+func (s *EnrichmentService) RetrySong(id uuid.UUID) (*models.Song, error) {
+	if !s.Enabled() {
+		return nil, ErrEnrichmentDisabled
+	}
+
+	song, err := s.SongRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSongNotFound
+		}
+		return nil, err
+	}
+
+	switch song.EnrichmentStatus {
+	case models.EnrichmentPending, models.EnrichmentEnriched:
+		return nil, ErrEnrichmentNotRetryable
+	}
+
+	if err := s.requeue(id); err != nil {
+		return nil, err
+	}
+	return s.SongRepo.GetByID(id)
+}
+
+// RefreshSong ставит песню в очередь заново в любом статусе: источники могли
+// узнать песню или исправить свои данные. Заблокированные поля не перезаписываются.
+func (s *EnrichmentService) RefreshSong(id uuid.UUID) (*models.Song, error) {
+	if !s.Enabled() {
+		return nil, ErrEnrichmentDisabled
+	}
+
+	if _, err := s.SongRepo.GetByID(id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSongNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.requeue(id); err != nil {
+		return nil, err
+	}
+	return s.SongRepo.GetByID(id)
+}
+
+// RetryFailed возвращает в очередь все песни в статусе failed.
+func (s *EnrichmentService) RetryFailed() (int, error) {
+	if !s.Enabled() {
+		return 0, ErrEnrichmentDisabled
+	}
+
+	// Сначала собираем ID: возвращённые в очередь песни выпадают из фильтра и сдвигают страницы
+	ids, err := songIDs(s.SongRepo, models.SongFilter{Enrichment: models.EnrichmentFailed}, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, id := range ids {
+		if err := s.requeue(id); err != nil {
+			return i, err
+		}
+	}
+	return len(ids), nil
+}
+
+// songIDs возвращает ID песен, подходящих под фильтр, но не больше limit; 0 - без ограничения.
+func songIDs(store repositories.SongStore, filter models.SongFilter, limit int) ([]uuid.UUID, error) {
+	const pageSize = 500
+
+	projection := models.SongProjection{Columns: []string{"id"}, Without: models.SongRelations}
+	var ids []uuid.UUID
+	for offset := 0; ; offset += pageSize {
+		songs, total, err := store.GetAll(filter, projection, offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, song := range songs {
+			if limit > 0 && len(ids) >= limit {
+				return ids, nil
+			}
+			ids = append(ids, song.ID)
+		}
+		if len(songs) == 0 || int64(offset+pageSize) >= total {
+			return ids, nil
+		}
+	}
+}
+
+// requeue ставит песню в очередь и меняет её статус в одной транзакции,
+// чтобы задание не оказалось в очереди при старом статусе песни.
+func (s *EnrichmentService) requeue(id uuid.UUID) error {
+	err := s.Tx.Transaction(func(stores *repositories.Stores) error {
+		if err := stores.Enrichment.Enqueue(id, time.Now()); err != nil {
+			return err
+		}
+		return stores.Songs.SetEnrichmentStatus(id, models.EnrichmentPending)
+	})
+	if err != nil {
+		return err
+	}
+	s.notify()
+	return nil
+}
+
+// Run разбирает очередь пулом из Workers обработчиков, пока не отменён ctx.
+func (s *EnrichmentService) Run(ctx context.Context) {
+	logger := utils.GetLogger()
+
+	jobs := make(chan models.EnrichmentJob)
+	var wg sync.WaitGroup
+	for i := 0; i < s.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				s.process(ctx, job)
+			}
+		}()
+	}
+	defer func() {
+		close(jobs)
+		wg.Wait()
+	}()
+
+	ticker := time.NewTicker(enrichmentPollInterval)
+	defer ticker.Stop()
+
+	for {
+		claimed, err := s.JobRepo.Claim(time.Now(), enrichmentLease, s.Workers)
+		if err != nil {
+			logger.Errorf("Не удалось получить задания обогащения: %v", err)
+		}
+		for _, job := range claimed {
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				// Захват не отпущен, задание возьмут снова после enrichmentLease
+				return
+			}
+		}
+		if len(claimed) == s.Workers {
+			// Очередь могла не опустеть - сразу берём следующие задания
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// process выполняет одну попытку обогащения.
+func (s *EnrichmentService) process(ctx context.Context, job models.EnrichmentJob) {
+	logger := utils.GetLogger()
+
+	song, err := s.SongRepo.GetByID(job.SongID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logger.Errorf("Не удалось загрузить песню %s для обогащения: %v", job.SongID, err)
+		}
+		// Песня в корзине или база недоступна - откладываем, не тратя попытку
+		job.NextAttemptAt = time.Now().Add(enrichmentLease)
+		s.release(&job)
+		return
+	}
+
+	metadata, err := s.Providers.Lookup(ctx, song.GroupName, song.SongTitle)
+	var partial *providers.PartialError
+	if errors.As(err, &partial) {
+		s.processPartial(job, song, metadata, partial)
+		return
+	}
+	if err != nil && (errors.Is(err, providers.ErrUnavailable) || ctx.Err() != nil) {
+		// Источники не спрашивали или обработку остановили - попытка не считается
+		job.NextAttemptAt = time.Now().Add(s.Backoff)
+		s.release(&job)
+		return
+	}
+
+	job.Attempts++
+	switch {
+	case errors.Is(err, providers.ErrNotFound):
+		job.LastError = err.Error()
+		s.finish(&job, models.EnrichmentNotFound)
+	case err != nil:
+		job.LastError = err.Error()
+		if job.Attempts >= s.MaxAttempts {
+			logger.Warnf("Обогащение песни %s не удалось после %d попыток: %v", song.ID, job.Attempts, err)
+			s.finish(&job, models.EnrichmentFailed)
+			return
+		}
+		job.NextAttemptAt = time.Now().Add(s.backoff(job.Attempts))
+		s.release(&job)
+	default:
+		if err := s.apply(song, metadata); err != nil {
+			logger.Errorf("Не удалось сохранить данные источников для песни %s: %v", song.ID, err)
+			job.LastError = err.Error()
+			job.NextAttemptAt = time.Now().Add(s.backoff(job.Attempts))
+			s.release(&job)
+			return
+		}
+		s.complete(song.ID)
+	}
+}
+
+// processPartial сохраняет данные, которые собрали ответившие источники, и откладывает
+// задание, чтобы позже запросить поля у тех, кто не ответил. Когда попытки кончаются,
+// песня остаётся с собранными данными и считается обогащённой.
+func (s *EnrichmentService) processPartial(job models.EnrichmentJob, song *models.Song, metadata *providers.Metadata, partial *providers.PartialError) {
+	logger := utils.GetLogger()
+
+	if err := s.apply(song, metadata); err != nil {
+		logger.Errorf("Не удалось сохранить данные источников для песни %s: %v", song.ID, err)
+		job.LastError = err.Error()
+		job.NextAttemptAt = time.Now().Add(s.backoff(max(job.Attempts, 1)))
+		s.release(&job)
+		return
+	}
+
+	// Как и при полном отказе, источники, которых не спрашивали, попытку не тратят
+	if !partial.Unavailable() {
+		job.Attempts++
+	}
+	job.LastError = partial.Error()
+	if job.Attempts >= s.MaxAttempts {
+		logger.Warnf("Песня %s обогащена не полностью после %d попыток: %v", song.ID, job.Attempts, partial)
+		s.complete(song.ID)
+		return
+	}
+	job.NextAttemptAt = time.Now().Add(s.backoff(max(job.Attempts, 1)))
+	s.release(&job)
+}
+
+// Refresh сразу, минуя очередь, запрашивает у источников данные песни и сохраняет их.
+// Возвращает enriched, если источники нашли песню, и not_found, если не нашли;
+// песня, обогащённая раньше, в этом случае свой статус сохраняет. Если ответили
+// не все источники, собранное сохраняется, а песня возвращается в очередь (pending).
+// Песню, которая ждёт в очереди, Refresh оставляет очереди и возвращает pending.
+func (s *EnrichmentService) Refresh(ctx context.Context, id uuid.UUID) (models.EnrichmentStatus, error) {
+	song, err := s.SongRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return "", ErrSongNotFound
+		}
+		return "", err
+	}
+	if song.EnrichmentStatus == models.EnrichmentPending {
+		return models.EnrichmentPending, nil
+	}
+
+	metadata, err := s.Providers.Lookup(ctx, song.GroupName, song.SongTitle)
+	var partial *providers.PartialError
+	switch {
+	case errors.As(err, &partial):
+		// Сохраняем собранное, а недостающие поля дозапросит очередь
+		if err := s.apply(song, metadata); err != nil {
+			return "", err
+		}
+		if err := s.requeue(song.ID); err != nil {
+			return "", err
+		}
+		return models.EnrichmentPending, nil
+	case errors.Is(err, providers.ErrNotFound):
+		if song.EnrichmentStatus != models.EnrichmentEnriched {
+			if err := s.SongRepo.SetEnrichmentStatus(song.ID, models.EnrichmentNotFound); err != nil {
+				return "", err
+			}
+		}
+		return models.EnrichmentNotFound, nil
+	case err != nil:
+		return "", err
+	}
+
+	if err := s.apply(song, metadata); err != nil {
+		return "", err
+	}
+	s.complete(song.ID)
+	return models.EnrichmentEnriched, nil
+}
+
+// complete отмечает песню обогащённой и убирает её задание из очереди.
+func (s *EnrichmentService) complete(songID uuid.UUID) {
+	logger := utils.GetLogger()
+
+	if err := s.SongRepo.SetEnrichmentStatus(songID, models.EnrichmentEnriched); err != nil {
+		logger.Errorf("Не удалось обновить статус обогащения песни %s: %v", songID, err)
+		return
+	}
+	if err := s.JobRepo.Delete(songID); err != nil {
+		logger.Errorf("Не удалось удалить задание обогащения песни %s: %v", songID, err)
+	}
+}
+
+// backoff возвращает паузу перед следующей попыткой после attempts неудачных.
+func (s *EnrichmentService) backoff(attempts int) time.Duration {
+	delay := s.Backoff
+	for i := 1; i < attempts && delay < maxEnrichmentBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxEnrichmentBackoff)
+}
+
+func (s *EnrichmentService) release(job *models.EnrichmentJob) {
+	if err := s.JobRepo.Release(job); err != nil {
+		utils.GetLogger().Errorf("Не удалось вернуть задание обогащения песни %s в очередь: %v", job.SongID, err)
+	}
+}
+
+// finish завершает задание без успеха: оно остаётся с последней ошибкой,
+// но больше не берётся, пока песню не вернут в очередь.
+func (s *EnrichmentService) finish(job *models.EnrichmentJob, status models.EnrichmentStatus) {
+	s.release(job)
+	if err := s.SongRepo.SetEnrichmentStatus(job.SongID, status); err != nil {
+		utils.GetLogger().Errorf("Не удалось обновить статус обогащения песни %s: %v", job.SongID, err)
+	}
+}
+
+// apply переносит в песню поля, которые нашли источники, и записывает их
+// происхождение. Заблокированные поля - исправленные вручную - не перезаписываются.
+// Песня перечитывается в транзакции вместе с блокировками, а сохраняются только
+// заполненные источниками колонки, чтобы не затереть правку, сделанную за время запроса.
+func (s *EnrichmentService) apply(song *models.Song, metadata *providers.Metadata) error {
+	err := writeSong(s.Tx, func(stores *repositories.Stores) error {
+		current, err := stores.Songs.GetForUpdate(song.ID)
+		if err != nil {
+			return err
+		}
+		locked, err := lockedSongFields(stores.Sources, current)
+		if err != nil {
+			return err
+		}
+
+		before := *current
+		var fields []string
+		var sources []models.SongFieldSource
+		use := func(field providers.Field) bool {
+			source, ok := metadata.Sources[field]
+			if !ok || locked[string(field)] {
+				return false
+			}
+			fields = append(fields, string(field))
+			sources = append(sources, fieldSource(current.ID, field, source))
+			return true
+		}
+
+		if use(providers.FieldReleaseDate) {
+			current.ReleaseDate = metadata.ReleaseDate
+		}
+		if use(providers.FieldText) {
+			current.Text = metadata.Text
+		}
+		if use(providers.FieldLink) {
+			current.Link = metadata.Link
+		}
+
+		if !models.SnapshotOf(&before).Equal(models.SnapshotOf(current)) {
+			if err := stores.Songs.UpdateFields(current, fields); err != nil {
+				return err
+			}
+			if err := recordRevision(stores.Revisions, &before, current, SongChange{Note: enrichmentNote}, nil); err != nil {
+				return err
+			}
+		}
+		*song = *current
+		return stores.Sources.Save(sources)
+	})
+	if err != nil {
+		return err
+	}
+
+	source, ok := metadata.Sources[providers.FieldAlbum]
+	if !ok {
+		return nil
+	}
+	// Данные песни уже сохранены, поэтому ошибка привязки к альбому не повторяет попытку
+	if err := s.attachToAlbum(song, metadata); err != nil {
+		utils.GetLogger().Warnf("Не удалось привязать песню %s к альбому %q: %v", song.ID, metadata.Album, err)
+		return nil
+	}
+	return s.SourceRepo.Save([]models.SongFieldSource{fieldSource(song.ID, providers.FieldAlbum, source)})
+}
+
+// GetProvenance возвращает происхождение и блокировки полей песни.
+func (s *EnrichmentService) GetProvenance(id uuid.UUID) (*models.SongProvenance, error) {
+	song, err := s.SongRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSongNotFound
+		}
+		return nil, err
+	}
+
+	sources, err := s.SourceRepo.GetBySong(id)
+	if err != nil {
+		return nil, err
+	}
+	byField := make(map[string]models.SongFieldSource, len(sources))
+	for _, source := range sources {
+		byField[source.Field] = source
+	}
+
+	provenance := &models.SongProvenance{
+		SongID:           song.ID,
+		EnrichmentStatus: song.EnrichmentStatus,
+		Fields:           make([]models.SongFieldSource, 0, len(providers.Fields)),
+	}
+	for _, field := range providers.Fields {
+		source, ok := byField[string(field)]
+		if !ok {
+			source = models.SongFieldSource{Field: string(field)}
+		}
+		provenance.Fields = append(provenance.Fields, source)
+	}
+	return provenance, nil
+}
+
+// SetLocks включает и снимает блокировку полей песни.
+func (s *EnrichmentService) SetLocks(id uuid.UUID, req models.SongLocksRequest) (*models.SongProvenance, error) {
+	if _, err := s.SongRepo.GetByID(id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSongNotFound
+		}
+		return nil, err
+	}
+
+	var lock, unlock []string
+	for field, value := range map[string]*bool{
+		models.SongFieldReleaseDate: req.ReleaseDate,
+		models.SongFieldText:        req.Text,
+		models.SongFieldLink:        req.Link,
+	} {
+		switch {
+		case value == nil:
+		case *value:
+			lock = append(lock, field)
+		default:
+			unlock = append(unlock, field)
+		}
+	}
+
+	if err := s.SourceRepo.SetLocked(id, lock, true); err != nil {
+		return nil, err
+	}
+	if err := s.SourceRepo.SetLocked(id, unlock, false); err != nil {
+		return nil, err
+	}
+	return s.GetProvenance(id)
+}
+
+// attachToAlbum добавляет песню в альбом, который назвал источник.
+// Альбом ищется у того же исполнителя по названию и создаётся, если его ещё нет.
+func (s *EnrichmentService) attachToAlbum(song *models.Song, metadata *providers.Metadata) error {
+	title := models.CleanAlbumTitle(metadata.Album)
+	if title == "" {
+		return nil
+	}
+
+	album, err := s.AlbumRepo.FindByTitle(song.ArtistID, models.NormalizeAlbumTitle(title))
+	if errors.Is(err, repositories.ErrNotFound) {
+		albumType := models.AlbumType(metadata.AlbumType)
+		if !albumType.Valid() {
+			albumType = models.AlbumTypeLP
+		}
+		album = &models.Album{
+			ID:              uuid.New(),
+			ArtistID:        song.ArtistID,
+			Title:           title,
+			NormalizedTitle: models.NormalizeAlbumTitle(title),
+			Type:            albumType,
+		}
+		err = s.AlbumRepo.Create(album)
+		if errors.Is(err, repositories.ErrConflict) {
+			// Альбом мог одновременно создать параллельный запрос
+			album, err = s.AlbumRepo.FindByTitle(song.ArtistID, album.NormalizedTitle)
+		}
+	} else if err == nil {
+		// При повторном обогащении песня уже может быть в этом альбоме
+		album, err = s.AlbumRepo.GetByID(album.ID)
+		if err == nil && slices.ContainsFunc(album.Tracks, func(track models.AlbumTrack) bool {
+			return track.SongID == song.ID
+		}) {
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	track := &models.AlbumTrack{
+		AlbumID:     album.ID,
+		SongID:      song.ID,
+		DiscNumber:  metadata.DiscNumber,
+		TrackNumber: metadata.TrackNumber,
+	}
+	err = s.AlbumRepo.AddTrack(track)
+	if errors.Is(err, repositories.ErrConflict) && track.TrackNumber != 0 {
+		// Позиция могла быть занята - ставим песню в конец диска
+		track.TrackNumber = 0
+		err = s.AlbumRepo.AddTrack(track)
+	}
+	return err
+}
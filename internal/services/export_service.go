@@ -0,0 +1,244 @@
+// export_service.go
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"song_library/internal/models"
+	"song_library/internal/repositories"
+)
+
+var ErrInvalidExportColumn = errors.New("неизвестная колонка выгрузки; доступны id, artistId, group, song, releaseDate, text, link, createdAt, updatedAt")
+
+// exportColumn - колонка выгрузки; названия совпадают с полями песни в JSON,
+// поэтому выгруженный CSV можно загрузить обратно через импорт.
+type exportColumn struct {
+	name  string
+	value func(song *models.Song) any
+}
+
+var exportColumns = []exportColumn{
+	{"id", func(song *models.Song) any { return song.ID.String() }},
+	{"artistId", func(song *models.Song) any { return song.ArtistID.String() }},
+	{"group", func(song *models.Song) any { return song.GroupName }},
+	{"song", func(song *models.Song) any { return song.SongTitle }},
+	{"releaseDate", func(song *models.Song) any { return exportDate(song.ReleaseDate) }},
+	{"text", func(song *models.Song) any { return song.Text }},
+	{"link", func(song *models.Song) any { return song.Link }},
+	{"createdAt", func(song *models.Song) any { return exportTime(song.CreatedAt) }},
+	{"updatedAt", func(song *models.Song) any { return exportTime(song.UpdatedAt) }},
+}
+
+// exportTime возвращает время создания или изменения в RFC 3339; нулевое время - nil.
+func exportTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// exportDate выгружает дату выпуска с её точностью: 1975, 1975-07 или 1975-07-16.
+func exportDate(date models.ReleaseDate) any {
+	if date.IsZero() {
+		return nil
+	}
+	return date.String()
+}
+
+type ExportService struct {
+	SongRepo repositories.SongStore
+}
+
+func NewExportService(songRepo repositories.SongStore) *ExportService {
+	return &ExportService{
+		SongRepo: songRepo,
+	}
+}
+
+// SongExport - проверенная выгрузка, готовая к записи.
+type SongExport struct {
+	Format  models.FileFormat
+	store   repositories.SongStore
+	filter  models.SongFilter
+	columns []exportColumn
+}
+
+// NewExport проверяет фильтр и параметры выгрузки до того, как начнётся запись,
+// чтобы об ошибке можно было сообщить обычным ответом.
+func (s *ExportService) NewExport(filter models.SongFilter, options models.ExportOptions) (*SongExport, error) {
+	if options.Format == "" {
+		options.Format = models.FormatNDJSON
+	}
+	if !options.Format.Valid() {
+		return nil, ErrInvalidFileFormat
+	}
+
+	filter, err := prepareSongFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := selectExportColumns(splitList(options.Columns), options.Lyrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SongExport{
+		Format:  options.Format,
+		store:   s.SongRepo,
+		filter:  filter,
+		columns: columns,
+	}, nil
+}
+
+func selectExportColumns(names []string, lyrics bool) ([]exportColumn, error) {
+	var columns []exportColumn
+	selected := map[string]bool{}
+	add := func(column exportColumn) {
+		if !selected[column.name] {
+			selected[column.name] = true
+			columns = append(columns, column)
+		}
+	}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, column := range exportColumns {
+			if strings.EqualFold(column.name, name) {
+				add(column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, ErrInvalidExportColumn
+		}
+	}
+
+	defaults := len(columns) == 0
+	for _, column := range exportColumns {
+		if (defaults && column.name != "text") || (lyrics && column.name == "text") {
+			add(column)
+		}
+	}
+	return columns, nil
+}
+
+// Write выгружает песни в w по мере чтения из хранилища.
+func (e *SongExport) Write(w io.Writer) error {
+	buffered := bufio.NewWriter(w)
+
+	withText := false
+	for _, column := range e.columns {
+		withText = withText || column.name == "text"
+	}
+
+	var write func(song *models.Song) error
+	var finish func() error
+	switch e.Format {
+	case models.FormatCSV:
+		write, finish = e.csvWriter(buffered)
+	default:
+		write, finish = e.jsonWriter(buffered)
+	}
+
+	if err := e.store.Export(e.filter, withText, write); err != nil {
+		return err
+	}
+	if err := finish(); err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+func (e *SongExport) csvWriter(w io.Writer) (func(song *models.Song) error, func() error) {
+	writer := csv.NewWriter(w)
+
+	record := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		record[i] = column.name
+	}
+	headerErr := writer.Write(record)
+
+	write := func(song *models.Song) error {
+		if headerErr != nil {
+			return headerErr
+		}
+		for i, column := range e.columns {
+			record[i] = ""
+			if value := column.value(song); value != nil {
+				record[i] = value.(string)
+			}
+		}
+		return writer.Write(record)
+	}
+	finish := func() error {
+		if headerErr != nil {
+			return headerErr
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+	return write, finish
+}
+
+// jsonWriter пишет песни объектами с колонками в выбранном порядке:
+// в NDJSON по одной на строку, в JSON - элементами массива.
+func (e *SongExport) jsonWriter(w io.Writer) (func(song *models.Song) error, func() error) {
+	array := e.Format == models.FormatJSON
+	count := 0
+
+	write := func(song *models.Song) error {
+		var object strings.Builder
+		if array {
+			if count == 0 {
+				object.WriteString("[\n")
+			} else {
+				object.WriteString(",\n")
+			}
+		}
+		object.WriteByte('{')
+		for i, column := range e.columns {
+			key, _ := json.Marshal(column.name)
+			value, err := json.Marshal(column.value(song))
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				object.WriteByte(',')
+			}
+			fmt.Fprintf(&object, "%s:%s", key, value)
+		}
+		object.WriteByte('}')
+		if !array {
+			object.WriteByte('\n')
+		}
+		count++
+
+		_, err := io.WriteString(w, object.String())
+		return err
+	}
+	finish := func() error {
+		if !array {
+			return nil
+		}
+		closing := "\n]\n"
+		if count == 0 {
+			closing = "[]\n"
+		}
+		_, err := io.WriteString(w, closing)
+		return err
+	}
+	return write, finish
+}
@@ -0,0 +1,176 @@
+// genre_service.go
+package services
+
+import (
+	"errors"
+
+	"song_library/internal/models"
+	"song_library/internal/repositories"
+	"song_library/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrGenreNotFound    = errors.New("жанр не найден")
+	ErrGenreExists      = errors.New("жанр с таким названием уже существует")
+	ErrEmptyGenreName   = errors.New("название жанра не может быть пустым")
+	ErrUnknownGenre     = errors.New("жанра нет в справочнике")
+	ErrGenreNotAssigned = errors.New("жанр не назначен песне")
+)
+
+type GenreService struct {
+	GenreRepo repositories.GenreStore
+	SongRepo  repositories.SongStore
+}
+
+func NewGenreService(genreRepo repositories.GenreStore, songRepo repositories.SongStore) *GenreService {
+	return &GenreService{
+		GenreRepo: genreRepo,
+		SongRepo:  songRepo,
+	}
+}
+
+func (s *GenreService) GetGenres(pagination *utils.Pagination) ([]models.Genre, error) {
+	genres, _, err := s.GenreRepo.GetAll(pagination.GetOffset(), pagination.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	return genres, nil
+}
+
+func (s *GenreService) GetGenre(id uuid.UUID) (*models.Genre, error) {
+	genre, err := s.GenreRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrGenreNotFound
+		}
+		return nil, err
+	}
+	return genre, nil
+}
+
+func (s *GenreService) AddGenre(req models.CreateGenreRequest) (*models.Genre, error) {
+	name := models.CleanArtistName(req.Name)
+	if name == "" {
+		return nil, ErrEmptyGenreName
+	}
+	normalizedName := models.NormalizeGenreName(name)
+
+	if err := s.checkNameFree(uuid.Nil, normalizedName); err != nil {
+		return nil, err
+	}
+
+	genre := &models.Genre{
+		ID:             uuid.New(),
+		Name:           name,
+		NormalizedName: normalizedName,
+	}
+
+	err := s.GenreRepo.Create(genre)
+	if err != nil {
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+func (s *GenreService) UpdateGenre(id uuid.UUID, req models.UpdateGenreRequest) (*models.Genre, error) {
+	genre, err := s.GetGenre(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := models.CleanArtistName(req.Name)
+	if name == "" {
+		return nil, ErrEmptyGenreName
+	}
+	normalizedName := models.NormalizeGenreName(name)
+
+	if err := s.checkNameFree(genre.ID, normalizedName); err != nil {
+		return nil, err
+	}
+
+	genre.Name = name
+	genre.NormalizedName = normalizedName
+
+	err = s.GenreRepo.Update(genre)
+	if err != nil {
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+// DeleteGenre удаляет жанр из справочника; песни, которым он был назначен, его теряют.
+func (s *GenreService) DeleteGenre(id uuid.UUID) error {
+	err := s.GenreRepo.Delete(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrGenreNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// AddSongGenre назначает песне жанр из справочника и возвращает обновлённую песню.
+func (s *GenreService) AddSongGenre(songID uuid.UUID, req models.AddSongGenreRequest) (*models.Song, error) {
+	if _, err := s.getSong(songID); err != nil {
+		return nil, err
+	}
+
+	genreID, err := uuid.Parse(req.GenreID)
+	if err != nil {
+		return nil, ErrUnknownGenre
+	}
+	if _, err := s.GenreRepo.GetByID(genreID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrUnknownGenre
+		}
+		return nil, err
+	}
+
+	if err := s.GenreRepo.AddToSong(songID, genreID); err != nil {
+		return nil, err
+	}
+
+	return s.getSong(songID)
+}
+
+func (s *GenreService) RemoveSongGenre(songID, genreID uuid.UUID) error {
+	if _, err := s.getSong(songID); err != nil {
+		return err
+	}
+
+	err := s.GenreRepo.RemoveFromSong(songID, genreID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrEntryNotFound) {
+			return ErrGenreNotAssigned
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *GenreService) checkNameFree(id uuid.UUID, normalizedName string) error {
+	existing, err := s.GenreRepo.GetByNormalizedName(normalizedName)
+	if err == nil && existing.ID != id {
+		return ErrGenreExists
+	}
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *GenreService) getSong(id uuid.UUID) (*models.Song, error) {
+	song, err := s.SongRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSongNotFound
+		}
+		return nil, err
+	}
+	return song, nil
+}
@@ -0,0 +1,418 @@
+// import_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"song_library/internal/importer"
+	"song_library/internal/models"
+	"song_library/internal/providers"
+	"song_library/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidFileFormat      = errors.New("format должен быть csv, json или ndjson")
+	ErrInvalidDuplicatePolicy = errors.New("onDuplicate должен быть skip, overwrite или merge")
+)
+
+// importNote - комментарий к ревизиям, записанным при импорте.
+const importNote = "Импорт"
+
+// ImportFileError - файл импорта не удалось разобрать целиком.
+type ImportFileError struct {
+	Err error
+}
+
+func (e *ImportFileError) Error() string {
+	return "не удалось разобрать файл импорта: " + e.Err.Error()
+}
+
+func (e *ImportFileError) Unwrap() error {
+	return e.Err
+}
+
+type ImportService struct {
+	SongRepo     repositories.SongStore
+	ArtistRepo   repositories.ArtistStore
+	RevisionRepo repositories.RevisionStore
+	SourceRepo   repositories.SongSourceStore
+	Tx           repositories.Transactor
+	Providers    *providers.Registry
+}
+
+func NewImportService(
+	songRepo repositories.SongStore,
+	artistRepo repositories.ArtistStore,
+	revisionRepo repositories.RevisionStore,
+	sourceRepo repositories.SongSourceStore,
+	tx repositories.Transactor,
+	registry *providers.Registry,
+) *ImportService {
+	return &ImportService{
+		SongRepo:     songRepo,
+		ArtistRepo:   artistRepo,
+		RevisionRepo: revisionRepo,
+		SourceRepo:   sourceRepo,
+		Tx:           tx,
+		Providers:    registry,
+	}
+}
+
+// importRun - состояние одного импорта.
+type importRun struct {
+	options   models.ImportOptions
+	changedBy string
+	// seen - ключи уже обработанных строк файла и номера этих строк
+	seen map[string]int
+	// titles - песни исполнителя по нормализованному названию, загружаются при первой встрече
+	titles map[uuid.UUID]map[string]uuid.UUID
+}
+
+// Import загружает песни из файла. Каждая строка проверяется и сохраняется отдельно:
+// ошибка в строке попадает в отчёт и не останавливает импорт. Ошибка возвращается,
+// только если неверны параметры или файл нельзя разобрать.
+func (s *ImportService) Import(r io.Reader, options models.ImportOptions, changedBy string) (*models.ImportReport, error) {
+	if !options.Format.Valid() {
+		return nil, ErrInvalidFileFormat
+	}
+	if options.OnDuplicate == "" {
+		options.OnDuplicate = models.DuplicateSkip
+	}
+	if !options.OnDuplicate.Valid() {
+		return nil, ErrInvalidDuplicatePolicy
+	}
+	if options.Enrich && !s.Providers.Enabled() {
+		return nil, ErrEnrichmentDisabled
+	}
+
+	records, err := importer.Parse(options.Format, r)
+	if err != nil {
+		return nil, &ImportFileError{Err: err}
+	}
+
+	run := &importRun{
+		options:   options,
+		changedBy: changedBy,
+		seen:      map[string]int{},
+		titles:    map[uuid.UUID]map[string]uuid.UUID{},
+	}
+	report := &models.ImportReport{
+		DryRun: options.DryRun,
+		Total:  len(records),
+		Rows:   make([]models.ImportRowResult, 0, len(records)),
+	}
+
+	for _, record := range records {
+		result := s.importRecord(run, record)
+		switch result.Status {
+		case models.ImportCreated:
+			report.Created++
+		case models.ImportUpdated:
+			report.Updated++
+		case models.ImportSkipped:
+			report.Skipped++
+		case models.ImportFailed:
+			report.Failed++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	return report, nil
+}
+
+func (s *ImportService) importRecord(run *importRun, record importer.Record) models.ImportRowResult {
+	result := models.ImportRowResult{
+		Row:       record.Row,
+		GroupName: models.CleanArtistName(record.Song.GroupName),
+		SongTitle: strings.TrimSpace(record.Song.SongTitle),
+	}
+	fail := func(err error) models.ImportRowResult {
+		result.Status = models.ImportFailed
+		result.Reason = err.Error()
+		return result
+	}
+
+	if record.Err != nil {
+		return fail(record.Err)
+	}
+	row, err := validateImportSong(record.Song)
+	if err != nil {
+		return fail(err)
+	}
+
+	keys := []string{"title:" + models.NormalizeArtistName(row.GroupName) + "\x00" + models.NormalizeArtistName(row.SongTitle)}
+	if row.ID != uuid.Nil {
+		keys = append(keys, "id:"+row.ID.String())
+	}
+	for _, key := range keys {
+		if previous, ok := run.seen[key]; ok {
+			return fail(fmt.Errorf("повторяет строку %d", previous))
+		}
+	}
+	for _, key := range keys {
+		run.seen[key] = record.Row
+	}
+
+	existing, err := s.findExisting(run, row)
+	if err != nil {
+		return fail(err)
+	}
+
+	if existing == nil {
+		song, err := s.create(run, row)
+		if err != nil {
+			return fail(err)
+		}
+		result.Status = models.ImportCreated
+		result.SongID = &song.ID
+		return result
+	}
+
+	result.SongID = &existing.ID
+	if run.options.OnDuplicate == models.DuplicateSkip {
+		result.Status = models.ImportSkipped
+		result.Reason = "песня уже есть в библиотеке"
+		return result
+	}
+
+	updated, err := s.update(run, existing.ID, row)
+	if err != nil {
+		return fail(err)
+	}
+	if !updated {
+		result.Status = models.ImportSkipped
+		result.Reason = "нет изменений"
+		return result
+	}
+	result.Status = models.ImportUpdated
+	return result
+}
+
+// validateImportSong проверяет строку и приводит значения к типам песни.
+func validateImportSong(song models.ImportSong) (*models.Song, error) {
+	row := &models.Song{
+		GroupName: models.CleanArtistName(song.GroupName),
+		SongTitle: strings.TrimSpace(song.SongTitle),
+		Text:      song.Text,
+		Link:      strings.TrimSpace(song.Link),
+	}
+	if row.GroupName == "" {
+		return nil, errors.New("не указан исполнитель (group)")
+	}
+	if row.SongTitle == "" {
+		return nil, errors.New("не указано название песни (song)")
+	}
+
+	if id := strings.TrimSpace(song.ID); id != "" {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("некорректный id: %s", id)
+		}
+		row.ID = parsed
+	}
+
+	if date := strings.TrimSpace(song.ReleaseDate); date != "" {
+		parsed, ok := models.ParseReleaseDate(date)
+		if !ok {
+			return nil, fmt.Errorf("некорректная дата выпуска: %s", date)
+		}
+		row.ReleaseDate = parsed
+	}
+
+	if row.Link != "" && !validLink(row.Link) {
+		return nil, fmt.Errorf("некорректная ссылка: %s", row.Link)
+	}
+
+	return row, nil
+}
+
+// recordSources блокирует поля, заданные в файле, чтобы обогащение их не перезаписало,
+// и, если импорт с обогащением, ставит песню с незаполненными полями в очередь.
+func recordSources(stores *repositories.Stores, run *importRun, song *models.Song, fileFields []string) error {
+	if err := stores.Sources.SetLocked(song.ID, fileFields, true); err != nil {
+		return err
+	}
+	if !run.options.Enrich || len(fileFields) == len(models.LockableSongFields) {
+		return nil
+	}
+	if err := stores.Songs.SetEnrichmentStatus(song.ID, models.EnrichmentPending); err != nil {
+		return err
+	}
+	return stores.Enrichment.Enqueue(song.ID, time.Now())
+}
+
+// findExisting ищет песню, которую повторяет строка: по id, если он указан,
+// иначе по исполнителю и названию без учёта регистра.
+func (s *ImportService) findExisting(run *importRun, row *models.Song) (*models.Song, error) {
+	if row.ID != uuid.Nil {
+		return s.getSong(row.ID)
+	}
+
+	artist, err := s.ArtistRepo.GetByNormalizedName(models.NormalizeArtistName(row.GroupName))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	titles, ok := run.titles[artist.ID]
+	if !ok {
+		titles, err = s.artistTitles(artist.ID)
+		if err != nil {
+			return nil, err
+		}
+		run.titles[artist.ID] = titles
+	}
+
+	songID, ok := titles[models.NormalizeArtistName(row.SongTitle)]
+	if !ok {
+		return nil, nil
+	}
+	return s.getSong(songID)
+}
+
+// artistTitles возвращает песни исполнителя по нормализованному названию.
+func (s *ImportService) artistTitles(artistID uuid.UUID) (map[string]uuid.UUID, error) {
+	const pageSize = 500
+
+	titles := map[string]uuid.UUID{}
+	filter := models.SongFilter{ArtistID: artistID}
+	projection := models.SongProjection{Columns: []string{"song_title"}, Without: models.SongRelations}
+	for offset := 0; ; offset += pageSize {
+		songs, total, err := s.SongRepo.GetAll(filter, projection, offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, song := range songs {
+			title := models.NormalizeArtistName(song.SongTitle)
+			if _, ok := titles[title]; !ok {
+				titles[title] = song.ID
+			}
+		}
+		if len(songs) == 0 || int64(offset+pageSize) >= total {
+			return titles, nil
+		}
+	}
+}
+
+func (s *ImportService) getSong(id uuid.UUID) (*models.Song, error) {
+	song, err := s.SongRepo.GetByID(id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil
+	}
+	return song, err
+}
+
+func (s *ImportService) create(run *importRun, row *models.Song) (*models.Song, error) {
+	if row.ID == uuid.Nil {
+		row.ID = uuid.New()
+	}
+	if run.options.DryRun {
+		return row, nil
+	}
+
+	// Поля из файла - ручные данные: обогащение не должно их перезаписать
+	fileFields := changedSongFields(&models.Song{}, row)
+	change := SongChange{ChangedBy: run.changedBy, Note: importNote}
+	err := writeSong(s.Tx, func(stores *repositories.Stores) error {
+		artist, err := resolveArtist(stores.Artists, row.GroupName)
+		if err != nil {
+			return err
+		}
+		row.ArtistID = artist.ID
+		row.Artist = artist
+		row.GroupName = artist.Name
+
+		if err := stores.Songs.Create(row); err != nil {
+			return err
+		}
+		if err := recordRevision(stores.Revisions, nil, row, change, nil); err != nil {
+			return err
+		}
+		return recordSources(stores, run, row, fileFields)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// update применяет строку к существующей песне по политике дубликатов
+// и сообщает, изменилась ли песня. Песня перечитывается под блокировкой
+// в транзакции записи, чтобы не затереть правки, сделанные после findExisting.
+func (s *ImportService) update(run *importRun, id uuid.UUID, row *models.Song) (bool, error) {
+	merge := run.options.OnDuplicate == models.DuplicateMerge
+	if run.options.DryRun {
+		song, err := s.SongRepo.GetByID(id)
+		if err != nil {
+			return false, err
+		}
+		return applyImportRow(song, row, merge), nil
+	}
+
+	fileFields := changedSongFields(&models.Song{}, row)
+	change := SongChange{ChangedBy: run.changedBy, Note: importNote}
+	var changed bool
+	err := writeSong(s.Tx, func(stores *repositories.Stores) error {
+		song, err := stores.Songs.GetForUpdate(id)
+		if err != nil {
+			return err
+		}
+		before := *song
+
+		changed = applyImportRow(song, row, merge)
+		if !changed {
+			return nil
+		}
+		if song.GroupName != before.GroupName {
+			artist, err := resolveArtist(stores.Artists, row.GroupName)
+			if err != nil {
+				return err
+			}
+			song.ArtistID = artist.ID
+			song.Artist = artist
+			song.GroupName = artist.Name
+		}
+
+		if err := stores.Songs.Update(song); err != nil {
+			return err
+		}
+		if err := recordRevision(stores.Revisions, &before, song, change, nil); err != nil {
+			return err
+		}
+		return recordSources(stores, run, song, fileFields)
+	})
+	if err != nil {
+		return false, err
+	}
+	return changed, nil
+}
+
+// applyImportRow переносит поля строки в песню: при merge пустые поля строки
+// не затирают заполненные. Сообщает, изменилась ли песня.
+func applyImportRow(song *models.Song, row *models.Song, merge bool) bool {
+	before := *song
+
+	if models.NormalizeArtistName(row.GroupName) != models.NormalizeArtistName(song.GroupName) {
+		song.GroupName = row.GroupName
+	}
+	song.SongTitle = row.SongTitle
+	if !merge || !row.ReleaseDate.IsZero() {
+		song.ReleaseDate = row.ReleaseDate
+	}
+	if !merge || row.Text != "" {
+		song.Text = row.Text
+	}
+	if !merge || row.Link != "" {
+		song.Link = row.Link
+	}
+
+	return !models.SnapshotOf(&before).Equal(models.SnapshotOf(song))
+}
@@ -0,0 +1,118 @@
+// import_service_test.go
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"song_library/internal/models"
+	"song_library/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateImportSong(t *testing.T) {
+	id := "123e4567-e89b-12d3-a456-426614174000"
+
+	tests := []struct {
+		name    string
+		song    models.ImportSong
+		want    models.Song
+		wantErr string
+	}{
+		{
+			name: "значения очищаются от пробелов",
+			song: models.ImportSong{ID: " " + id + " ", GroupName: "  Muse ", SongTitle: " Uprising ", Link: " https://example.com/u "},
+			want: models.Song{ID: uuid.MustParse(id), GroupName: "Muse", SongTitle: "Uprising", Link: "https://example.com/u"},
+		},
+		{
+			name: "дата в любом формате ParseReleaseDate",
+			song: models.ImportSong{GroupName: "Muse", SongTitle: "Uprising", ReleaseDate: "16 июля 2006"},
+			want: models.Song{GroupName: "Muse", SongTitle: "Uprising", ReleaseDate: mustReleaseDate(t, "2006-07-16")},
+		},
+		{name: "без исполнителя", song: models.ImportSong{GroupName: " ", SongTitle: "Uprising"}, wantErr: "не указан исполнитель"},
+		{name: "без названия", song: models.ImportSong{GroupName: "Muse"}, wantErr: "не указано название"},
+		{name: "некорректный id", song: models.ImportSong{ID: "42", GroupName: "Muse", SongTitle: "Uprising"}, wantErr: "некорректный id"},
+		{name: "некорректная дата", song: models.ImportSong{GroupName: "Muse", SongTitle: "Uprising", ReleaseDate: "31 апреля 2006"}, wantErr: "некорректная дата"},
+		{name: "ссылка без схемы http", song: models.ImportSong{GroupName: "Muse", SongTitle: "Uprising", Link: "ftp://example.com"}, wantErr: "некорректная ссылка"},
+		{name: "ссылка без адреса", song: models.ImportSong{GroupName: "Muse", SongTitle: "Uprising", Link: "https://"}, wantErr: "некорректная ссылка"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateImportSong(tt.song)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validateImportSong = %v, ожидалась ошибка %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateImportSong: %v", err)
+			}
+			if got.ID != tt.want.ID || got.GroupName != tt.want.GroupName || got.SongTitle != tt.want.SongTitle ||
+				got.Link != tt.want.Link || !got.ReleaseDate.Equal(tt.want.ReleaseDate) {
+				t.Errorf("validateImportSong = %+v, ожидалось %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportDuplicateRows(t *testing.T) {
+	id := "123e4567-e89b-12d3-a456-426614174000"
+	file := strings.Join([]string{
+		`{"group":"Muse","song":"Uprising"}`,
+		`{"group":" MUSE ","song":"uprising","text":"повтор по названию"}`,
+		`{"id":"` + id + `","group":"Muse","song":"Hysteria"}`,
+		`{"id":"` + id + `","group":"Kino","song":"Kukushka"}`,
+		`{"group":"Muse"}`,
+		`{"group":"Muse","song":"Starlight"}`,
+	}, "\n")
+
+	tests := []struct {
+		row    int
+		status models.ImportStatus
+		reason string
+	}{
+		{row: 1, status: models.ImportCreated},
+		{row: 2, status: models.ImportFailed, reason: "повторяет строку 1"},
+		{row: 3, status: models.ImportCreated},
+		{row: 4, status: models.ImportFailed, reason: "повторяет строку 3"},
+		{row: 5, status: models.ImportFailed, reason: "не указано название песни (song)"},
+		{row: 6, status: models.ImportCreated},
+	}
+
+	storage := repositories.NewMemoryStorage()
+	stores := repositories.NewMemoryStores(storage)
+	service := NewImportService(stores.Songs, stores.Artists, stores.Revisions, stores.Sources,
+		repositories.NewMemoryTransactor(storage), nil)
+
+	report, err := service.Import(strings.NewReader(file), models.ImportOptions{Format: models.FormatNDJSON}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != len(tests) || report.Created != 3 || report.Failed != 3 {
+		t.Errorf("отчёт: всего %d, создано %d, ошибок %d; ожидалось 6, 3 и 3", report.Total, report.Created, report.Failed)
+	}
+	for i, tt := range tests {
+		got := report.Rows[i]
+		if got.Row != tt.row || got.Status != tt.status || got.Reason != tt.reason {
+			t.Errorf("строка %d = %s %q, ожидалось %s %q", got.Row, got.Status, got.Reason, tt.status, tt.reason)
+		}
+	}
+
+	// Песня из строки 3 сохранена с ID из файла, а строка 4 её не изменила
+	song, err := stores.Songs.GetByID(uuid.MustParse(id))
+	if err != nil || song.SongTitle != "Hysteria" {
+		t.Errorf("песня по id из файла: %v, %v", song, err)
+	}
+}
+
+func mustReleaseDate(t *testing.T, value string) models.ReleaseDate {
+	t.Helper()
+	date, ok := models.ParseReleaseDate(value)
+	if !ok {
+		t.Fatalf("ParseReleaseDate(%q) не разобрал дату", value)
+	}
+	return date
+}
@@ -0,0 +1,222 @@
+// playlist_service.go
+package services
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+
+	"song_library/internal/models"
+	"song_library/internal/repositories"
+	"song_library/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPlaylistNotFound      = errors.New("плейлист не найден")
+	ErrPlaylistEntryNotFound = errors.New("запись плейлиста не найдена")
+	ErrEmptyPlaylistName     = errors.New("название плейлиста не может быть пустым")
+	ErrPlaylistSongNotFound  = errors.New("добавляемая песня не найдена")
+)
+
+type PlaylistService struct {
+	PlaylistRepo repositories.PlaylistStore
+	SongRepo     repositories.SongStore
+}
+
+func NewPlaylistService(playlistRepo repositories.PlaylistStore, songRepo repositories.SongStore) *PlaylistService {
+	return &PlaylistService{
+		PlaylistRepo: playlistRepo,
+		SongRepo:     songRepo,
+	}
+}
+
+func (s *PlaylistService) GetPlaylists(pagination *utils.Pagination) ([]models.Playlist, error) {
+	playlists, _, err := s.PlaylistRepo.GetAll(pagination.GetOffset(), pagination.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	return playlists, nil
+}
+
+func (s *PlaylistService) GetPlaylist(id uuid.UUID) (*models.Playlist, error) {
+	playlist, err := s.PlaylistRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPlaylistNotFound
+		}
+		return nil, err
+	}
+	return playlist, nil
+}
+
+func (s *PlaylistService) AddPlaylist(req models.CreatePlaylistRequest) (*models.Playlist, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrEmptyPlaylistName
+	}
+
+	playlist := &models.Playlist{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+	}
+
+	err := s.PlaylistRepo.Create(playlist)
+	if err != nil {
+		return nil, err
+	}
+
+	return playlist, nil
+}
+
+func (s *PlaylistService) UpdatePlaylist(id uuid.UUID, req models.UpdatePlaylistRequest) (*models.Playlist, error) {
+	playlist, err := s.GetPlaylist(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			return nil, ErrEmptyPlaylistName
+		}
+		playlist.Name = name
+	}
+	if req.Description != nil {
+		playlist.Description = strings.TrimSpace(*req.Description)
+	}
+
+	err = s.PlaylistRepo.Update(playlist)
+	if err != nil {
+		return nil, err
+	}
+
+	return playlist, nil
+}
+
+func (s *PlaylistService) DeletePlaylist(id uuid.UUID) error {
+	return s.playlistError(s.PlaylistRepo.Delete(id))
+}
+
+func (s *PlaylistService) GetEntries(id uuid.UUID, pagination *utils.Pagination) (*models.PlaylistEntriesResponse, error) {
+	entries, total, err := s.PlaylistRepo.GetEntries(id, pagination.GetOffset(), pagination.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		// Пустой список и несуществующий плейлист выглядят одинаково - уточняем
+		if _, err := s.GetPlaylist(id); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &models.PlaylistEntriesResponse{
+		Entries: entries,
+		Page:    pagination.Page,
+		Limit:   pagination.Limit,
+		Total:   total,
+	}
+
+	return response, nil
+}
+
+// GetShuffledEntries возвращает записи в детерминированно перемешанном порядке.
+// Один и тот же seed для одного и того же плейлиста всегда даёт один и тот же порядок;
+// если seed не передан, он генерируется и возвращается в ответе.
+func (s *PlaylistService) GetShuffledEntries(id uuid.UUID, seed *int64, pagination *utils.Pagination) (*models.PlaylistEntriesResponse, error) {
+	if _, err := s.GetPlaylist(id); err != nil {
+		return nil, err
+	}
+
+	if seed == nil {
+		generated := rand.Int63()
+		seed = &generated
+	}
+
+	entries, err := s.PlaylistRepo.GetAllEntries(id)
+	if err != nil {
+		return nil, err
+	}
+
+	random := rand.New(rand.NewSource(*seed))
+	random.Shuffle(len(entries), func(i, j int) {
+		entries[i], entries[j] = entries[j], entries[i]
+	})
+
+	total := len(entries)
+	offset := pagination.GetOffset()
+	if offset > total {
+		offset = total
+	}
+	end := offset + pagination.GetLimit()
+	if end > total {
+		end = total
+	}
+
+	response := &models.PlaylistEntriesResponse{
+		Entries: entries[offset:end],
+		Page:    pagination.Page,
+		Limit:   pagination.Limit,
+		Total:   int64(total),
+		Seed:    seed,
+	}
+
+	return response, nil
+}
+
+func (s *PlaylistService) AddEntry(id uuid.UUID, req models.AddPlaylistEntryRequest) (*models.PlaylistEntry, error) {
+	songID, err := uuid.Parse(req.SongID)
+	if err != nil {
+		return nil, ErrPlaylistSongNotFound
+	}
+
+	song, err := s.SongRepo.GetByID(songID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrPlaylistSongNotFound
+		}
+		return nil, err
+	}
+
+	entry := &models.PlaylistEntry{
+		ID:         uuid.New(),
+		PlaylistID: id,
+		SongID:     songID,
+		Position:   req.Position,
+		AddedAt:    time.Now(),
+	}
+
+	err = s.PlaylistRepo.InsertEntry(entry)
+	if err != nil {
+		return nil, s.playlistError(err)
+	}
+
+	entry.Song = song
+	return entry, nil
+}
+
+func (s *PlaylistService) MoveEntry(id, entryID uuid.UUID, req models.MovePlaylistEntryRequest) (*models.PlaylistEntry, error) {
+	entry, err := s.PlaylistRepo.MoveEntry(id, entryID, req.Position)
+	if err != nil {
+		return nil, s.playlistError(err)
+	}
+	return entry, nil
+}
+
+func (s *PlaylistService) RemoveEntry(id, entryID uuid.UUID) error {
+	return s.playlistError(s.PlaylistRepo.RemoveEntry(id, entryID))
+}
+
+// playlistError переводит ошибки хранилища в ошибки сервиса.
+func (s *PlaylistService) playlistError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		return ErrPlaylistNotFound
+	case errors.Is(err, repositories.ErrEntryNotFound):
+		return ErrPlaylistEntryNotFound
+	}
+	return err
+}
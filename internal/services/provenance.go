@@ -0,0 +1,58 @@
+// provenance.go
+package services
+
+import (
+	"song_library/internal/models"
+	"song_library/internal/providers"
+	"song_library/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+// changedSongFields возвращает блокируемые поля, которые отличаются у before и after.
+func changedSongFields(before, after *models.Song) []string {
+	var fields []string
+	if !before.ReleaseDate.Equal(after.ReleaseDate) {
+		fields = append(fields, models.SongFieldReleaseDate)
+	}
+	if before.Text != after.Text {
+		fields = append(fields, models.SongFieldText)
+	}
+	if before.Link != after.Link {
+		fields = append(fields, models.SongFieldLink)
+	}
+	return fields
+}
+
+// lockChangedFields блокирует поля, которые изменились при ручной правке песни,
+// чтобы обогащение их больше не перезаписывало.
+func lockChangedFields(store repositories.SongSourceStore, before, after *models.Song) error {
+	return store.SetLocked(after.ID, changedSongFields(before, after), true)
+}
+
+// lockedSongFields возвращает заблокированные поля песни.
+func lockedSongFields(store repositories.SongSourceStore, song *models.Song) (map[string]bool, error) {
+	sources, err := store.GetBySong(song.ID)
+	if err != nil {
+		return nil, err
+	}
+	locked := map[string]bool{}
+	for _, source := range sources {
+		if source.Locked {
+			locked[source.Field] = true
+		}
+	}
+	return locked, nil
+}
+
+// fieldSource превращает происхождение поля из ответа Registry в запись для хранилища.
+func fieldSource(songID uuid.UUID, field providers.Field, source providers.Source) models.SongFieldSource {
+	fetchedAt := source.FetchedAt
+	return models.SongFieldSource{
+		SongID:    songID,
+		Field:     string(field),
+		Provider:  source.Provider,
+		FetchedAt: &fetchedAt,
+		Payload:   models.RawJSON(source.Payload),
+	}
+}
@@ -0,0 +1,278 @@
+// revision_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"song_library/internal/lyrics"
+	"song_library/internal/models"
+	"song_library/internal/repositories"
+	"song_library/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRevisionNotFound = errors.New("ревизия не найдена")
+	ErrInvalidRevision  = errors.New("номер ревизии должен быть положительным числом")
+)
+
+// baselineNote - пометка ревизии с состоянием песни, изменённой до появления истории.
+const baselineNote = "Состояние до первого изменения"
+
+// revisionAttempts - сколько раз повторяется изменение песни, если номер её новой
+// ревизии одновременно занял другой запрос.
+const revisionAttempts = 3
+
+// SongChange описывает, кто и зачем меняет песню.
+type SongChange struct {
+	ChangedBy string
+	Note      string
+}
+
+type RevisionService struct {
+	RevisionRepo repositories.RevisionStore
+	SongRepo     repositories.SongStore
+	ArtistRepo   repositories.ArtistStore
+	Tx           repositories.Transactor
+}
+
+func NewRevisionService(
+	revisionRepo repositories.RevisionStore,
+	songRepo repositories.SongStore,
+	artistRepo repositories.ArtistStore,
+	tx repositories.Transactor,
+) *RevisionService {
+	return &RevisionService{
+		RevisionRepo: revisionRepo,
+		SongRepo:     songRepo,
+		ArtistRepo:   artistRepo,
+		Tx:           tx,
+	}
+}
+
+func (s *RevisionService) GetRevisions(songID uuid.UUID, pagination *utils.Pagination) (*models.SongRevisionsResponse, error) {
+	if _, err := s.getSong(songID); err != nil {
+		return nil, err
+	}
+
+	revisions, total, err := s.RevisionRepo.GetBySong(songID, pagination.GetOffset(), pagination.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SongRevisionsResponse{
+		Revisions: revisions,
+		Page:      pagination.Page,
+		Limit:     pagination.Limit,
+		Total:     total,
+	}, nil
+}
+
+func (s *RevisionService) GetRevision(songID uuid.UUID, revision int) (*models.SongRevision, error) {
+	if revision < 1 {
+		return nil, ErrInvalidRevision
+	}
+	if _, err := s.getSong(songID); err != nil {
+		return nil, err
+	}
+
+	found, err := s.RevisionRepo.Get(songID, revision)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return found, nil
+}
+
+// DiffRevisions сравнивает две ревизии песни. Порядок не важен: from может быть
+// и новее to, тогда diff показывает, как вернуться к старому состоянию.
+// Нулевой to означает последнюю ревизию, нулевой from - ревизию перед to;
+// первая ревизия сравнивается с пустой песней.
+func (s *RevisionService) DiffRevisions(songID uuid.UUID, from, to int) (*models.RevisionDiff, error) {
+	if from < 0 || to < 0 {
+		return nil, ErrInvalidRevision
+	}
+
+	var newer *models.SongRevision
+	var err error
+	if to == 0 {
+		if _, err := s.getSong(songID); err != nil {
+			return nil, err
+		}
+		newer, err = s.RevisionRepo.Latest(songID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+	} else {
+		newer, err = s.GetRevision(songID, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if from == 0 {
+		from = newer.Revision - 1
+	}
+	older := &models.SongRevision{}
+	if from > 0 {
+		older, err = s.GetRevision(songID, from)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	diff := &models.RevisionDiff{
+		From:   from,
+		To:     newer.Revision,
+		Fields: fieldChanges(older.Snapshot, newer.Snapshot),
+		Lines:  lyrics.DiffLines(older.Snapshot.Text, newer.Snapshot.Text),
+	}
+	for _, line := range diff.Lines {
+		switch line.Op {
+		case models.DiffInsert:
+			diff.Added++
+		case models.DiffDelete:
+			diff.Removed++
+		}
+	}
+	return diff, nil
+}
+
+// RestoreRevision возвращает песню к состоянию ревизии. История не переписывается:
+// восстановление записывается новой ревизией со ссылкой на восстановленную.
+func (s *RevisionService) RestoreRevision(songID uuid.UUID, revision int, change SongChange) (*models.Song, error) {
+	restored, err := s.GetRevision(songID, revision)
+	if err != nil {
+		return nil, err
+	}
+	if change.Note == "" {
+		change.Note = fmt.Sprintf("Восстановлена ревизия %d", revision)
+	}
+
+	var song *models.Song
+	err = writeSong(s.Tx, func(stores *repositories.Stores) error {
+		current, err := stores.Songs.GetForUpdate(songID)
+		if err != nil {
+			return err
+		}
+		before := *current
+
+		snapshot := restored.Snapshot
+		artist, err := stores.Artists.GetByID(snapshot.ArtistID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			// Исполнителя могли удалить после ревизии - создаём его заново по имени
+			artist, err = resolveArtist(stores.Artists, snapshot.GroupName)
+		}
+		if err != nil {
+			return err
+		}
+
+		current.ArtistID = artist.ID
+		current.Artist = artist
+		current.GroupName = artist.Name
+		current.SongTitle = snapshot.SongTitle
+		current.ReleaseDate = snapshot.ReleaseDate
+		current.Text = snapshot.Text
+		current.Link = snapshot.Link
+
+		if err := stores.Songs.Update(current); err != nil {
+			return err
+		}
+		if err := recordRevision(stores.Revisions, &before, current, change, &revision); err != nil {
+			return err
+		}
+		song = current
+		// Откат - ручная правка: восстановленные значения обогащение не трогает
+		return lockChangedFields(stores.Sources, &before, current)
+	})
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrSongNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return song, nil
+}
+
+func (s *RevisionService) getSong(songID uuid.UUID) (*models.Song, error) {
+	song, err := s.SongRepo.GetByID(songID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSongNotFound
+		}
+		return nil, err
+	}
+	return song, nil
+}
+
+// writeSong выполняет изменение песни вместе с записью его ревизии в одной транзакции.
+// Если номер ревизии одновременно занял другой запрос, транзакция повторяется
+// целиком: fn должна заново прочитать песню через переданные хранилища.
+func writeSong(tx repositories.Transactor, fn func(stores *repositories.Stores) error) error {
+	for attempt := 1; ; attempt++ {
+		err := tx.Transaction(fn)
+		if !errors.Is(err, repositories.ErrConflict) || attempt == revisionAttempts {
+			return err
+		}
+	}
+}
+
+// recordRevision записывает состояние песни после изменения. before - состояние
+// до него (nil для новой песни): если у песни ещё нет истории, оно сохраняется
+// первой ревизией, чтобы правку песни, добавленной до появления истории, можно было откатить.
+// Изменение, после которого песня не отличается от последней ревизии, не записывается.
+func recordRevision(store repositories.RevisionStore, before, after *models.Song, change SongChange, restoredFrom *int) error {
+	latest, err := store.Latest(after.ID)
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		latest = nil
+		if before != nil {
+			latest = &models.SongRevision{
+				SongID:   before.ID,
+				Snapshot: models.SnapshotOf(before),
+				Note:     baselineNote,
+			}
+			if err := store.Create(latest); err != nil {
+				return err
+			}
+		}
+	case err != nil:
+		return err
+	}
+
+	snapshot := models.SnapshotOf(after)
+	if latest != nil && latest.Snapshot.Equal(snapshot) && restoredFrom == nil {
+		return nil
+	}
+
+	return store.Create(&models.SongRevision{
+		SongID:       after.ID,
+		Snapshot:     snapshot,
+		ChangedBy:    change.ChangedBy,
+		Note:         change.Note,
+		RestoredFrom: restoredFrom,
+	})
+}
+
+func fieldChanges(older, newer models.SongSnapshot) []models.FieldChange {
+	changes := []models.FieldChange{}
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, models.FieldChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("group", older.GroupName, newer.GroupName)
+	add("song", older.SongTitle, newer.SongTitle)
+	add("releaseDate", formatReleaseDate(older), formatReleaseDate(newer))
+	add("link", older.Link, newer.Link)
+	return changes
+}
+
+func formatReleaseDate(snapshot models.SongSnapshot) string {
+	return snapshot.ReleaseDate.String()
+}
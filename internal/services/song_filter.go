@@ -0,0 +1,178 @@
+// song_filter.go
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// FilterError перечисляет все ошибки в параметрах фильтра и сортировки списка песен,
+// чтобы клиент мог исправить их за один раз.
+type FilterError struct {
+	Details []string
+}
+
+func (e *FilterError) Error() string {
+	return "некорректные параметры фильтра: " + strings.Join(e.Details, "; ")
+}
+
+// maxFilterIDs - сколько ID песен можно передать в ids.
+const maxFilterIDs = 100
+
+// parseFilterParams проверяет параметры запроса со способами сравнения, статусом обогащения,
+// диапазонами дат, флагами, списком ID и сортировкой и заполняет соответствующие поля фильтра.
+func parseFilterParams(filter *models.SongFilter) error {
+	var details []string
+	fail := func(format string, args ...any) {
+		details = append(details, fmt.Sprintf(format, args...))
+	}
+
+	if filter.Match == "" {
+		filter.Match = models.TextMatchContains
+	}
+	if !filter.Match.Valid() {
+		fail("match: ожидается contains, prefix или exact, передано %q", filter.Match)
+	}
+	if filter.TagMatch == "" {
+		filter.TagMatch = models.TagMatchAny
+	}
+	if !filter.TagMatch.Valid() {
+		fail("tagMatch: ожидается any или all, передано %q", filter.TagMatch)
+	}
+	if filter.Enrichment != "" && !filter.Enrichment.Valid() {
+		fail("enrichment: ожидается pending, enriched, failed или not_found, передано %q", filter.Enrichment)
+	}
+
+	filter.IDs = nil
+	for _, value := range splitList(filter.IDsParam) {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			fail("ids: некорректный ID %q", value)
+			continue
+		}
+		filter.IDs = append(filter.IDs, id)
+	}
+	if len(filter.IDs) > maxFilterIDs {
+		fail("ids: не больше %d ID, передано %d", maxFilterIDs, len(filter.IDs))
+	}
+
+	ranges := []struct {
+		name     string
+		from, to string
+		target   *models.TimeRange
+	}{
+		{"released", filter.ReleasedFromParam, filter.ReleasedToParam, &filter.Released},
+		{"created", filter.CreatedFromParam, filter.CreatedToParam, &filter.Created},
+		{"updated", filter.UpdatedFromParam, filter.UpdatedToParam, &filter.Updated},
+	}
+	for _, r := range ranges {
+		var timeRange models.TimeRange
+		if r.from != "" {
+			from, _, ok := parseFilterTime(r.from)
+			if !ok {
+				fail("%sFrom: некорректная дата %q", r.name, r.from)
+			}
+			timeRange.From = from
+		}
+		if r.to != "" {
+			_, before, ok := parseFilterTime(r.to)
+			if !ok {
+				fail("%sTo: некорректная дата %q", r.name, r.to)
+			}
+			timeRange.Before = before
+		}
+		if !timeRange.From.IsZero() && !timeRange.Before.IsZero() && !timeRange.From.Before(timeRange.Before) {
+			fail("%sFrom позже %sTo", r.name, r.name)
+		}
+		*r.target = timeRange
+	}
+
+	flags := []struct {
+		name   string
+		value  string
+		target **bool
+	}{
+		{"hasLyrics", filter.HasLyricsParam, &filter.HasLyrics},
+		{"hasLink", filter.HasLinkParam, &filter.HasLink},
+	}
+	for _, flag := range flags {
+		*flag.target = nil
+		if flag.value == "" {
+			continue
+		}
+		value, err := strconv.ParseBool(flag.value)
+		if err != nil {
+			fail("%s: ожидается true или false, передано %q", flag.name, flag.value)
+			continue
+		}
+		*flag.target = &value
+	}
+
+	order, sortDetails := parseSongOrder(filter.SortParam)
+	filter.Order = order
+	details = append(details, sortDetails...)
+
+	if len(details) > 0 {
+		return &FilterError{Details: details}
+	}
+	return nil
+}
+
+// parseFilterTime разбирает границу диапазона дат. Момент в RFC 3339 задаёт точное время,
+// а дата в любом формате ParseReleaseDate - весь период: для 1999 это весь 1999 год.
+// Возвращает начало периода и момент сразу после его конца.
+func parseFilterTime(value string) (time.Time, time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, t.Add(time.Nanosecond), true
+	}
+	date, ok := models.ParseReleaseDate(value)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	return date.Time, date.End(), true
+}
+
+// parseSongOrder разбирает сортировку вида -releaseDate,group: поля через запятую,
+// минус перед полем - по убыванию.
+func parseSongOrder(value string) ([]models.SongOrder, []string) {
+	var order []models.SongOrder
+	var details []string
+	seen := map[models.SongSortField]bool{}
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		desc := strings.HasPrefix(item, "-")
+		name := strings.TrimLeft(item, "+-")
+
+		var field models.SongSortField
+		for _, allowed := range models.SongSortFields {
+			if strings.EqualFold(string(allowed), name) {
+				field = allowed
+				break
+			}
+		}
+		switch {
+		case field == "":
+			details = append(details, fmt.Sprintf("sort: по полю %q сортировать нельзя; доступны song, group, releaseDate, createdAt, updatedAt", name))
+		case seen[field]:
+			details = append(details, fmt.Sprintf("sort: поле %s указано дважды", field))
+		default:
+			seen[field] = true
+			order = append(order, models.SongOrder{Field: field, Desc: desc})
+		}
+	}
+	return order, details
+}
@@ -0,0 +1,168 @@
+// song_filter_test.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestParseFilterParams(t *testing.T) {
+	id := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	yes, no := true, false
+
+	manyIDs := make([]string, maxFilterIDs+1)
+	for i := range manyIDs {
+		manyIDs[i] = fmt.Sprintf("00000000-0000-0000-0000-%012d", i)
+	}
+
+	tests := []struct {
+		name   string
+		filter models.SongFilter
+		// check проверяет разобранный фильтр, если ошибок нет
+		check func(t *testing.T, filter models.SongFilter)
+		// wantDetails - начала ожидаемых сообщений об ошибках в порядке проверки
+		wantDetails []string
+	}{
+		{
+			name: "значения по умолчанию",
+			check: func(t *testing.T, filter models.SongFilter) {
+				if filter.Match != models.TextMatchContains || filter.TagMatch != models.TagMatchAny {
+					t.Errorf("match %q, tagMatch %q", filter.Match, filter.TagMatch)
+				}
+				if filter.IDs != nil || filter.HasLyrics != nil || filter.Order != nil {
+					t.Errorf("лишние поля: %+v", filter)
+				}
+			},
+		},
+		{
+			name: "ID через запятую и повтором параметра",
+			filter: models.SongFilter{
+				IDsParam: []string{id.String() + ", ", " " + id.String()},
+			},
+			check: func(t *testing.T, filter models.SongFilter) {
+				if !reflect.DeepEqual(filter.IDs, []uuid.UUID{id, id}) {
+					t.Errorf("IDs = %v", filter.IDs)
+				}
+			},
+		},
+		{
+			name: "год включает весь год",
+			filter: models.SongFilter{
+				ReleasedFromParam: "1999",
+				ReleasedToParam:   "2001-02",
+				CreatedToParam:    "2024-01-02T10:00:00Z",
+			},
+			check: func(t *testing.T, filter models.SongFilter) {
+				want := models.TimeRange{From: day(1999, 1, 1), Before: day(2001, 3, 1)}
+				if !filter.Released.From.Equal(want.From) || !filter.Released.Before.Equal(want.Before) {
+					t.Errorf("Released = %+v, ожидалось %+v", filter.Released, want)
+				}
+				created := time.Date(2024, 1, 2, 10, 0, 0, 1, time.UTC)
+				if !filter.Created.From.IsZero() || !filter.Created.Before.Equal(created) {
+					t.Errorf("Created = %+v", filter.Created)
+				}
+			},
+		},
+		{
+			name:   "флаги",
+			filter: models.SongFilter{HasLyricsParam: "true", HasLinkParam: "0"},
+			check: func(t *testing.T, filter models.SongFilter) {
+				if !reflect.DeepEqual(filter.HasLyrics, &yes) || !reflect.DeepEqual(filter.HasLink, &no) {
+					t.Errorf("hasLyrics %v, hasLink %v", filter.HasLyrics, filter.HasLink)
+				}
+			},
+		},
+		{
+			name: "все ошибки сразу",
+			filter: models.SongFilter{
+				Match:             "fuzzy",
+				TagMatch:          "some",
+				Enrichment:        "done",
+				IDsParam:          []string{"not-an-id"},
+				ReleasedFromParam: "вчера",
+				CreatedFromParam:  "2024-02",
+				CreatedToParam:    "2024-01",
+				HasLyricsParam:    "maybe",
+				SortParam:         "rating",
+			},
+			wantDetails: []string{
+				"match:", "tagMatch:", "enrichment:", "ids: некорректный ID",
+				"releasedFrom:", "createdFrom позже createdTo", "hasLyrics:", "sort:",
+			},
+		},
+		{
+			name:        "слишком много ID",
+			filter:      models.SongFilter{IDsParam: []string{strings.Join(manyIDs, ",")}},
+			wantDetails: []string{fmt.Sprintf("ids: не больше %d ID", maxFilterIDs)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			err := parseFilterParams(&filter)
+
+			var filterErr *FilterError
+			if tt.wantDetails == nil {
+				if err != nil {
+					t.Fatalf("неожиданная ошибка: %v", err)
+				}
+				tt.check(t, filter)
+				return
+			}
+			if !errors.As(err, &filterErr) {
+				t.Fatalf("ошибка %v, ожидалась FilterError", err)
+			}
+			if len(filterErr.Details) != len(tt.wantDetails) {
+				t.Fatalf("ошибки %q, ожидалось %d", filterErr.Details, len(tt.wantDetails))
+			}
+			for i, want := range tt.wantDetails {
+				if !strings.HasPrefix(filterErr.Details[i], want) {
+					t.Errorf("ошибка %d = %q, ожидалось начало %q", i, filterErr.Details[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSongOrder(t *testing.T) {
+	tests := []struct {
+		value       string
+		want        []models.SongOrder
+		wantDetails int
+	}{
+		{value: "", want: nil},
+		{value: "song", want: []models.SongOrder{{Field: models.SortSong}}},
+		{
+			value: " -releaseDate , +group,CREATEDAT,",
+			want: []models.SongOrder{
+				{Field: models.SortReleaseDate, Desc: true},
+				{Field: models.SortGroup},
+				{Field: models.SortCreatedAt},
+			},
+		},
+		{value: "song,-song", want: []models.SongOrder{{Field: models.SortSong}}, wantDetails: 1},
+		{value: "rating,-text", wantDetails: 2},
+	}
+
+	for _, tt := range tests {
+		order, details := parseSongOrder(tt.value)
+		if !reflect.DeepEqual(order, tt.want) {
+			t.Errorf("parseSongOrder(%q) = %+v, ожидалось %+v", tt.value, order, tt.want)
+		}
+		if len(details) != tt.wantDetails {
+			t.Errorf("parseSongOrder(%q): ошибки %q, ожидалось %d", tt.value, details, tt.wantDetails)
+		}
+	}
+}
@@ -3,142 +3,723 @@ package services
 
 import (
 	"errors"
+	"math"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
+	"song_library/internal/lyrics"
 	"song_library/internal/models"
 	"song_library/internal/repositories"
 	"song_library/internal/utils"
-	"song_library/pkg/external_api"
 
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 var (
-	ErrSongNotFound = errors.New("песня не найдена")
+	ErrSongNotFound        = errors.New("песня не найдена")
+	ErrEmptySearchQuery    = errors.New("пустой поисковый запрос")
+	ErrInvalidLyricsMode   = errors.New("mode должен быть expand или collapse")
+	ErrInvalidLyricsView   = errors.New("view должен быть translated или side-by-side")
+	ErrTranslationRequired = errors.New("для view=side-by-side укажите язык перевода в lang")
+	ErrInvalidLRC          = lyrics.ErrInvalidLRC
+	ErrNoSyncedLyrics      = errors.New("у песни нет синхронизированного текста")
+	ErrInvalidTimeWindow   = errors.New("некорректное окно времени")
+	ErrInvalidSongDate     = errors.New("некорректная дата выпуска; ожидается 2006-07-16, 2006-07, 2006, 16.07.2006, July 16, 2006 или 16 июля 2006")
+	ErrInvalidLink         = errors.New("ссылка должна быть адресом http или https")
+	ErrInvalidAddSongMode  = errors.New("mode должен быть enrich, enrich-if-missing или manual-only")
+	ErrInvalidCursor       = errors.New("некорректный курсор в after или before")
+	ErrCursorConflict      = errors.New("after и before нельзя задавать вместе")
+	ErrCursorWithSort      = errors.New("курсоры after и before работают только в порядке добавления; с sort используйте page")
+	ErrCursorWithAlbum     = errors.New("песни альбома идут в порядке трек-листа; курсоры after и before для них не поддерживаются, используйте page")
 )
 
 type SongService struct {
-	SongRepo          *repositories.SongRepository
-	ExternalAPIClient *external_api.MusicAPIClient
+	SongRepo        repositories.SongStore
+	ArtistRepo      repositories.ArtistStore
+	AlbumRepo       repositories.AlbumStore
+	PlaylistRepo    repositories.PlaylistStore
+	TranslationRepo repositories.TranslationStore
+	RevisionRepo    repositories.RevisionStore
+	SourceRepo      repositories.SongSourceStore
+	Tx              repositories.Transactor
+	Enrichment      *EnrichmentService
+	// Cursors подписывает курсоры списка песен
+	Cursors *utils.Cursors
 }
 
-func NewSongService(repo *repositories.SongRepository, apiClient *external_api.MusicAPIClient) *SongService {
+// parseSongFields проверяет дату выпуска и ссылку из запроса; пустые значения допустимы.
+func parseSongFields(releaseDate, link string) (models.ReleaseDate, string, error) {
+	var date models.ReleaseDate
+	if value := strings.TrimSpace(releaseDate); value != "" {
+		parsed, ok := models.ParseReleaseDate(value)
+		if !ok {
+			return models.ReleaseDate{}, "", ErrInvalidSongDate
+		}
+		date = parsed
+	}
+	link = strings.TrimSpace(link)
+	if link != "" && !validLink(link) {
+		return models.ReleaseDate{}, "", ErrInvalidLink
+	}
+	return date, link, nil
+}
+
+// validLink проверяет, что ссылка - абсолютный адрес http или https.
+func validLink(value string) bool {
+	link, err := url.Parse(value)
+	return err == nil && (link.Scheme == "http" || link.Scheme == "https") && link.Host != ""
+}
+
+func NewSongService(
+	repo repositories.SongStore,
+	artistRepo repositories.ArtistStore,
+	albumRepo repositories.AlbumStore,
+	playlistRepo repositories.PlaylistStore,
+	translationRepo repositories.TranslationStore,
+	revisionRepo repositories.RevisionStore,
+	sourceRepo repositories.SongSourceStore,
+	tx repositories.Transactor,
+	enrichment *EnrichmentService,
+	cursorSecret string,
+) *SongService {
 	return &SongService{
-		SongRepo:          repo,
-		ExternalAPIClient: apiClient,
+		SongRepo:        repo,
+		ArtistRepo:      artistRepo,
+		AlbumRepo:       albumRepo,
+		PlaylistRepo:    playlistRepo,
+		TranslationRepo: translationRepo,
+		RevisionRepo:    revisionRepo,
+		SourceRepo:      sourceRepo,
+		Tx:              tx,
+		Enrichment:      enrichment,
+		Cursors:         utils.NewCursors(cursorSecret),
 	}
 }
 
-func (s *SongService) GetSongs(filter models.SongFilter, pagination *utils.Pagination) ([]models.Song, error) {
+// GetSongs возвращает страницу песен с полями из options.Fields; по умолчанию без текста.
+// Страница выбирается по номеру или по курсору after/before; курсоры следующей
+// и предыдущей страниц возвращаются в обоих режимах, кроме списка песен альбома.
+func (s *SongService) GetSongs(filter models.SongFilter, options models.SongViewOptions, pagination *utils.Pagination) (*models.SongListResponse, error) {
 	offset := pagination.GetOffset()
 	limit := pagination.GetLimit()
 
-	songs, _, err := s.SongRepo.GetAll(filter, offset, limit)
+	filter, err := prepareSongFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	view, err := newSongView(options, false)
+	if err != nil {
+		return nil, err
+	}
+	// Курсоры построены на порядке добавления, поэтому в другом порядке их нет
+	cursors := filter.AlbumID == uuid.Nil && len(filter.Order) == 0
+	if cursors && len(view.projection.Columns) > 0 && !slices.Contains(view.projection.Columns, "created_at") {
+		// Курсор строится по ключу сортировки, даже если его поля не запрошены
+		view.projection.Columns = append(view.projection.Columns, "created_at")
+	}
+
+	fetch := limit
+	if pagination.Cursor() {
+		if filter.AlbumID != uuid.Nil {
+			return nil, ErrCursorWithAlbum
+		}
+		if !cursors {
+			return nil, ErrCursorWithSort
+		}
+		if pagination.After != "" && pagination.Before != "" {
+			return nil, ErrCursorConflict
+		}
+		if filter.After, err = s.decodeSongCursor(pagination.After); err != nil {
+			return nil, err
+		}
+		if filter.Before, err = s.decodeSongCursor(pagination.Before); err != nil {
+			return nil, err
+		}
+		// Лишняя песня показывает, есть ли страница дальше в направлении чтения
+		offset, fetch = 0, limit+1
+	}
+
+	songs, total, err := s.SongRepo.GetAll(filter, view.projection, offset, fetch)
 	if err != nil {
 		return nil, err
 	}
 
-	return songs, nil
+	var info models.PageInfo
+	var hasNext, hasPrev bool
+	if pagination.Cursor() {
+		// На странице по курсору песни не пересчитываются
+		info = models.PageInfo{Limit: limit}
+		more := len(songs) > limit
+		switch {
+		case filter.Before != nil:
+			if more {
+				songs = songs[1:]
+			}
+			hasNext, hasPrev = true, more
+		default:
+			if more {
+				songs = songs[:limit]
+			}
+			hasNext, hasPrev = more, true
+		}
+	} else {
+		info = models.NewPageInfo(pagination.Page, pagination.Limit, total)
+		hasNext = int64(offset+len(songs)) < total
+		hasPrev = offset > 0
+	}
+	if cursors && len(songs) > 0 {
+		if hasNext {
+			if info.NextCursor, err = s.Cursors.Encode(models.KeyOf(&songs[len(songs)-1])); err != nil {
+				return nil, err
+			}
+		}
+		if hasPrev {
+			if info.PrevCursor, err = s.Cursors.Encode(models.KeyOf(&songs[0])); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	views, err := s.renderSongs(view, songs)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SongListResponse{
+		Items:    views,
+		PageInfo: info,
+	}, nil
 }
 
-func (s *SongService) AddSong(groupName, songTitle string) (*models.Song, error) {
+// decodeSongCursor проверяет курсор списка песен; пустой курсор - nil.
+func (s *SongService) decodeSongCursor(token string) (*models.SongKey, error) {
+	if token == "" {
+		return nil, nil
+	}
+	var key models.SongKey
+	if err := s.Cursors.Decode(token, &key); err != nil || key.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	return &key, nil
+}
 
-	songDetail, err := s.ExternalAPIClient.GetSongInfo(groupName, songTitle)
+// GetSong возвращает песню с полями из options.Fields; по умолчанию со всеми, включая текст.
+func (s *SongService) GetSong(id uuid.UUID, options models.SongViewOptions) (*models.SongView, error) {
+	view, err := newSongView(options, true)
 	if err != nil {
 		return nil, err
 	}
 
-	releaseDate, err := time.Parse("02.01.2006", songDetail.ReleaseDate)
+	song, err := s.SongRepo.GetProjected(id, view.projection)
 	if err != nil {
-		releaseDate = time.Time{}
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSongNotFound
+		}
+		return nil, err
+	}
+	views, err := s.renderSongs(view, []models.Song{*song})
+	if err != nil {
+		return nil, err
+	}
+	return &views[0], nil
+}
+
+// GetSongFacets возвращает число песен по каждому тегу и жанру среди песен под фильтром.
+func (s *SongService) GetSongFacets(filter models.SongFilter) (*models.SongFacetsResponse, error) {
+	filter, err := prepareSongFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.SongRepo.Facets(filter)
+}
+
+func (s *SongService) SearchSongs(query string, pagination *utils.Pagination) (*models.SongSearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	results, total, err := s.SongRepo.Search(query, pagination.GetOffset(), pagination.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.SongSearchResponse{
+		Results: results,
+		Page:    pagination.Page,
+		Limit:   pagination.Limit,
+		Total:   total,
+	}
+
+	return response, nil
+}
+
+// AddSong сохраняет песню сразу, не дожидаясь источников метаданных: дата выпуска, текст
+// и ссылка заполняются позже очередью обогащения, пока песня в статусе pending.
+func (s *SongService) AddSong(req models.AddSongRequest, changedBy string) (*models.Song, error) {
+	mode := req.Mode
+	if mode == "" {
+		mode = models.AddSongEnrich
+	}
+	if !mode.Valid() {
+		return nil, ErrInvalidAddSongMode
+	}
+	releaseDate, link, err := parseSongFields(req.ReleaseDate, req.Link)
+	if err != nil {
+		return nil, err
+	}
+
+	artist, err := resolveArtist(s.ArtistRepo, req.GroupName)
+	if err != nil {
+		return nil, err
 	}
 
 	newSong := &models.Song{
 		ID:          uuid.New(),
-		GroupName:   groupName,
-		SongTitle:   songTitle,
+		ArtistID:    artist.ID,
+		Artist:      artist,
+		GroupName:   artist.Name,
+		SongTitle:   req.SongTitle,
 		ReleaseDate: releaseDate,
-		Text:        songDetail.Text,
-		Link:        songDetail.Link,
+		Text:        req.Text,
+		Link:        link,
+	}
+
+	// Заблокированные поля обогащение не перезаписывает
+	var locked []string
+	switch mode {
+	case models.AddSongEnrichIfMissing:
+		locked = changedSongFields(&models.Song{}, newSong)
+	case models.AddSongManualOnly:
+		locked = models.LockableSongFields
+	}
+	enrich := s.Enrichment.Enabled() && len(locked) < len(models.LockableSongFields)
+	if enrich {
+		newSong.EnrichmentStatus = models.EnrichmentPending
 	}
 
-	err = s.SongRepo.Create(newSong)
+	// Песня, её ревизия, блокировки и задание обогащения сохраняются вместе:
+	// песня в статусе pending без задания осталась бы в нём навсегда
+	err = writeSong(s.Tx, func(stores *repositories.Stores) error {
+		if err := stores.Songs.Create(newSong); err != nil {
+			return err
+		}
+		if err := recordRevision(stores.Revisions, nil, newSong, SongChange{ChangedBy: changedBy}, nil); err != nil {
+			return err
+		}
+		if err := stores.Sources.SetLocked(newSong.ID, locked, true); err != nil {
+			return err
+		}
+		if !enrich {
+			return nil
+		}
+		return stores.Enrichment.Enqueue(newSong.ID, time.Now())
+	})
 	if err != nil {
 		return nil, err
 	}
+	if enrich {
+		s.Enrichment.notify()
+	}
 
-	return newSong, nil
+	return s.SongRepo.GetByID(newSong.ID)
 }
 
-func (s *SongService) GetSongLyrics(id uuid.UUID, pagination *utils.Pagination) (*models.SongLyricsResponse, error) {
-	song, err := s.SongRepo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+// GetSongLyrics возвращает страницу размеченных частей текста. В режиме collapse
+// повторы остаются на своих местах, но без текста - только со ссылкой repeatOf.
+// Если выбран перевод, части показываются в переводе, а в режиме side-by-side
+// оригинал остаётся и к нему добавляются пары оригинал-перевод той же страницы.
+func (s *SongService) GetSongLyrics(id uuid.UUID, options models.LyricsOptions, pagination *utils.Pagination) (*models.SongLyricsResponse, error) {
+	if options.Mode == "" {
+		options.Mode = models.LyricsExpand
+	}
+	if !options.Mode.Valid() {
+		return nil, ErrInvalidLyricsMode
+	}
+	if options.View == "" {
+		options.View = models.LyricsTranslated
+	}
+	if !options.View.Valid() {
+		return nil, ErrInvalidLyricsView
+	}
+
+	if _, err := s.SongRepo.GetByID(id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
 			return nil, ErrSongNotFound
 		}
 		return nil, err
 	}
 
-	verses := strings.Split(song.Text, "\n\n")
-	totalVerses := len(verses)
-
-	offset := pagination.GetOffset()
-	limit := pagination.GetLimit()
-
-	if offset > totalVerses {
-		offset = totalVerses
+	translation, err := s.lyricsTranslation(id, options)
+	if err != nil {
+		return nil, err
+	}
+	if translation == nil && options.View == models.LyricsSideBySide {
+		return nil, ErrTranslationRequired
 	}
 
-	end := offset + limit
-	if end > totalVerses {
-		end = totalVerses
+	sections, err := s.SongRepo.GetSections(id)
+	if err != nil {
+		return nil, err
 	}
+	totalSections := len(sections)
 
-	paginatedVerses := verses[offset:end]
+	translated := translatedVerses(translation, sections)
+	sections = paginateSections(sections, pagination.GetOffset(), pagination.GetLimit())
 
 	response := &models.SongLyricsResponse{
-		Verses: paginatedVerses,
-		Page:   pagination.Page,
-		Limit:  pagination.Limit,
-		Total:  totalVerses,
+		Verses:   make([]string, len(sections)),
+		Sections: sections,
+		PageInfo: models.NewPageInfo(pagination.Page, pagination.Limit, int64(totalSections)),
+	}
+	if translation != nil {
+		response.Translation = &models.TranslationInfo{
+			Language: translation.Language,
+			Author:   translation.Author,
+			Source:   translation.Source,
+		}
+	}
+	if options.View == models.LyricsSideBySide {
+		response.Pairs = make([]models.LyricsPair, len(sections))
+	}
+
+	for i := range sections {
+		collapsed := options.Mode == models.LyricsCollapse && sections[i].RepeatOf != nil
+		text, ok := translated[sections[i].Position]
+
+		switch {
+		case collapsed:
+			sections[i].Text = ""
+			text = ""
+		case translation != nil && options.View == models.LyricsTranslated && ok:
+			sections[i].Text = text
+		}
+		if response.Pairs != nil {
+			response.Pairs[i] = models.LyricsPair{
+				Position:    sections[i].Position,
+				Original:    sections[i].Text,
+				Translation: text,
+			}
+		}
+		response.Verses[i] = sections[i].Text
 	}
 
 	return response, nil
 }
 
-func (s *SongService) UpdateSong(id uuid.UUID, req models.UpdateSongRequest) (*models.Song, error) {
+// lyricsTranslation выбирает перевод для ответа /lyrics. Явно запрошенный язык
+// должен найтись, а Accept-Language учитывается по возможности: если подходящего
+// перевода нет, возвращается оригинал.
+func (s *SongService) lyricsTranslation(id uuid.UUID, options models.LyricsOptions) (*models.Translation, error) {
+	if options.Language == models.LyricsOriginal {
+		return nil, nil
+	}
+
+	preferred := options.AcceptLanguages
+	if options.Language != "" {
+		language, err := lyrics.CanonicalLanguage(options.Language)
+		if err != nil {
+			return nil, err
+		}
+		preferred = []string{language}
+	}
+	if len(preferred) == 0 {
+		return nil, nil
+	}
+
+	translations, err := s.TranslationRepo.GetBySong(id)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]string, len(translations))
+	for i, translation := range translations {
+		available[i] = translation.Language
+	}
+
+	language, ok := lyrics.MatchLanguage(available, preferred)
+	if !ok {
+		if options.Language != "" {
+			return nil, ErrTranslationNotFound
+		}
+		return nil, nil
+	}
+
+	for i := range translations {
+		if translations[i].Language == language {
+			return &translations[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// translatedVerses возвращает переводы частей по позициям. Повтор без своего
+// перевода получает перевод первой части, которую он повторяет.
+func translatedVerses(translation *models.Translation, sections []models.LyricsSection) map[int]string {
+	if translation == nil {
+		return nil
+	}
+
+	texts := make(map[int]string, len(sections))
+	for _, verse := range translation.Verses {
+		texts[verse.Position] = verse.Text
+	}
+	for _, section := range sections {
+		if _, ok := texts[section.Position]; ok || section.RepeatOf == nil {
+			continue
+		}
+		if text, ok := texts[*section.RepeatOf]; ok {
+			texts[section.Position] = text
+		}
+	}
+	return texts
+}
+
+// ImportSyncedLyrics сохраняет строки LRC песни. Если текст песни совпадает со строками
+// LRC, он не меняется и строки привязываются к его частям; иначе текстом песни
+// становится текст из LRC, чтобы пагинация по частям соответствовала синхронизации.
+func (s *SongService) ImportSyncedLyrics(id uuid.UUID, data string, changedBy string) (*models.SyncedLinesResponse, error) {
+	parsed, err := lyrics.ParseLRC(data)
+	if err != nil {
+		return nil, err
+	}
+
+	change := SongChange{ChangedBy: changedBy, Note: "Текст заменён текстом из LRC"}
+	err = writeSong(s.Tx, func(stores *repositories.Stores) error {
+		current, err := stores.Songs.GetForUpdate(id)
+		if err != nil {
+			return err
+		}
+
+		sections, err := stores.Songs.GetSections(id)
+		if err != nil {
+			return err
+		}
+
+		if !lyrics.AssignVerses(parsed.Lines, sections) {
+			before := *current
+			current.Text = parsed.PlainText()
+			if err := stores.Songs.Update(current); err != nil {
+				return err
+			}
+			if err := recordRevision(stores.Revisions, &before, current, change, nil); err != nil {
+				return err
+			}
+			if err := lockChangedFields(stores.Sources, &before, current); err != nil {
+				return err
+			}
+
+			sections, err = stores.Songs.GetSections(id)
+			if err != nil {
+				return err
+			}
+			lyrics.AssignVerses(parsed.Lines, sections)
+		}
+
+		return stores.Songs.SetSyncedLines(id, parsed.Lines)
+	})
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrSongNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SyncedLinesResponse{
+		Lines: parsed.Lines,
+		Total: len(parsed.Lines),
+	}, nil
+}
+
+// DeleteSyncedLyrics удаляет временные метки; текст песни остаётся.
+func (s *SongService) DeleteSyncedLyrics(id uuid.UUID) error {
+	lines, err := s.syncedLines(id)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return ErrNoSyncedLyrics
+	}
+	return s.SongRepo.SetSyncedLines(id, nil)
+}
+
+// ExportLRC собирает LRC песни; withWords добавляет метки слов (расширенный LRC).
+func (s *SongService) ExportLRC(id uuid.UUID, withWords bool) (string, error) {
+	lines, err := s.syncedLines(id)
+	if err != nil {
+		return "", err
+	}
+	if len(lines) == 0 {
+		return "", ErrNoSyncedLyrics
+	}
+
 	song, err := s.SongRepo.GetByID(id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+
+	return lyrics.FormatLRC(song.SongTitle, song.GroupName, lines, withWords), nil
+}
+
+// GetSyncedLines возвращает строки, звучащие в окне времени [from, to).
+// Время задаётся в секундах или как mm:ss.xx; без границ возвращаются все строки.
+// Если from и to совпадают, возвращается строка, звучащая в этот момент.
+func (s *SongService) GetSyncedLines(id uuid.UUID, from, to string) (*models.SyncedLinesResponse, error) {
+	lines, err := s.syncedLines(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, ErrNoSyncedLyrics
+	}
+
+	if from != "" || to != "" {
+		fromMs, toMs := int64(0), int64(math.MaxInt64)
+		if from != "" {
+			if fromMs, err = lyrics.ParseTimestamp(from); err != nil {
+				return nil, ErrInvalidTimeWindow
+			}
+		}
+		if to != "" {
+			if toMs, err = lyrics.ParseTimestamp(to); err != nil {
+				return nil, ErrInvalidTimeWindow
+			}
+		}
+		if toMs < fromMs {
+			return nil, ErrInvalidTimeWindow
+		}
+		if toMs == fromMs {
+			toMs++
+		}
+
+		lines, err = s.SongRepo.GetSyncedLinesBetween(id, fromMs, toMs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.SyncedLinesResponse{
+		Lines: lines,
+		Total: len(lines),
+	}, nil
+}
+
+func (s *SongService) syncedLines(id uuid.UUID) ([]models.SyncedLine, error) {
+	if _, err := s.SongRepo.GetByID(id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
 			return nil, ErrSongNotFound
 		}
 		return nil, err
 	}
+	return s.SongRepo.GetSyncedLines(id)
+}
+
+// BackfillLyricsSections размечает тексты песен, сохранённых до появления частей.
+func (s *SongService) BackfillLyricsSections() (int, error) {
+	return s.SongRepo.BackfillSections()
+}
 
-	if req.GroupName != "" {
-		song.GroupName = req.GroupName
+func paginateSections(sections []models.LyricsSection, offset, limit int) []models.LyricsSection {
+	if offset > len(sections) {
+		offset = len(sections)
 	}
-	if req.SongTitle != "" {
-		song.SongTitle = req.SongTitle
+	end := offset + limit
+	if end > len(sections) {
+		end = len(sections)
 	}
+	return sections[offset:end]
+}
 
-	err = s.SongRepo.Update(song)
+// UpdateSong сохраняет изменения песни и записывает их в историю ревизий.
+// Изменённые дата, текст и ссылка блокируются от перезаписи обогащением.
+func (s *SongService) UpdateSong(id uuid.UUID, req models.UpdateSongRequest, changedBy string) (*models.Song, error) {
+	releaseDate, link, err := parseSongFields(req.ReleaseDate, req.Link)
 	if err != nil {
 		return nil, err
 	}
 
+	change := SongChange{ChangedBy: changedBy, Note: req.Note}
+	var song *models.Song
+	err = writeSong(s.Tx, func(stores *repositories.Stores) error {
+		current, err := stores.Songs.GetForUpdate(id)
+		if err != nil {
+			return err
+		}
+		before := *current
+
+		if req.GroupName != "" {
+			artist, err := resolveArtist(stores.Artists, req.GroupName)
+			if err != nil {
+				return err
+			}
+			current.ArtistID = artist.ID
+			current.Artist = artist
+			current.GroupName = artist.Name
+		}
+		if req.SongTitle != "" {
+			current.SongTitle = req.SongTitle
+		}
+		if !releaseDate.IsZero() {
+			current.ReleaseDate = releaseDate
+		}
+		if req.Text != "" {
+			current.Text = req.Text
+		}
+		if link != "" {
+			current.Link = link
+		}
+
+		if err := stores.Songs.Update(current); err != nil {
+			return err
+		}
+		if err := recordRevision(stores.Revisions, &before, current, change, nil); err != nil {
+			return err
+		}
+		song = current
+		return lockChangedFields(stores.Sources, &before, current)
+	})
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrSongNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
 	return song, nil
 }
 
+// DeleteSong удаляет песню. Записи плейлистов с этой песней не удаляются,
+// а помечаются, чтобы клиенты могли показать пропавший трек.
 func (s *SongService) DeleteSong(id uuid.UUID) error {
-	err := s.SongRepo.Delete(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrSongNotFound
+	err := s.Tx.Transaction(func(stores *repositories.Stores) error {
+		if err := stores.Songs.Delete(id); err != nil {
+			return err
 		}
-		return err
+		return stores.Playlists.MarkSongRemoved(id, time.Now())
+	})
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrSongNotFound
+	}
+	return err
+}
+
+// prepareSongFilter приводит теги и жанры фильтра к нормализованному виду и разбирает
+// остальные параметры запроса (parseFilterParams). Теги, жанры и ID в запросе можно
+// передать повторяющимся параметром или через запятую.
+func prepareSongFilter(filter models.SongFilter) (models.SongFilter, error) {
+	filter.Tags = normalizedNames(splitList(filter.Tags), models.NormalizeTagName)
+	filter.Genres = normalizedNames(splitList(filter.Genres), models.NormalizeGenreName)
+	if err := parseFilterParams(&filter); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func splitList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		items = append(items, strings.Split(value, ",")...)
 	}
-	return nil
+	return items
 }
@@ -0,0 +1,243 @@
+// song_view.go
+package services
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"song_library/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSongField = errors.New("неизвестное поле песни в fields; доступны id, artistId, group, song, releaseDate, text, link, genres, tags, enrichmentStatus, enrichment, createdAt, updatedAt")
+	ErrInvalidExpand    = errors.New("неизвестная связь в expand; доступны artist, albums, provenance, translations")
+)
+
+// songField - поле песни в ответе API: колонки, из которых оно читается,
+// и связь, которую для него нужно подгрузить.
+type songField struct {
+	name     string
+	columns  []string
+	relation string
+	// omitEmpty - поле не выводится, если value вернула nil
+	omitEmpty bool
+	value     func(song *models.Song) any
+}
+
+var songFields = []songField{
+	{"id", []string{"id"}, "", false, func(song *models.Song) any { return song.ID }},
+	{"artistId", []string{"artist_id"}, "", false, func(song *models.Song) any { return song.ArtistID }},
+	{"group", []string{"artist_id"}, models.SongRelationArtist, false, func(song *models.Song) any { return song.GroupName }},
+	{"song", []string{"song_title"}, "", false, func(song *models.Song) any { return song.SongTitle }},
+	{"releaseDate", []string{"release_date", "release_date_precision"}, "", false, func(song *models.Song) any { return song.ReleaseDate }},
+	{"text", []string{"text"}, "", false, func(song *models.Song) any { return song.Text }},
+	{"link", []string{"link"}, "", false, func(song *models.Song) any { return song.Link }},
+	{"genres", nil, models.SongRelationGenres, true, func(song *models.Song) any {
+		if len(song.Genres) == 0 {
+			return nil
+		}
+		return song.Genres
+	}},
+	{"tags", nil, models.SongRelationTags, true, func(song *models.Song) any {
+		if len(song.Tags) == 0 {
+			return nil
+		}
+		return song.Tags
+	}},
+	{"enrichmentStatus", []string{"enrichment_status"}, "", true, func(song *models.Song) any {
+		if song.EnrichmentStatus == "" {
+			return nil
+		}
+		return song.EnrichmentStatus
+	}},
+	{"enrichment", nil, models.SongRelationEnrichment, true, func(song *models.Song) any {
+		if song.Enrichment == nil {
+			return nil
+		}
+		return song.Enrichment
+	}},
+	{"createdAt", []string{"created_at"}, "", false, func(song *models.Song) any { return song.CreatedAt }},
+	{"updatedAt", []string{"updated_at"}, "", false, func(song *models.Song) any { return song.UpdatedAt }},
+}
+
+// Связанные данные, которые можно добавить к песне через expand.
+const (
+	expandArtist       = "artist"
+	expandAlbums       = "albums"
+	expandProvenance   = "provenance"
+	expandTranslations = "translations"
+)
+
+var songExpansions = []string{expandArtist, expandAlbums, expandProvenance, expandTranslations}
+
+// maxSongAlbums - сколько альбомов песни возвращает expand=albums.
+const maxSongAlbums = 100
+
+// songView - проверенные fields и expand вместе с проекцией для хранилища.
+type songView struct {
+	fields     []songField
+	expand     []string
+	projection models.SongProjection
+}
+
+// newSongView проверяет fields и expand. Без fields возвращаются все поля,
+// а если withText = false - все, кроме текста. id выводится всегда.
+func newSongView(options models.SongViewOptions, withText bool) (*songView, error) {
+	view := &songView{}
+
+	selected := map[string]bool{"id": true}
+	defaults := true
+	for _, name := range splitList(options.Fields) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		i := slices.IndexFunc(songFields, func(field songField) bool {
+			return strings.EqualFold(field.name, name)
+		})
+		if i < 0 {
+			return nil, ErrInvalidSongField
+		}
+		selected[songFields[i].name] = true
+		defaults = false
+	}
+
+	for _, name := range splitList(options.Expand) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || slices.Contains(view.expand, name) {
+			continue
+		}
+		if !slices.Contains(songExpansions, name) {
+			return nil, ErrInvalidExpand
+		}
+		view.expand = append(view.expand, name)
+	}
+
+	relations := map[string]bool{}
+	if slices.Contains(view.expand, expandArtist) {
+		relations[models.SongRelationArtist] = true
+		view.projection.Columns = append(view.projection.Columns, "artist_id")
+	}
+	// Поля выводятся в порядке songFields, как и раньше в models.Song
+	for _, field := range songFields {
+		if defaults && (field.name != "text" || withText) || selected[field.name] {
+			view.fields = append(view.fields, field)
+			for _, column := range field.columns {
+				if !slices.Contains(view.projection.Columns, column) {
+					view.projection.Columns = append(view.projection.Columns, column)
+				}
+			}
+			if field.relation != "" {
+				relations[field.relation] = true
+			}
+		}
+	}
+	for _, relation := range models.SongRelations {
+		if !relations[relation] {
+			view.projection.Without = append(view.projection.Without, relation)
+		}
+	}
+	return view, nil
+}
+
+// songExpansion - связанные данные страницы песен из expand, загруженные
+// одним запросом на связь, а не на каждую песню.
+type songExpansion struct {
+	albums       map[uuid.UUID][]models.Album
+	provenance   map[uuid.UUID][]models.SongFieldSource
+	translations map[uuid.UUID][]models.Translation
+}
+
+// renderSongs оставляет у песен поля из view и добавляет связанные данные из expand.
+func (s *SongService) renderSongs(view *songView, songs []models.Song) ([]models.SongView, error) {
+	expansion, err := s.loadExpansion(view, songs)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]models.SongView, 0, len(songs))
+	for i := range songs {
+		song := &songs[i]
+		var result models.SongView
+		for _, field := range view.fields {
+			value := field.value(song)
+			if value == nil && field.omitEmpty {
+				continue
+			}
+			result.Add(field.name, value)
+		}
+		for _, name := range view.expand {
+			result.Add(name, expansion.value(song, name))
+		}
+		views = append(views, result)
+	}
+	return views, nil
+}
+
+// loadExpansion загружает связанные данные из expand для всех песен сразу.
+func (s *SongService) loadExpansion(view *songView, songs []models.Song) (*songExpansion, error) {
+	expansion := &songExpansion{}
+	if len(songs) == 0 {
+		return expansion, nil
+	}
+
+	ids := make([]uuid.UUID, len(songs))
+	for i := range songs {
+		ids[i] = songs[i].ID
+	}
+
+	var err error
+	for _, name := range view.expand {
+		switch name {
+		case expandAlbums:
+			expansion.albums, err = s.AlbumRepo.GetBySongs(ids)
+		case expandProvenance:
+			expansion.provenance, err = s.SourceRepo.GetBySongs(ids)
+		case expandTranslations:
+			expansion.translations, err = s.TranslationRepo.GetBySongs(ids)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return expansion, nil
+}
+
+// value возвращает связанные данные name для песни.
+func (e *songExpansion) value(song *models.Song, name string) any {
+	switch name {
+	case expandArtist:
+		return song.Artist
+	case expandAlbums:
+		albums := e.albums[song.ID]
+		if len(albums) > maxSongAlbums {
+			albums = albums[:maxSongAlbums]
+		}
+		return nonNil(albums)
+	case expandProvenance:
+		return nonNil(e.provenance[song.ID])
+	case expandTranslations:
+		translations := e.translations[song.ID]
+		infos := make([]models.TranslationInfo, 0, len(translations))
+		for _, translation := range translations {
+			infos = append(infos, models.TranslationInfo{
+				Language: translation.Language,
+				Author:   translation.Author,
+				Source:   translation.Source,
+			})
+		}
+		return infos
+	}
+	return nil
+}
+
+// nonNil заменяет nil на пустой список, чтобы в JSON он выводился как [].
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,120 @@
+// tag_service.go
+package services
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"song_library/internal/models"
+	"song_library/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidTag     = errors.New("тег не может быть пустым или длиннее 64 символов")
+	ErrTagNotAssigned = errors.New("тег не назначен песне")
+)
+
+type TagService struct {
+	TagRepo  repositories.TagStore
+	SongRepo repositories.SongStore
+}
+
+func NewTagService(tagRepo repositories.TagStore, songRepo repositories.SongStore) *TagService {
+	return &TagService{
+		TagRepo:  tagRepo,
+		SongRepo: songRepo,
+	}
+}
+
+// AddSongTags назначает песне теги, создавая новые, и возвращает обновлённую песню.
+// Теги, отличающиеся только регистром и пробелами, считаются одним тегом.
+func (s *TagService) AddSongTags(songID uuid.UUID, req models.AddSongTagsRequest) (*models.Song, error) {
+	if _, err := s.getSong(songID); err != nil {
+		return nil, err
+	}
+
+	for _, name := range req.Tags {
+		name = models.CleanArtistName(name)
+		if name == "" || utf8.RuneCountInString(name) > models.MaxTagLength {
+			return nil, ErrInvalidTag
+		}
+	}
+
+	tags, err := s.TagRepo.FindOrCreate(uniqueNames(req.Tags, models.NormalizeTagName))
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]uuid.UUID, 0, len(tags))
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	if err := s.TagRepo.AddToSong(songID, tagIDs); err != nil {
+		return nil, err
+	}
+
+	return s.getSong(songID)
+}
+
+func (s *TagService) RemoveSongTag(songID uuid.UUID, name string) error {
+	if _, err := s.getSong(songID); err != nil {
+		return err
+	}
+
+	tag, err := s.TagRepo.GetByNormalizedName(models.NormalizeTagName(name))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrTagNotAssigned
+		}
+		return err
+	}
+
+	err = s.TagRepo.RemoveFromSong(songID, tag.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrEntryNotFound) {
+			return ErrTagNotAssigned
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *TagService) getSong(id uuid.UUID) (*models.Song, error) {
+	song, err := s.SongRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSongNotFound
+		}
+		return nil, err
+	}
+	return song, nil
+}
+
+// uniqueNames очищает названия и убирает пустые и повторяющиеся после нормализации.
+func uniqueNames(names []string, normalize func(string) string) []string {
+	seen := make(map[string]struct{}, len(names))
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		name = models.CleanArtistName(name)
+		key := normalize(name)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, name)
+	}
+	return unique
+}
+
+func normalizedNames(names []string, normalize func(string) string) []string {
+	unique := uniqueNames(names, normalize)
+	for i, name := range unique {
+		unique[i] = normalize(name)
+	}
+	return unique
+}
@@ -0,0 +1,176 @@
+// translation_service.go
+package services
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"song_library/internal/lyrics"
+	"song_library/internal/models"
+	"song_library/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidLanguage       = lyrics.ErrInvalidLanguage
+	ErrTranslationNotFound   = errors.New("перевод не найден")
+	ErrEmptyTranslation      = errors.New("перевод должен содержать text или verses")
+	ErrTranslationMisaligned = errors.New("части перевода не совпадают с частями оригинала: передайте verses с позициями")
+	ErrInvalidVersePosition  = errors.New("позиция части перевода не совпадает с частями оригинала")
+)
+
+type TranslationService struct {
+	TranslationRepo repositories.TranslationStore
+	SongRepo        repositories.SongStore
+}
+
+func NewTranslationService(translationRepo repositories.TranslationStore, songRepo repositories.SongStore) *TranslationService {
+	return &TranslationService{
+		TranslationRepo: translationRepo,
+		SongRepo:        songRepo,
+	}
+}
+
+func (s *TranslationService) GetTranslations(songID uuid.UUID) ([]models.Translation, error) {
+	if err := s.checkSong(songID); err != nil {
+		return nil, err
+	}
+	return s.TranslationRepo.GetBySong(songID)
+}
+
+func (s *TranslationService) GetTranslation(songID uuid.UUID, language string) (*models.Translation, error) {
+	language, err := lyrics.CanonicalLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSong(songID); err != nil {
+		return nil, err
+	}
+
+	translation, err := s.TranslationRepo.Get(songID, language)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrTranslationNotFound
+		}
+		return nil, err
+	}
+	return translation, nil
+}
+
+// SaveTranslation создаёт или заменяет перевод песни на язык. Второе значение
+// сообщает, был ли перевод создан.
+func (s *TranslationService) SaveTranslation(songID uuid.UUID, language string, req models.SaveTranslationRequest) (*models.Translation, bool, error) {
+	language, err := lyrics.CanonicalLanguage(language)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := s.checkSong(songID); err != nil {
+		return nil, false, err
+	}
+
+	sections, err := s.SongRepo.GetSections(songID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	translation := &models.Translation{
+		SongID:   songID,
+		Language: language,
+		Text:     strings.TrimSpace(req.Text),
+		Author:   strings.TrimSpace(req.Author),
+		Source:   strings.TrimSpace(req.Source),
+	}
+
+	switch {
+	case len(req.Verses) > 0:
+		verses, err := checkVerses(req.Verses, sections)
+		if err != nil {
+			return nil, false, err
+		}
+		translation.Verses = verses
+		if translation.Text == "" {
+			texts := make([]string, len(verses))
+			for i, verse := range verses {
+				texts[i] = verse.Text
+			}
+			translation.Text = strings.Join(texts, "\n\n")
+		}
+	case translation.Text != "":
+		verses, ok := lyrics.AlignTranslation(sections, translation.Text)
+		if !ok {
+			return nil, false, ErrTranslationMisaligned
+		}
+		translation.Verses = verses
+	default:
+		return nil, false, ErrEmptyTranslation
+	}
+
+	created := true
+	existing, err := s.TranslationRepo.Get(songID, language)
+	if err == nil {
+		created = false
+		translation.CreatedAt = existing.CreatedAt
+	} else if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, false, err
+	}
+
+	if err := s.TranslationRepo.Save(translation); err != nil {
+		return nil, false, err
+	}
+	return translation, created, nil
+}
+
+func (s *TranslationService) DeleteTranslation(songID uuid.UUID, language string) error {
+	language, err := lyrics.CanonicalLanguage(language)
+	if err != nil {
+		return err
+	}
+	if err := s.checkSong(songID); err != nil {
+		return err
+	}
+
+	err = s.TranslationRepo.Delete(songID, language)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrTranslationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *TranslationService) checkSong(songID uuid.UUID) error {
+	if _, err := s.SongRepo.GetByID(songID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrSongNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// checkVerses проверяет явно заданные части перевода: каждая должна ссылаться
+// на существующую часть оригинала не больше одного раза.
+func checkVerses(verses []models.TranslatedVerse, sections []models.LyricsSection) ([]models.TranslatedVerse, error) {
+	checked := make([]models.TranslatedVerse, 0, len(verses))
+	seen := make(map[int]bool, len(verses))
+	for _, verse := range verses {
+		if verse.Position < 1 || verse.Position > len(sections) || seen[verse.Position] {
+			return nil, ErrInvalidVersePosition
+		}
+		seen[verse.Position] = true
+
+		text := strings.TrimSpace(verse.Text)
+		if text == "" {
+			return nil, ErrEmptyTranslation
+		}
+		checked = append(checked, models.TranslatedVerse{Position: verse.Position, Text: text})
+	}
+
+	sort.Slice(checked, func(i, j int) bool {
+		return checked[i].Position < checked[j].Position
+	})
+	return checked, nil
+}
@@ -0,0 +1,84 @@
+// trash_service.go
+package services
+
+import (
+	"errors"
+	"time"
+
+	"song_library/internal/models"
+	"song_library/internal/repositories"
+	"song_library/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+var ErrSongNotInTrash = errors.New("песни нет в корзине")
+
+// TrashService управляет корзиной: удалённые песни можно восстановить,
+// пока они не старше Retention, после чего их удаляет PurgeExpired.
+type TrashService struct {
+	SongRepo     repositories.SongStore
+	PlaylistRepo repositories.PlaylistStore
+	Tx           repositories.Transactor
+	// Retention - срок хранения в корзине; 0 отключает окончательное удаление
+	Retention time.Duration
+}
+
+func NewTrashService(songRepo repositories.SongStore, playlistRepo repositories.PlaylistStore, tx repositories.Transactor, retention time.Duration) *TrashService {
+	return &TrashService{
+		SongRepo:     songRepo,
+		PlaylistRepo: playlistRepo,
+		Tx:           tx,
+		Retention:    retention,
+	}
+}
+
+func (s *TrashService) GetTrash(pagination *utils.Pagination) (*models.TrashResponse, error) {
+	songs, total, err := s.SongRepo.GetTrash(pagination.GetOffset(), pagination.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.TrashResponse{
+		Songs: make([]models.TrashedSong, len(songs)),
+		Page:  pagination.Page,
+		Limit: pagination.Limit,
+		Total: total,
+	}
+	for i, song := range songs {
+		trashed := models.TrashedSong{Song: song, TrashedAt: song.DeletedAt.Time}
+		if s.Retention > 0 {
+			purgeAt := song.DeletedAt.Time.Add(s.Retention)
+			trashed.PurgeAt = &purgeAt
+		}
+		response.Songs[i] = trashed
+	}
+
+	return response, nil
+}
+
+// RestoreSong возвращает песню из корзины, и записи плейлистов снова на неё указывают.
+func (s *TrashService) RestoreSong(id uuid.UUID) (*models.Song, error) {
+	err := s.Tx.Transaction(func(stores *repositories.Stores) error {
+		if err := stores.Songs.Restore(id); err != nil {
+			return err
+		}
+		return stores.Playlists.ClearSongRemoved(id)
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSongNotInTrash
+		}
+		return nil, err
+	}
+
+	return s.SongRepo.GetByID(id)
+}
+
+// PurgeExpired окончательно удаляет песни, пролежавшие в корзине дольше Retention.
+func (s *TrashService) PurgeExpired() (int64, error) {
+	if s.Retention <= 0 {
+		return 0, nil
+	}
+	return s.SongRepo.Purge(time.Now().Add(-s.Retention))
+}
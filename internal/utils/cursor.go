@@ -0,0 +1,72 @@
+// cursor.go
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("некорректный курсор")
+
+// Cursors упаковывает позиции в списках в непрозрачные курсоры: JSON в base64 с подписью
+// HMAC-SHA256, чтобы клиент не мог подделать позицию в списке.
+type Cursors struct {
+	secret []byte
+}
+
+// NewCursors возвращает курсоры, подписанные ключом secret. Без ключа курсоры
+// подписываются случайным ключом процесса и перестают действовать после перезапуска.
+func NewCursors(secret string) *Cursors {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, sha256.Size)
+		if _, err := rand.Read(key); err != nil {
+			panic(err)
+		}
+	}
+	return &Cursors{
+		secret: key,
+	}
+}
+
+// Encode упаковывает value в курсор.
+func (c *Cursors) Encode(value any) (string, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(c.sign(payload)), nil
+}
+
+// Decode проверяет подпись курсора и распаковывает его в value.
+func (c *Cursors) Decode(token string, value any) error {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidCursor
+	}
+	encoding := base64.RawURLEncoding
+	payload, err := encoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	mac, err := encoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(payload)) {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(payload, value); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func (c *Cursors) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,94 @@
+// cursor_test.go
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type cursorKey struct {
+	C string `json:"c"`
+	I int    `json:"i"`
+}
+
+func TestCursorsRoundTrip(t *testing.T) {
+	cursors := NewCursors("secret")
+	want := cursorKey{C: "2024-01-02T10:00:00Z", I: 42}
+
+	token, err := cursors.Encode(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.ContainsAny(token, "+/=") {
+		t.Errorf("курсор %q небезопасен для адреса", token)
+	}
+
+	var got cursorKey
+	if err := cursors.Decode(token, &got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got != want {
+		t.Errorf("Decode = %+v, ожидалось %+v", got, want)
+	}
+}
+
+func TestCursorsDecodeInvalid(t *testing.T) {
+	cursors := NewCursors("secret")
+	valid, err := cursors.Encode(cursorKey{C: "2024-01-02T10:00:00Z", I: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, signature, _ := strings.Cut(valid, ".")
+	encoding := base64.RawURLEncoding
+
+	forged := encoding.EncodeToString([]byte(`{"c":"2024-01-02T10:00:00Z","i":2}`))
+	foreign, err := NewCursors("other").Encode(cursorKey{C: "2024-01-02T10:00:00Z", I: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notJSON := encoding.EncodeToString([]byte("not json"))
+	notJSONSigned := notJSON + "." + encoding.EncodeToString(cursors.sign([]byte("not json")))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "пустой", token: ""},
+		{name: "без подписи", token: payload},
+		{name: "подпись другого ключа", token: foreign},
+		{name: "подменённая позиция", token: forged + "." + signature},
+		{name: "обрезанная подпись", token: payload + "." + signature[:len(signature)-2]},
+		{name: "не base64", token: "!!!." + signature},
+		{name: "подписанный не JSON", token: notJSONSigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var key cursorKey
+			if err := cursors.Decode(tt.token, &key); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Decode(%q) = %v, ожидалась ErrInvalidCursor", tt.token, err)
+			}
+		})
+	}
+}
+
+func TestCursorsWithoutSecret(t *testing.T) {
+	cursors := NewCursors("")
+	token, err := cursors.Encode(cursorKey{I: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var key cursorKey
+	if err := cursors.Decode(token, &key); err != nil {
+		t.Errorf("Decode своего курсора: %v", err)
+	}
+	// Курсор пустого ключа не должен подходить к другому процессу или к пустой подписи
+	for _, other := range []*Cursors{NewCursors(""), {}} {
+		if err := other.Decode(token, &key); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Decode чужого курсора = %v, ожидалась ErrInvalidCursor", err)
+		}
+	}
+}
@@ -2,8 +2,9 @@
 package utils
 
 type HTTPError struct {
-	Status  int    `json:"status"`
-	Message string `json:"message"`
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
 }
 
 func NewHTTPError(status int, message string) *HTTPError {
@@ -12,3 +13,9 @@ func NewHTTPError(status int, message string) *HTTPError {
 		Message: message,
 	}
 }
+
+// WithDetails добавляет к ошибке список конкретных проблем в запросе.
+func (e *HTTPError) WithDetails(details []string) *HTTPError {
+	e.Details = details
+	return e
+}
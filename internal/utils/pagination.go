@@ -7,14 +7,23 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const DefaultLimit = 10
+
+// Pagination - страница списка: по номеру или, если задан курсор After или Before,
+// после или до элемента, на который указывает курсор. Номер страницы в режиме курсоров
+// не учитывается.
 type Pagination struct {
-	Page  int `json:"page"`
-	Limit int `json:"limit"`
+	Page   int    `json:"page"`
+	Limit  int    `json:"limit"`
+	After  string `json:"after,omitempty"`
+	Before string `json:"before,omitempty"`
 }
 
-func NewPaginationFromRequest(c *gin.Context) *Pagination {
+// NewPaginationFromRequest читает страницу из запроса. limit больше maxLimit уменьшается
+// до него, чтобы один запрос не читал всю библиотеку.
+func NewPaginationFromRequest(c *gin.Context, maxLimit int) *Pagination {
 	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "10")
+	limitStr := c.DefaultQuery("limit", strconv.Itoa(DefaultLimit))
 
 	page, err := strconv.Atoi(pageStr)
 	if err != nil || page < 1 {
@@ -23,15 +32,25 @@ func NewPaginationFromRequest(c *gin.Context) *Pagination {
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit < 1 {
-		limit = 10
+		limit = DefaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
 	}
 
 	return &Pagination{
-		Page:  page,
-		Limit: limit,
+		Page:   page,
+		Limit:  limit,
+		After:  c.Query("after"),
+		Before: c.Query("before"),
 	}
 }
 
+// Cursor сообщает, что страница выбирается по курсору, а не по номеру.
+func (p *Pagination) Cursor() bool {
+	return p.After != "" || p.Before != ""
+}
+
 func (p *Pagination) GetOffset() int {
 	return (p.Page - 1) * p.Limit
 }
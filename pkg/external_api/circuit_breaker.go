@@ -0,0 +1,79 @@
+// circuit_breaker.go
+package external_api
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen - внешний API недавно раз за разом не отвечал, и запрос
+// отклонён без обращения к нему.
+var ErrCircuitOpen = errors.New("внешний API временно недоступен")
+
+// circuitBreaker размыкается после threshold неудачных запросов подряд и
+// cooldown отклоняет запросы сразу. Затем пропускает один пробный запрос:
+// удачный замыкает цепь, неудачный размыкает её ещё на cooldown.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow сообщает, можно ли обратиться к внешнему API.
+func (b *circuitBreaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if b.probing || time.Now().Before(b.openUntil) {
+		return ErrCircuitOpen
+	}
+	b.probing = true
+	return nil
+}
+
+// record учитывает итог запроса; ok - внешний API ответил, пусть и 404.
+func (b *circuitBreaker) record(ok bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if ok {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// abandon завершает запрос, прерванный вызывающим: о внешнем API он ничего не сказал.
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
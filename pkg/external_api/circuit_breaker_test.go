@@ -0,0 +1,115 @@
+// circuit_breaker_test.go
+package external_api
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	// Шаги: allow - запрос пропущен (или отклонён ErrCircuitOpen), затем его итог
+	type step struct {
+		wait    time.Duration
+		allowed bool
+		// outcome: "ok", "fail", "abandon" или "" - запрос не выполнялся
+		outcome string
+	}
+
+	const cooldown = 20 * time.Millisecond
+	tests := []struct {
+		name      string
+		threshold int
+		steps     []step
+	}{
+		{
+			name:      "отключён",
+			threshold: 0,
+			steps: []step{
+				{allowed: true, outcome: "fail"},
+				{allowed: true, outcome: "fail"},
+				{allowed: true, outcome: "fail"},
+			},
+		},
+		{
+			name:      "размыкается после threshold сбоев подряд",
+			threshold: 2,
+			steps: []step{
+				{allowed: true, outcome: "fail"},
+				{allowed: true, outcome: "fail"},
+				{allowed: false},
+			},
+		},
+		{
+			name:      "удачный запрос сбрасывает счётчик",
+			threshold: 2,
+			steps: []step{
+				{allowed: true, outcome: "fail"},
+				{allowed: true, outcome: "ok"},
+				{allowed: true, outcome: "fail"},
+				{allowed: true, outcome: "ok"},
+			},
+		},
+		{
+			name:      "удачная проба замыкает цепь",
+			threshold: 1,
+			steps: []step{
+				{allowed: true, outcome: "fail"},
+				{allowed: false},
+				{wait: 2 * cooldown, allowed: true, outcome: "ok"},
+				{allowed: true, outcome: "ok"},
+			},
+		},
+		{
+			name:      "неудачная проба размыкает цепь снова",
+			threshold: 1,
+			steps: []step{
+				{allowed: true, outcome: "fail"},
+				{wait: 2 * cooldown, allowed: true, outcome: "fail"},
+				{allowed: false},
+			},
+		},
+		{
+			name:      "пока идёт проба, остальные запросы отклоняются",
+			threshold: 1,
+			steps: []step{
+				{allowed: true, outcome: "fail"},
+				{wait: 2 * cooldown, allowed: true},
+				{allowed: false},
+			},
+		},
+		{
+			name:      "прерванная проба не меняет состояние",
+			threshold: 1,
+			steps: []step{
+				{allowed: true, outcome: "fail"},
+				{wait: 2 * cooldown, allowed: true, outcome: "abandon"},
+				{allowed: true, outcome: "ok"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := newCircuitBreaker(tt.threshold, cooldown)
+			for i, step := range tt.steps {
+				time.Sleep(step.wait)
+				err := breaker.allow()
+				if allowed := err == nil; allowed != step.allowed {
+					t.Fatalf("шаг %d: запрос пропущен: %v, ожидалось %v", i, allowed, step.allowed)
+				}
+				if err != nil && !errors.Is(err, ErrCircuitOpen) {
+					t.Fatalf("шаг %d: ошибка %v, ожидалась ErrCircuitOpen", i, err)
+				}
+				switch step.outcome {
+				case "ok":
+					breaker.record(true)
+				case "fail":
+					breaker.record(false)
+				case "abandon":
+					breaker.abandon()
+				}
+			}
+		})
+	}
+}
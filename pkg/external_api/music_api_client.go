@@ -2,21 +2,60 @@
 package external_api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 )
 
+// ErrSongNotFound - внешний API ответил 404: такой песни он не знает.
+var ErrSongNotFound = errors.New("внешний API не знает такой песни")
+
+const (
+	// maxResponseSize ограничивает тело ответа: текст песни не бывает больше
+	maxResponseSize = 4 << 20
+	// maxRetryDelay ограничивает паузу перед повтором; если внешний API в Retry-After
+	// просит ждать дольше, повторять запрос будет уже очередь вызывающего
+	maxRetryDelay = time.Minute
+)
+
+// Options задаёт, сколько ждать внешний API и как переживать его сбои.
+// Нулевое значение поля отключает соответствующее ограничение.
+type Options struct {
+	// Timeout ограничивает запрос целиком, вместе с повторами и паузами между ними
+	Timeout time.Duration
+	// AttemptTimeout ограничивает одну попытку
+	AttemptTimeout time.Duration
+	// MaxRetries - сколько раз повторить запрос после ответа 5xx, 429 или сетевой ошибки
+	MaxRetries int
+	// RetryBackoff - пауза перед первым повтором; каждая следующая вдвое дольше
+	RetryBackoff time.Duration
+	// BreakerThreshold - после стольких неудачных запросов подряд клиент перестаёт
+	// обращаться к внешнему API на BreakerCooldown
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
 type MusicAPIClient struct {
 	BaseURL string
 	Client  *http.Client
+	Options Options
+
+	breaker *circuitBreaker
 }
 
-func NewMusicAPIClient(baseURL string) *MusicAPIClient {
+func NewMusicAPIClient(baseURL string, options Options) *MusicAPIClient {
 	return &MusicAPIClient{
 		BaseURL: baseURL,
 		Client:  &http.Client{},
+		Options: options,
+		breaker: newCircuitBreaker(options.BreakerThreshold, options.BreakerCooldown),
 	}
 }
 
@@ -24,9 +63,54 @@ type SongDetailResponse struct {
 	ReleaseDate string `json:"releaseDate"`
 	Text        string `json:"text"`
 	Link        string `json:"link"`
+	// Необязательные поля: внешний API может сообщить, с какого релиза песня
+	Album       string `json:"album"`
+	AlbumType   string `json:"albumType"`
+	DiscNumber  int    `json:"discNumber"`
+	TrackNumber int    `json:"trackNumber"`
+	// Raw - тело ответа как есть
+	Raw json.RawMessage `json:"-"`
+}
+
+// statusError - внешний API ответил неожиданным статусом.
+type statusError struct {
+	StatusCode int
+	// RetryAfter - через сколько внешний API просит повторить запрос
+	RetryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("внешний API вернул статус %d", e.StatusCode)
+}
+
+// retryable сообщает, стоит ли повторить запрос: 429, 5xx и ошибки сети
+// считаются временными, остальное повтор не исправит. Только временные ошибки
+// считаются сбоями внешнего API для circuitBreaker.
+func retryable(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	var decodeErr *decodeError
+	return !errors.As(err, &decodeErr)
 }
 
-func (client *MusicAPIClient) GetSongInfo(group, song string) (*SongDetailResponse, error) {
+// decodeError - внешний API ответил 200, но тело не разобрать.
+type decodeError struct {
+	Err error
+}
+
+func (e *decodeError) Error() string {
+	return "не удалось разобрать ответ внешнего API: " + e.Err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.Err
+}
+
+// GetSongInfo запрашивает данные песни, повторяя запрос при временных сбоях.
+// Пока внешний API недоступен, сразу возвращает ErrCircuitOpen.
+func (client *MusicAPIClient) GetSongInfo(ctx context.Context, group, song string) (*SongDetailResponse, error) {
 	endpoint, err := url.Parse(client.BaseURL)
 	if err != nil {
 		return nil, err
@@ -37,26 +121,133 @@ func (client *MusicAPIClient) GetSongInfo(group, song string) (*SongDetailRespon
 	queryParams.Set("song", song)
 	endpoint.RawQuery = queryParams.Encode()
 
-	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	if err := client.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	callerCtx := ctx
+	if client.Options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, client.Options.Timeout)
+		defer cancel()
+	}
+
+	songDetail, err := client.getWithRetries(ctx, endpoint.String())
+	switch {
+	case err == nil, errors.Is(err, ErrSongNotFound), !retryable(err):
+		// Внешний API ответил - значит, он доступен, даже если отклонил запрос с 4xx
+		client.breaker.record(true)
+	case callerCtx.Err() != nil:
+		// Запрос отменил вызывающий, а не оборвал таймаут
+		client.breaker.abandon()
+	default:
+		client.breaker.record(false)
+	}
+	return songDetail, err
+}
+
+func (client *MusicAPIClient) getWithRetries(ctx context.Context, endpoint string) (*SongDetailResponse, error) {
+	for attempt := 0; ; attempt++ {
+		songDetail, err := client.get(ctx, endpoint)
+		if err == nil || errors.Is(err, ErrSongNotFound) || !retryable(err) {
+			return songDetail, err
+		}
+		if attempt >= client.Options.MaxRetries || ctx.Err() != nil {
+			return nil, err
+		}
+
+		delay := client.retryDelay(attempt)
+		var statusErr *statusError
+		if errors.As(err, &statusErr) && statusErr.RetryAfter > delay {
+			if statusErr.RetryAfter > maxRetryDelay {
+				return nil, err
+			}
+			delay = statusErr.RetryAfter
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			// Повтор не успеет до конца отведённого времени
+			return nil, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay возвращает случайную паузу от половины до полной экспоненциальной,
+// чтобы клиенты, получившие ошибку одновременно, не повторяли запрос хором.
+// Экспоненциальная пауза не превышает maxRetryDelay.
+func (client *MusicAPIClient) retryDelay(attempt int) time.Duration {
+	delay := client.Options.RetryBackoff
+	if delay <= 0 {
+		return 0
+	}
+	for i := 0; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxRetryDelay)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// get выполняет одну попытку запроса.
+func (client *MusicAPIClient) get(ctx context.Context, endpoint string) (*SongDetailResponse, error) {
+	if client.Options.AttemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, client.Options.AttemptTimeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resp, err := client.Client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("не удалось обратиться к внешнему API: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrSongNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("внешний API вернул статус %d", resp.StatusCode)
+		return nil, &statusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось дочитать ответ внешнего API: %w", err)
 	}
 
 	var songDetail SongDetailResponse
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(&songDetail); err != nil {
-		return nil, err
+	if err := json.Unmarshal(body, &songDetail); err != nil {
+		return nil, &decodeError{Err: err}
 	}
+	songDetail.Raw = body
 
 	return &songDetail, nil
 }
+
+// parseRetryAfter разбирает заголовок Retry-After: число секунд или дату HTTP.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		// Огромное число секунд переполнило бы time.Duration; больше maxRetryDelay всё равно не ждём
+		return time.Duration(min(seconds, int(maxRetryDelay/time.Second)+1)) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+// music_api_client_test.go
+package external_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{value: "", min: 0, max: 0},
+		{value: "0", min: 0, max: 0},
+		{value: "-5", min: 0, max: 0},
+		{value: "abc", min: 0, max: 0},
+		{value: "3", min: 3 * time.Second, max: 3 * time.Second},
+		{value: "99999999999999", min: maxRetryDelay, max: maxRetryDelay + time.Second},
+		{value: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), min: 0, max: 0},
+		{value: time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat), min: 28 * time.Second, max: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		got := parseRetryAfter(tt.value)
+		if got < tt.min || got > tt.max {
+			t.Errorf("parseRetryAfter(%q) = %v, ожидалось от %v до %v", tt.value, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		backoff time.Duration
+		attempt int
+		full    time.Duration
+	}{
+		{backoff: 0, attempt: 3, full: 0},
+		{backoff: 100 * time.Millisecond, attempt: 0, full: 100 * time.Millisecond},
+		{backoff: 100 * time.Millisecond, attempt: 3, full: 800 * time.Millisecond},
+		{backoff: 100 * time.Millisecond, attempt: 20, full: maxRetryDelay},
+		{backoff: time.Second, attempt: 70, full: maxRetryDelay},
+		{backoff: time.Hour, attempt: 0, full: maxRetryDelay},
+	}
+
+	for _, tt := range tests {
+		client := NewMusicAPIClient("", Options{RetryBackoff: tt.backoff})
+		for range 20 {
+			got := client.retryDelay(tt.attempt)
+			if got < tt.full/2 || got > tt.full {
+				t.Fatalf("retryDelay(%d) с паузой %v = %v, ожидалось от %v до %v", tt.attempt, tt.backoff, got, tt.full/2, tt.full)
+			}
+		}
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: &statusError{StatusCode: http.StatusBadRequest}, want: false},
+		{err: &statusError{StatusCode: http.StatusUnauthorized}, want: false},
+		{err: &statusError{StatusCode: http.StatusTooManyRequests}, want: true},
+		{err: &statusError{StatusCode: http.StatusBadGateway}, want: true},
+		{err: fmt.Errorf("обёртка: %w", &statusError{StatusCode: http.StatusServiceUnavailable}), want: true},
+		{err: &decodeError{Err: errors.New("bad json")}, want: false},
+		{err: errors.New("connection refused"), want: true},
+	}
+
+	for _, tt := range tests {
+		if got := retryable(tt.err); got != tt.want {
+			t.Errorf("retryable(%v) = %v, ожидалось %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestGetSongInfoBreaker(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		wantOpen bool
+	}{
+		{name: "404 - API доступен", status: http.StatusNotFound, wantOpen: false},
+		{name: "400 - API доступен", status: http.StatusBadRequest, wantOpen: false},
+		{name: "401 - API доступен", status: http.StatusUnauthorized, wantOpen: false},
+		{name: "429 - сбой", status: http.StatusTooManyRequests, wantOpen: true},
+		{name: "503 - сбой", status: http.StatusServiceUnavailable, wantOpen: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewMusicAPIClient(server.URL, Options{BreakerThreshold: 2, BreakerCooldown: time.Minute})
+			for range 2 {
+				if _, err := client.GetSongInfo(context.Background(), "group", "song"); err == nil {
+					t.Fatal("ожидалась ошибка")
+				}
+			}
+
+			_, err := client.GetSongInfo(context.Background(), "group", "song")
+			if open := errors.Is(err, ErrCircuitOpen); open != tt.wantOpen {
+				t.Errorf("цепь разомкнута: %v, ожидалось %v (ошибка %v)", open, tt.wantOpen, err)
+			}
+		})
+	}
+}